@@ -54,11 +54,12 @@ func loadProjectsForSelection() ([]status.ProjectDisplay, error) {
 	opts := status.Options{
 		CheckDirty: false, // Not needed for cd operation
 		LoadBranch: false, // Not needed for cd operation
+		// Filter by default root to reduce clutter
+		Filter: status.Filter{Workspace: application.Config.GetDefaultRoot()},
+		Sort:   status.SortName,
 	}
 
-	// Filter by default root to reduce clutter
-	defaultRoot := application.Config.GetDefaultRoot()
-	rawProjects, err := application.Status.GetAll(opts, defaultRoot)
+	rawProjects, err := application.Status.GetAll(opts)
 	if err != nil {
 		return nil, err
 	}
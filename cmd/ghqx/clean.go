@@ -26,10 +26,14 @@ func runClean(cmd *cobra.Command, args []string) error {
 
 	// Load the app to get config, but handle errors gracefully
 	// as the config file might not even exist.
-	loadedApp, err := app.NewFromConfigPath(configPath)
+	loadedApp, err := app.NewFromConfigPath(configPath, profileName)
 	if err == nil {
 		fmt.Println("\n" + i18n.T("clean.warning.targetRoots"))
 		for name, path := range loadedApp.Config.Roots {
+			if loadedApp.Config.IsReadOnly(name) {
+				fmt.Printf("- %s (%s) %s\n", name, path, i18n.T("clean.warning.readOnly"))
+				continue
+			}
 			fmt.Printf("- %s (%s)\n", name, path)
 		}
 	} else {
@@ -56,6 +60,11 @@ func runClean(cmd *cobra.Command, args []string) error {
 		fmt.Println(i18n.T("clean.deleting.roots"))
 		for name, path := range loadedApp.Config.Roots {
 			fmt.Printf("  - %s (%s)... ", name, path)
+			// Read-only roots are never deleted
+			if loadedApp.Config.IsReadOnly(name) {
+				fmt.Println(i18n.T("clean.deleting.readOnly"))
+				continue
+			}
 			if err := os.RemoveAll(path); err != nil {
 				fmt.Println(ui.FormatError(err))
 			} else {
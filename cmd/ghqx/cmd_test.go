@@ -10,26 +10,6 @@ import (
 	"github.com/mi8bi/ghqx/internal/status"
 )
 
-func TestExtractRepoNameAndContains(t *testing.T) {
-	cases := map[string]string{
-		"github.com/user/repo": "repo",
-		"user/repo":            "repo",
-		"simple":               "simple",
-	}
-	for in, want := range cases {
-		if got := extractRepoName(in); got != want {
-			t.Fatalf("extractRepoName(%q) = %q, want %q", in, got, want)
-		}
-	}
-
-	if !contains("a/b/c", "b/c") {
-		t.Fatalf("contains should detect suffix with slash")
-	}
-	if contains("abc", "bc") {
-		t.Fatalf("contains should require slash boundary")
-	}
-}
-
 func TestPadRightAndTruncate(t *testing.T) {
 	s := "hello"
 	p := padRight(s, 10)
@@ -75,7 +55,7 @@ func TestOutputTablesAndCheckRepositoryExists(t *testing.T) {
 
 	// Now check repository exists
 	got := checkRepositoryExists("github.com/user/repo")
-	if got == "" {
+	if got == nil {
 		t.Fatalf("expected checkRepositoryExists to find repo")
 	}
 }
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/mi8bi/ghqx/internal/status"
+	"github.com/spf13/cobra"
+)
+
+// completeProjectNames completes project names for shell completion.
+// Projects come from the project index, so completion does not walk the roots
+// unless a root changed since it was last scanned.
+func completeProjectNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if err := loadApp(); err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	projects, err := application.Status.GetAll(status.Options{Sort: status.SortName})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	seen := make(map[string]bool)
+	var names []string
+	for _, p := range projects {
+		if strings.HasPrefix(p.Name, toComplete) && !seen[p.Name] {
+			seen[p.Name] = true
+			names = append(names, p.Name)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeWorkspaces completes configured workspace (root) names.
+func completeWorkspaces(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if err := loadApp(); err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []string
+	for name := range application.Config.Roots {
+		if strings.HasPrefix(name, toComplete) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
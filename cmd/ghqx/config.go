@@ -2,12 +2,16 @@ package main
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/mi8bi/ghqx/internal/config"
 	"github.com/mi8bi/ghqx/internal/domain"
+	"github.com/mi8bi/ghqx/internal/ghq"
 	"github.com/mi8bi/ghqx/internal/i18n" // Add this import
 	configtui "github.com/mi8bi/ghqx/internal/tui/config"
 	"github.com/mi8bi/ghqx/internal/ui"
@@ -16,6 +20,7 @@ import (
 
 var (
 	configInitYes bool
+	configGetJSON bool
 )
 
 var configCmd = &cobra.Command{
@@ -44,10 +49,105 @@ var configEditCmd = &cobra.Command{
 	RunE:  runConfigEdit,
 }
 
+var configMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "", // Will be set in root.go init() after locale is determined
+	Long:  "", // Will be set in root.go init() after locale is determined
+	Args:  cobra.NoArgs,
+	RunE:  runConfigMigrate,
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "", // Will be set in root.go init() after locale is determined
+	Long:  "", // Will be set in root.go init() after locale is determined
+	Args:  cobra.NoArgs,
+	RunE:  runConfigValidate,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get [key]",
+	Short: "", // Will be set in root.go init() after locale is determined
+	Long:  "", // Will be set in root.go init() after locale is determined
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runConfigGet,
+
+	ValidArgsFunction: completeConfigKeys,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "", // Will be set in root.go init() after locale is determined
+	Long:  "", // Will be set in root.go init() after locale is determined
+	Args:  cobra.ExactArgs(2),
+	RunE:  runConfigSet,
+
+	ValidArgsFunction: completeConfigKeys,
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "", // Will be set in root.go init() after locale is determined
+	Long:  "", // Will be set in root.go init() after locale is determined
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConfigUnset,
+
+	ValidArgsFunction: completeConfigKeys,
+}
+
+var configAddRootCmd = &cobra.Command{
+	Use:   "add-root <name> <path>",
+	Short: "", // Will be set in root.go init() after locale is determined
+	Long:  "", // Will be set in root.go init() after locale is determined
+	Args:  cobra.ExactArgs(2),
+	RunE:  runConfigAddRoot,
+
+	ValidArgsFunction: completeAddRootArgs,
+}
+
+var configRemoveRootCmd = &cobra.Command{
+	Use:   "remove-root <name>",
+	Short: "", // Will be set in root.go init() after locale is determined
+	Long:  "", // Will be set in root.go init() after locale is determined
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConfigRemoveRoot,
+
+	ValidArgsFunction: completeWorkspaces,
+}
+
+var configHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "", // Will be set in root.go init() after locale is determined
+	Long:  "", // Will be set in root.go init() after locale is determined
+	Args:  cobra.NoArgs,
+	RunE:  runConfigHistory,
+}
+
+var configRollbackCmd = &cobra.Command{
+	Use:   "rollback [number]",
+	Short: "", // Will be set in root.go init() after locale is determined
+	Long:  "", // Will be set in root.go init() after locale is determined
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runConfigRollback,
+
+	ValidArgsFunction: cobra.NoFileCompletions,
+}
+
 func init() {
 	configCmd.AddCommand(configInitCmd)
 	configCmd.AddCommand(configShowCmd)
 	configCmd.AddCommand(configEditCmd)
+	configCmd.AddCommand(configMigrateCmd)
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configUnsetCmd)
+	configCmd.AddCommand(configAddRootCmd)
+	configCmd.AddCommand(configRemoveRootCmd)
+	configCmd.AddCommand(configHistoryCmd)
+	configCmd.AddCommand(configRollbackCmd)
+
+	configGetCmd.Flags().BoolVar(&configGetJSON, "json", false, i18n.T("config.get.flag.json"))
 
 	configInitCmd.Flags().BoolVar(&configInitYes, "yes", false, i18n.T("config.init.flag.yes"))
 }
@@ -113,31 +213,266 @@ func runConfigShow(cmd *cobra.Command, args []string) error {
 
 	fmt.Println(i18n.T("config.show.title"))
 	fmt.Println("==================")
+	fmt.Printf(i18n.T("config.show.profile")+"\n", application.Config.ActiveProfile())
 	printConfigSummary(application.Config)
 
 	return nil
 }
 
 func runConfigEdit(cmd *cobra.Command, args []string) error {
-	loader := config.NewLoader()
+	loader := newLoader()
 
-	// Load existing config
-	cfg, err := loader.Load(configPath)
+	// Save back to the file that was loaded, wherever it was found
+	path, err := loader.Path(configPath)
+	if err != nil {
+		return err
+	}
+	cfg, err := loader.Load(path)
 	if err != nil {
 		return err
 	}
 
-	// Determine config path for saving
-	savePath := configPath
-	if savePath == "" {
-		savePath, err = config.GetDefaultConfigPath()
+	// Launch TUI editor
+	return configtui.Run(cfg, path)
+}
+
+// runConfigMigrate rewrites the config file in the current schema, keeping a backup of the original.
+func runConfigMigrate(cmd *cobra.Command, args []string) error {
+	result, err := newLoader().Migrate(configPath)
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	if !result.Migrated() {
+		fmt.Fprint(w, ui.FormatInfo(fmt.Sprintf(i18n.T("config.migrate.upToDate"), result.Path, config.SchemaVersion)))
+		return nil
+	}
+
+	fmt.Fprint(w, ui.FormatSuccess(fmt.Sprintf(i18n.T("config.migrate.success"), result.Path, result.FromVersion, config.SchemaVersion)))
+	fmt.Fprint(w, ui.FormatInfo(fmt.Sprintf(i18n.T("config.migrate.backup"), result.Backup)))
+	return nil
+}
+
+// runConfigValidate reports every problem of the config file and its roots, with a hint for each.
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	path, problems, err := newLoader().Diagnose(configPath, ghq.Roots())
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	if len(problems) == 0 {
+		fmt.Fprint(w, ui.FormatSuccess(fmt.Sprintf(i18n.T("config.validate.ok"), path)))
+		return nil
+	}
+
+	for _, problem := range problems {
+		fmt.Fprint(w, ui.FormatFailure(problem.Message))
+		if problem.Hint != "" {
+			fmt.Fprintf(w, "    %s: %s\n", i18n.T("config.validate.hint"), problem.Hint)
+		}
+	}
+	return domain.ErrConfigValidateFailed(path, len(problems))
+}
+
+// runConfigGet prints the value of a key, or every key that is set when no key is given.
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	if err := loadApp(); err != nil {
+		return err
+	}
+
+	var value any
+	if len(args) == 1 {
+		v, err := application.Config.Get(args[0])
 		if err != nil {
 			return err
 		}
+		value = v
+	} else {
+		value = application.Config.Values()
 	}
 
-	// Launch TUI editor
-	return configtui.Run(cfg, savePath)
+	w := cmd.OutOrStdout()
+	if configGetJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(value)
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(w, "%s=%s\n", key, formatConfigValue(v[key]))
+		}
+	case []string:
+		// One item per line, so lists are easy to read from scripts
+		for _, item := range v {
+			fmt.Fprintln(w, item)
+		}
+	default:
+		fmt.Fprintln(w, formatConfigValue(v))
+	}
+	return nil
+}
+
+// formatConfigValue formats a value the way `config set` accepts it.
+func formatConfigValue(value any) string {
+	if items, ok := value.([]string); ok {
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprint(value)
+}
+
+// runConfigSet stores a value under a key, validating the result before saving.
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	key, value := args[0], args[1]
+
+	var updated *config.Config
+	if _, err := newLoader().Update(configPath, func(cfg *config.Config) error {
+		updated = cfg
+		return cfg.Set(key, value)
+	}); err != nil {
+		return err
+	}
+	// Setting a root's path may add the root, whose directory is created like add-root does
+	if err := config.EnsureRootDirectories(updated); err != nil {
+		return err
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), ui.FormatSuccess(fmt.Sprintf(i18n.T("config.set.success"), key, value)))
+	return nil
+}
+
+// runConfigUnset resets a key to its default.
+func runConfigUnset(cmd *cobra.Command, args []string) error {
+	key := args[0]
+	if _, err := newLoader().Update(configPath, func(cfg *config.Config) error {
+		return cfg.Unset(key)
+	}); err != nil {
+		return err
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), ui.FormatSuccess(fmt.Sprintf(i18n.T("config.unset.success"), key)))
+	return nil
+}
+
+// runConfigAddRoot adds a root and creates its directory.
+func runConfigAddRoot(cmd *cobra.Command, args []string) error {
+	name, path := args[0], args[1]
+
+	var updated *config.Config
+	if _, err := newLoader().Update(configPath, func(cfg *config.Config) error {
+		updated = cfg
+		return cfg.AddRoot(name, path)
+	}); err != nil {
+		return err
+	}
+	if err := config.EnsureRootDirectories(updated); err != nil {
+		return err
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), ui.FormatSuccess(fmt.Sprintf(i18n.T("config.addRoot.success"), name, path)))
+	return nil
+}
+
+// runConfigRemoveRoot removes a root from the configuration, leaving its directory in place.
+func runConfigRemoveRoot(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	var path string
+	if _, err := newLoader().Update(configPath, func(cfg *config.Config) error {
+		path = cfg.Roots[name]
+		return cfg.RemoveRoot(name)
+	}); err != nil {
+		return err
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), ui.FormatSuccess(fmt.Sprintf(i18n.T("config.removeRoot.success"), name, path)))
+	return nil
+}
+
+// runConfigHistory lists the backups of the config file, newest first.
+func runConfigHistory(cmd *cobra.Command, args []string) error {
+	path, backups, err := newLoader().History(configPath)
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	if len(backups) == 0 {
+		fmt.Fprint(w, ui.FormatInfo(fmt.Sprintf(i18n.T("config.history.empty"), path)))
+		return nil
+	}
+
+	fmt.Fprintf(w, i18n.T("config.history.header")+"\n", path)
+	for i, backup := range backups {
+		fmt.Fprintf(w, "%3d  %s  %s\n", i+1, backup.Time.Format("2006-01-02 15:04:05"), backup.Path)
+	}
+	return nil
+}
+
+// runConfigRollback restores the config file from a backup (the newest by default).
+func runConfigRollback(cmd *cobra.Command, args []string) error {
+	loader := newLoader()
+	n := 1
+	if len(args) == 1 {
+		var err error
+		if n, err = strconv.Atoi(args[0]); err != nil {
+			path, backups, historyErr := loader.History(configPath)
+			if historyErr != nil {
+				return historyErr
+			}
+			if len(backups) == 0 {
+				return domain.ErrConfigNoHistory(path)
+			}
+			return domain.ErrConfigBackupNotFound(args[0], len(backups))
+		}
+	}
+
+	backup, err := loader.Rollback(configPath, n)
+	if err != nil {
+		return err
+	}
+
+	path, _, _ := loader.History(configPath)
+	fmt.Fprint(cmd.OutOrStdout(), ui.FormatSuccess(fmt.Sprintf(i18n.T("config.rollback.success"), path, backup.Time.Format("2006-01-02 15:04:05"))))
+	return nil
+}
+
+// completeConfigKeys completes config keys, and paths for the value of path keys.
+func completeConfigKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 1 {
+		// roots.<name> and roots.<name>.path take a directory
+		if key := args[0]; strings.HasPrefix(key, "roots.") && (strings.HasSuffix(key, ".path") || strings.Count(key, ".") == 1) {
+			return nil, cobra.ShellCompDirectiveFilterDirs
+		}
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	if len(args) > 1 || loadApp() != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var keys []string
+	for _, key := range application.Config.Keys() {
+		if strings.HasPrefix(key, toComplete) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeAddRootArgs leaves the name to the user and completes directories for the path.
+func completeAddRootArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 1 {
+		return nil, cobra.ShellCompDirectiveFilterDirs
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
 }
 
 // promptForConfig は対話的に設定を入力する
@@ -157,14 +492,15 @@ func promptForConfig() (*config.Config, error) {
 	// Roots
 	fmt.Println(i18n.T("config.prompt.section.roots"))
 
+	// Paths may use ~ and environment variables; they are saved as typed
 	devPath := promptWithDefault(reader, i18n.T("config.prompt.path.dev"), defaults.Roots["dev"])
-	cfg.Roots["dev"] = devPath
+	cfg.SetRootPath("dev", devPath)
 
 	releasePath := promptWithDefault(reader, i18n.T("config.prompt.path.release"), defaults.Roots["release"])
-	cfg.Roots["release"] = releasePath
+	cfg.SetRootPath("release", releasePath)
 
 	sandboxPath := promptWithDefault(reader, i18n.T("config.prompt.path.sandbox"), defaults.Roots["sandbox"])
-	cfg.Roots["sandbox"] = sandboxPath
+	cfg.SetRootPath("sandbox", sandboxPath)
 
 	fmt.Println()
 
@@ -193,8 +529,18 @@ func promptWithDefault(reader *bufio.Reader, prompt, defaultValue string) string
 // printConfigSummary は設定の要約を表示する
 func printConfigSummary(cfg *config.Config) {
 	fmt.Println("\n" + i18n.T("config.summary.section.roots"))
-	for name, path := range cfg.Roots {
-		fmt.Printf("  %-10s = %s\n", name, path)
+	for _, root := range cfg.Workspaces() {
+		raw := cfg.RawRootPath(string(root.Name))
+		line := fmt.Sprintf("  %-10s = %s  [%s]", root.Name, raw, root.WorkspaceType)
+		if root.Description != "" {
+			line += " " + root.Description
+		}
+		fmt.Println(line)
+
+		// Paths written with ~ or variables also show where they point
+		if raw != root.Path {
+			fmt.Printf("  %-10s   -> %s\n", "", root.Path)
+		}
 	}
 
 	fmt.Println("\n" + i18n.T("config.summary.section.default"))
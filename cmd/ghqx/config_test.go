@@ -2,6 +2,8 @@ package main
 
 import (
 	"bufio"
+	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -9,6 +11,8 @@ import (
 	"testing"
 
 	"github.com/mi8bi/ghqx/internal/config"
+	"github.com/mi8bi/ghqx/internal/domain"
+	"github.com/spf13/cobra"
 )
 
 func TestRunConfigInitWithYesFlag(t *testing.T) {
@@ -222,3 +226,242 @@ func TestPromptForConfig(t *testing.T) {
 		t.Errorf("expected 'default', got %q", result)
 	}
 }
+
+func TestRunConfigMigrate(t *testing.T) {
+	tmp := t.TempDir()
+	cfgPath := filepath.Join(tmp, "config.toml")
+	if err := os.WriteFile(cfgPath, []byte("[roots]\ndev = \"/tmp/dev\"\n"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	oldConfigPath := configPath
+	configPath = cfgPath
+	defer func() { configPath = oldConfigPath }()
+
+	var out strings.Builder
+	configMigrateCmd.SetOut(&out)
+	defer configMigrateCmd.SetOut(nil)
+
+	if err := runConfigMigrate(configMigrateCmd, nil); err != nil {
+		t.Fatalf("runConfigMigrate failed: %v", err)
+	}
+	if !strings.Contains(out.String(), cfgPath+".v1.bak") {
+		t.Errorf("output should name the backup: %q", out.String())
+	}
+
+	cfg, err := config.NewLoader().Load(cfgPath)
+	if err != nil || cfg.Version != config.SchemaVersion {
+		t.Fatalf("expected a version %d config, got %+v (%v)", config.SchemaVersion, cfg, err)
+	}
+}
+
+func TestRunConfigSetGet(t *testing.T) {
+	tmp := t.TempDir()
+	cfgPath := filepath.Join(tmp, "config.toml")
+	cfg := &config.Config{
+		Version: config.SchemaVersion,
+		Roots:   map[string]string{"dev": filepath.Join(tmp, "dev")},
+		Default: config.DefaultConfig{Root: "dev"},
+	}
+	if err := config.NewLoader().Save(cfg, cfgPath); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	oldConfigPath := configPath
+	configPath = cfgPath
+	defer func() { configPath = oldConfigPath }()
+
+	var out strings.Builder
+	for _, cmd := range []*cobra.Command{configSetCmd, configGetCmd, configAddRootCmd, configRemoveRootCmd} {
+		cmd.SetOut(&out)
+		defer cmd.SetOut(nil)
+	}
+
+	if err := runConfigSet(configSetCmd, []string{"roots.dev.layout", "flat"}); err != nil {
+		t.Fatalf("runConfigSet failed: %v", err)
+	}
+	if err := runConfigSet(configSetCmd, []string{"roots.dev.layout", "spiral"}); err == nil {
+		t.Error("invalid values should be rejected")
+	}
+
+	out.Reset()
+	if err := runConfigGet(configGetCmd, []string{"roots.dev.layout"}); err != nil {
+		t.Fatalf("runConfigGet failed: %v", err)
+	}
+	if out.String() != "flat\n" {
+		t.Errorf("get output = %q, want %q", out.String(), "flat\n")
+	}
+
+	oldJSON := configGetJSON
+	configGetJSON = true
+	defer func() { configGetJSON = oldJSON }()
+
+	out.Reset()
+	if err := runConfigGet(configGetCmd, nil); err != nil {
+		t.Fatalf("runConfigGet --json failed: %v", err)
+	}
+	var values map[string]any
+	if err := json.Unmarshal([]byte(out.String()), &values); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out.String(), err)
+	}
+	if values["roots.dev.layout"] != "flat" || values["default.root"] != "dev" {
+		t.Errorf("unexpected values: %v", values)
+	}
+
+	workPath := filepath.Join(tmp, "work")
+	if err := runConfigAddRoot(configAddRootCmd, []string{"work", workPath}); err != nil {
+		t.Fatalf("runConfigAddRoot failed: %v", err)
+	}
+	if _, err := os.Stat(workPath); err != nil {
+		t.Errorf("root directory was not created: %v", err)
+	}
+	if err := runConfigRemoveRoot(configRemoveRootCmd, []string{"work"}); err != nil {
+		t.Fatalf("runConfigRemoveRoot failed: %v", err)
+	}
+	if _, err := os.Stat(workPath); err != nil {
+		t.Errorf("root directory should be kept: %v", err)
+	}
+
+	loaded, err := config.NewLoader().Load(cfgPath)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if _, ok := loaded.Roots["work"]; ok || loaded.RootLayout("dev") != domain.LayoutFlat {
+		t.Errorf("unexpected config: %+v", loaded)
+	}
+}
+
+func TestRunConfigValidate(t *testing.T) {
+	tmp := t.TempDir()
+	cfgPath := filepath.Join(tmp, "config.toml")
+	devPath := filepath.Join(tmp, "dev")
+	cfg := &config.Config{
+		Version: config.SchemaVersion,
+		Roots:   map[string]string{"dev": devPath, "nested": filepath.Join(devPath, "nested")},
+		Default: config.DefaultConfig{Root: "dev"},
+	}
+	if err := config.NewLoader().Save(cfg, cfgPath); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	oldConfigPath := configPath
+	configPath = cfgPath
+	defer func() { configPath = oldConfigPath }()
+
+	var out strings.Builder
+	configValidateCmd.SetOut(&out)
+	defer configValidateCmd.SetOut(nil)
+
+	// Both roots are missing and they overlap
+	err := runConfigValidate(configValidateCmd, nil)
+	if err == nil {
+		t.Fatal("expected validation to fail")
+	}
+	if got := strings.Count(out.String(), "\n    "); got != 3 {
+		t.Errorf("expected 3 problems with hints, got %d:\n%s", got, out.String())
+	}
+
+	// --strict refuses to load the same config
+	strictConfig = true
+	defer func() { strictConfig = false }()
+	if err := loadApp(); err == nil {
+		t.Error("strict loading should fail")
+	}
+
+	cfg.Roots = map[string]string{"dev": devPath}
+	if err := config.NewLoader().Save(cfg, cfgPath); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if err := os.MkdirAll(devPath, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	out.Reset()
+	if err := runConfigValidate(configValidateCmd, nil); err != nil {
+		t.Fatalf("expected a valid config, got %v\n%s", err, out.String())
+	}
+	if err := loadApp(); err != nil {
+		t.Errorf("strict loading failed on a valid config: %v", err)
+	}
+}
+
+func TestPrintConfigSummaryShowsResolvedPaths(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	cfg := &config.Config{Default: config.DefaultConfig{Root: "dev"}}
+	cfg.SetRootPath("dev", "~/src/dev")
+	cfg.SetRootPath("sandbox", "/tmp/sandbox")
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	printConfigSummary(cfg)
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	output, _ := ioutil.ReadAll(r)
+	outputStr := string(output)
+
+	if !strings.Contains(outputStr, "~/src/dev") {
+		t.Errorf("printConfigSummary should show the raw path:\n%s", outputStr)
+	}
+	if !strings.Contains(outputStr, "-> "+filepath.Join(home, "src", "dev")) {
+		t.Errorf("printConfigSummary should show the resolved path:\n%s", outputStr)
+	}
+	if strings.Count(outputStr, "->") != 1 {
+		t.Errorf("only expanded paths should show a resolved path:\n%s", outputStr)
+	}
+}
+
+func TestRunConfigHistoryRollback(t *testing.T) {
+	tmp := t.TempDir()
+	cfgPath := filepath.Join(tmp, "config.toml")
+	original := "# keep me\nversion = 2\n\n[default]\nroot = \"dev\"\n\n[roots.dev]\npath = \"" + filepath.ToSlash(filepath.Join(tmp, "dev")) + "\"\n"
+	if err := os.WriteFile(cfgPath, []byte(original), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	oldConfigPath := configPath
+	configPath = cfgPath
+	defer func() { configPath = oldConfigPath }()
+
+	var out strings.Builder
+	for _, cmd := range []*cobra.Command{configSetCmd, configHistoryCmd, configRollbackCmd} {
+		cmd.SetOut(&out)
+		defer cmd.SetOut(nil)
+	}
+
+	if err := runConfigHistory(configHistoryCmd, nil); err != nil {
+		t.Fatalf("runConfigHistory failed: %v", err)
+	}
+	if err := runConfigSet(configSetCmd, []string{"roots.dev.layout", "flat"}); err != nil {
+		t.Fatalf("runConfigSet failed: %v", err)
+	}
+	data, _ := os.ReadFile(cfgPath)
+	if !strings.HasPrefix(string(data), "# keep me\n") || !strings.Contains(string(data), "layout = \"flat\"") {
+		t.Errorf("config set should keep comments:\n%s", data)
+	}
+
+	out.Reset()
+	if err := runConfigHistory(configHistoryCmd, nil); err != nil {
+		t.Fatalf("runConfigHistory failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "  1  ") || strings.Contains(out.String(), "  2  ") {
+		t.Errorf("expected one backup:\n%s", out.String())
+	}
+
+	err := runConfigRollback(configRollbackCmd, []string{"two"})
+	var ghqxErr *domain.GhqxError
+	if !errors.As(err, &ghqxErr) || ghqxErr.Hint != domain.ErrConfigBackupNotFound("two", 1).Hint {
+		t.Errorf("non-numeric backups should be rejected with the backup count, got %v", err)
+	}
+	if err := runConfigRollback(configRollbackCmd, nil); err != nil {
+		t.Fatalf("runConfigRollback failed: %v", err)
+	}
+	if data, _ := os.ReadFile(cfgPath); string(data) != original {
+		t.Errorf("rollback should restore the original file:\n%s", data)
+	}
+}
@@ -17,7 +17,7 @@ var doctorCmd = &cobra.Command{
 }
 
 func runDoctor(cmd *cobra.Command, args []string) error {
-	doctorService := doctor.NewServiceWithConfigPath(configPath)
+	doctorService := doctor.NewServiceWithConfigPath(configPath).WithProfile(profileName)
 	results := doctorService.RunChecks()
 
 	allOK := true
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/mi8bi/ghqx/internal/domain"
+	"github.com/mi8bi/ghqx/internal/foreach"
+	"github.com/mi8bi/ghqx/internal/i18n"
+	"github.com/mi8bi/ghqx/internal/status"
+	"github.com/mi8bi/ghqx/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	execWorkspace string
+	execDirty     bool
+	execClean     bool
+	execGit       bool
+	execNoGit     bool
+	execQuery     string
+	execParallel  int
+	execKeepGoing bool
+)
+
+var execCmd = &cobra.Command{
+	Use:     "exec [flags] -- <command> [args...]",
+	Aliases: []string{"foreach"},
+	Short:   "", // Will be set in root.go init() after locale is determined
+	Long:    "", // Will be set in root.go init() after locale is determined
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    runExec,
+}
+
+func init() {
+	execCmd.Flags().StringVar(&execWorkspace, "workspace", "", i18n.T("exec.flag.workspace"))
+	execCmd.Flags().BoolVar(&execDirty, "dirty", false, i18n.T("exec.flag.dirty"))
+	execCmd.Flags().BoolVar(&execClean, "clean", false, i18n.T("exec.flag.clean"))
+	execCmd.Flags().BoolVar(&execGit, "git", false, i18n.T("exec.flag.git"))
+	execCmd.Flags().BoolVar(&execNoGit, "no-git", false, i18n.T("exec.flag.noGit"))
+	execCmd.Flags().StringVarP(&execQuery, "query", "q", "", i18n.T("exec.flag.query"))
+	execCmd.Flags().IntVarP(&execParallel, "parallel", "p", 1, i18n.T("exec.flag.parallel"))
+	execCmd.Flags().BoolVarP(&execKeepGoing, "keep-going", "k", false, i18n.T("exec.flag.keepGoing"))
+	_ = execCmd.RegisterFlagCompletionFunc("workspace", completeWorkspaces)
+	execCmd.MarkFlagsMutuallyExclusive("dirty", "clean")
+	execCmd.MarkFlagsMutuallyExclusive("git", "no-git")
+
+	// Everything after the first positional argument belongs to the command,
+	// so "ghqx exec git log -1" works without "--"
+	execCmd.Flags().SetInterspersed(false)
+}
+
+// runExec runs a command in every matching project and prints a summary.
+func runExec(cmd *cobra.Command, args []string) error {
+	if err := loadApp(); err != nil {
+		return err
+	}
+
+	service := foreach.NewService(application.Config, application.Status)
+	opts := foreach.Options{
+		Filter: status.Filter{
+			Workspace: execWorkspace,
+			Dirty:     execDirty,
+			Clean:     execClean,
+			Git:       execGit,
+			NoGit:     execNoGit,
+			Query:     execQuery,
+		},
+		Command:   args,
+		Parallel:  execParallel,
+		KeepGoing: execKeepGoing,
+		Stdout:    cmd.OutOrStdout(),
+		Stderr:    cmd.ErrOrStderr(),
+	}
+
+	results, err := service.Run(opts)
+	if err != nil {
+		return err
+	}
+
+	if len(results) == 0 {
+		fmt.Print(ui.FormatInfo(i18n.T("exec.noProjects")))
+		return nil
+	}
+
+	failed := printExecSummary(results)
+	if failed > 0 {
+		return domain.NewError(
+			domain.ErrCodeCommandFailed,
+			fmt.Sprintf(i18n.T("exec.failed"), failed, len(results)),
+		)
+	}
+
+	return nil
+}
+
+// printExecSummary lists every project whose command did not succeed
+// followed by the overall counts. It returns the number of failures.
+func printExecSummary(results []foreach.Result) int {
+	succeeded, failed, skipped := 0, 0, 0
+	for _, r := range results {
+		switch {
+		case r.Skipped:
+			skipped++
+		case r.Failed():
+			failed++
+		default:
+			succeeded++
+		}
+	}
+
+	fmt.Println()
+	for _, r := range results {
+		if !r.Failed() {
+			continue
+		}
+		if r.ExitCode >= 0 {
+			fmt.Print(ui.FormatFailure(fmt.Sprintf(i18n.T("exec.exitCode"), r.Project.DisplayName, r.ExitCode)))
+		} else {
+			fmt.Print(ui.FormatFailure(fmt.Sprintf(i18n.T("exec.startFailed"), r.Project.DisplayName, r.Err)))
+		}
+	}
+	fmt.Printf(i18n.T("exec.summary")+"\n", succeeded, failed, skipped)
+
+	return failed
+}
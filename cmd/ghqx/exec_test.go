@@ -0,0 +1,45 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mi8bi/ghqx/internal/domain"
+	"github.com/mi8bi/ghqx/internal/foreach"
+)
+
+func TestRunExecWithLoadAppError(t *testing.T) {
+	oldConfigPath := configPath
+	configPath = "/nonexistent/config.toml"
+	defer func() { configPath = oldConfigPath }()
+
+	if err := runExec(execCmd, []string{"true"}); err == nil {
+		t.Fatal("expected error when loadApp fails")
+	}
+}
+
+func TestPrintExecSummary(t *testing.T) {
+	results := []foreach.Result{
+		{Project: domain.Project{DisplayName: "user/a"}},
+		{Project: domain.Project{DisplayName: "user/b"}, ExitCode: 2, Err: errors.New("exit status 2")},
+		{Project: domain.Project{DisplayName: "user/c"}, ExitCode: -1, Err: errors.New("not found")},
+		{Project: domain.Project{DisplayName: "user/d"}, ExitCode: -1, Skipped: true},
+	}
+	if failed := printExecSummary(results); failed != 2 {
+		t.Errorf("expected 2 failures, got %d", failed)
+	}
+}
+
+func TestExecCmdStopsParsingFlagsAtCommand(t *testing.T) {
+	if err := execCmd.Flags().Parse([]string{"--dirty", "git", "log", "--oneline"}); err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	defer func() { execDirty = false }()
+
+	if !execDirty {
+		t.Error("expected --dirty to be parsed as ghqx flag")
+	}
+	if got := execCmd.Flags().Args(); len(got) != 3 || got[2] != "--oneline" {
+		t.Errorf("expected command flags to be passed through, got %v", got)
+	}
+}
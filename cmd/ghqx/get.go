@@ -1,8 +1,13 @@
 package main
 
 import (
+	"bufio"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
+	"github.com/mi8bi/ghqx/internal/domain"
 	"github.com/mi8bi/ghqx/internal/ghq"
 	"github.com/mi8bi/ghqx/internal/i18n"
 	"github.com/mi8bi/ghqx/internal/status"
@@ -12,19 +17,38 @@ import (
 
 var (
 	getTargetWorkspace string // Renamed from getZone
+	getListFile        string
+	getParallel        int
 )
 
 var getCmd = &cobra.Command{
-	Use:   "get <repository>",
+	Use:   "get <repository> | get -f <file> | get -",
 	Short: "", // Will be set in root.go init() after locale is determined
 	Long:  "", // Will be set in root.go init() after locale is determined
-	Args:  cobra.ExactArgs(1),
+	Args:  getArgs,
 	RunE:  runGet,
 }
 
 func init() {
 	// Renamed from --zone to --workspace, and getZone to getTargetWorkspace
 	getCmd.Flags().StringVar(&getTargetWorkspace, "workspace", "sandbox", i18n.T("get.flag.workspace"))
+	getCmd.Flags().StringVarP(&getListFile, "file", "f", "", i18n.T("get.flag.file"))
+	getCmd.Flags().IntVarP(&getParallel, "parallel", "p", 4, i18n.T("get.flag.parallel"))
+}
+
+// getArgs accepts at most one repository, and none when --file gives the list,
+// so a repository next to -f is refused rather than silently ignored.
+func getArgs(cmd *cobra.Command, args []string) error {
+	if err := cobra.MaximumNArgs(1)(cmd, args); err != nil {
+		return err
+	}
+	if len(args) > 0 && cmd.Flags().Changed("file") {
+		return domain.NewError(
+			domain.ErrCodeInvalidPath,
+			fmt.Sprintf(i18n.T("get.bulk.fileWithRepository"), args[0]),
+		).WithHint(i18n.T("get.bulk.fileWithRepositoryHint"))
+	}
+	return nil
 }
 
 func runGet(cmd *cobra.Command, args []string) error {
@@ -32,8 +56,6 @@ func runGet(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	repository := args[0]
-
 	// Determine the target workspace (Renamed from targetZone)
 	targetWorkspace := getTargetWorkspace
 	if !cmd.Flags().Changed("workspace") { // Changed flag name
@@ -41,11 +63,21 @@ func runGet(cmd *cobra.Command, args []string) error {
 		targetWorkspace = application.Config.GetDefaultRoot()
 	}
 
+	// Bulk mode: read repositories from a file or stdin
+	if getListFile != "" || (len(args) == 1 && args[0] == "-") {
+		return runBulkGet(cmd, targetWorkspace)
+	}
+
+	if len(args) != 1 {
+		return domain.ErrArgumentRequired
+	}
+	repository := args[0]
+
 	// 既に同じリポジトリが他のワークスペースに存在するかチェック (Renamed from zone)
-	if existingWorkspace := checkRepositoryExists(repository); existingWorkspace != "" {
+	if existing := checkRepositoryExists(repository); existing != nil {
 		fmt.Print(ui.FormatWarning(fmt.Sprintf(
 			i18n.T("get.repositoryExists"),
-			existingWorkspace,
+			existing.WorkspaceType, existing.Path,
 		)))
 		fmt.Println(i18n.T("get.continueFetch"))
 	}
@@ -74,43 +106,186 @@ func runGet(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// checkRepositoryExists は指定したリポジリが既に存在するかチェックする (Renamed from zone)
-// 存在する場合はそのワークスペースを返し、存在しない場合は空文字列を返す (Renamed from zone)
-func checkRepositoryExists(repository string) string {
+// checkRepositoryExists は指定したリポジトリが既に存在するかチェックする
+// 存在する場合はそのプロジェクトを返し、存在しない場合は nil を返す
+func checkRepositoryExists(repository string) *domain.Project {
+	ref, err := domain.ParseRepoRef(repository)
+	if err != nil {
+		return nil // 解析できない指定は ghq に任せる
+	}
+
 	// global application should be loaded by PersistentPreRunE
-	projects, err := application.Status.GetAll(status.Options{})
+	matches, err := application.Status.FindRepository(ref)
+	if err != nil || len(matches) == 0 {
+		return nil // エラーは無視して続行
+	}
+
+	return &matches[0]
+}
+
+// runBulkGet はファイルまたは標準入力に列挙されたリポジトリを並列に取得する
+// 各リポジトリの結果を完了順に表示し、最後に集計を表示する
+// 1つでも失敗した場合はエラーを返す
+func runBulkGet(cmd *cobra.Command, defaultWorkspace string) error {
+	var reader io.Reader = cmd.InOrStdin()
+	if getListFile != "" && getListFile != "-" {
+		f, err := os.Open(getListFile)
+		if err != nil {
+			return domain.NewErrorWithCause(
+				domain.ErrCodeFSError,
+				fmt.Sprintf(i18n.T("get.bulk.openFailed"), getListFile),
+				err,
+			)
+		}
+		defer f.Close()
+		reader = f
+	}
+
+	requests, err := parseRepositoryList(reader, defaultWorkspace)
 	if err != nil {
-		return "" // エラーは無視して続行
+		return err
+	}
+
+	// 既存のリポジトリは一度のスキャンでまとめて判定する
+	projects, _ := application.Status.GetAll(status.Options{})
+
+	var opts []ghq.GetOptions
+	var seen []domain.RepoRef
+	skipped := 0
+	for _, req := range requests {
+		ref, err := domain.ParseRepoRef(req.Repository)
+		if err == nil {
+			if matches := application.Status.MatchRepository(projects, ref); len(matches) > 0 {
+				skipped++
+				fmt.Print(ui.FormatInfo(fmt.Sprintf(
+					i18n.T("get.bulk.skipExisting"),
+					req.Repository, matches[0].WorkspaceType, matches[0].Path,
+				)))
+				continue
+			}
+			if containsRepoRef(seen, ref) {
+				skipped++
+				fmt.Print(ui.FormatInfo(fmt.Sprintf(i18n.T("get.bulk.skipDuplicate"), req.Repository)))
+				continue
+			}
+			seen = append(seen, ref)
+		}
+		opts = append(opts, req)
 	}
 
-	// リポジリ名を抽出（簡易的な実装）
-	// 例: "github.com/user/repo" → "repo"
-	// 例: "user/repo" → "repo"
-	repoName := extractRepoName(repository)
+	total := len(opts)
+	done := 0
+	ghqClient := ghq.NewClient(application.Config)
+	results := ghqClient.GetMany(opts, getParallel, func(r ghq.GetResult) {
+		done++
+		fmt.Printf("[%d/%d] ", done, total)
+		if r.Err != nil {
+			fmt.Print(ui.FormatFailure(fmt.Sprintf(
+				i18n.T("get.bulk.cloneFailed"),
+				r.Options.Repository, r.Options.Workspace,
+			)))
+			return
+		}
+		fmt.Print(ui.FormatSuccess(fmt.Sprintf(
+			i18n.T("get.cloneSuccess"),
+			r.Options.Repository, r.Options.Workspace,
+		)))
+	})
 
-	for _, proj := range projects {
-		if contains(proj.Name, repoName) {
-			// Updated to WorkspaceType
-			return string(proj.WorkspaceType)
+	var failures []ghq.GetResult
+	for _, r := range results {
+		if r.Err != nil {
+			failures = append(failures, r)
 		}
 	}
 
-	return ""
+	fmt.Println()
+	fmt.Printf(i18n.T("get.bulk.summary")+"\n", total-len(failures), skipped, len(failures))
+	for _, r := range failures {
+		fmt.Printf("  - %s: %s\n", r.Options.Repository, describeGetFailure(r))
+	}
+
+	if len(failures) > 0 {
+		return domain.NewError(
+			domain.ErrCodeGitError,
+			fmt.Sprintf(i18n.T("get.bulk.failed"), len(failures)),
+		)
+	}
+
+	return nil
 }
 
-// extractRepoName はリポジリURLから名前を抽出する（簡易版）
-func extractRepoName(repository string) string {
-	// 最後の / 以降を取得
-	for i := len(repository) - 1; i >= 0; i-- {
-		if repository[i] == '/' {
-			return repository[i+1:]
+// parseRepositoryList はリポジトリ一覧を読み込む
+// 1行に "<repository> [workspace]" を記述する。空行と # で始まる行は無視する
+// workspace を省略した行は defaultWorkspace に取得する
+func parseRepositoryList(r io.Reader, defaultWorkspace string) ([]ghq.GetOptions, error) {
+	var opts []ghq.GetOptions
+
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) > 2 {
+			return nil, domain.NewError(
+				domain.ErrCodeInvalidPath,
+				fmt.Sprintf(i18n.T("get.bulk.invalidLine"), lineNo, line),
+			).WithHint(i18n.T("get.bulk.invalidLineHint"))
+		}
+
+		workspace := defaultWorkspace
+		if len(fields) == 2 {
+			workspace = fields[1]
 		}
+		opts = append(opts, ghq.GetOptions{Repository: fields[0], Workspace: workspace})
 	}
-	return repository
+
+	if err := scanner.Err(); err != nil {
+		return nil, domain.NewErrorWithCause(
+			domain.ErrCodeFSError,
+			i18n.T("get.bulk.readFailed"),
+			err,
+		)
+	}
+
+	return opts, nil
+}
+
+// containsRepoRef は refs に ref と同じリポジトリが含まれるかチェックする
+func containsRepoRef(refs []domain.RepoRef, ref domain.RepoRef) bool {
+	for _, r := range refs {
+		if r.Equal(ref) {
+			return true
+		}
+	}
+	return false
 }
 
-// contains はaにbが含まれるかチェックする
-func contains(a, b string) bool {
-	return len(a) >= len(b) && (a == b || len(a) > len(b) &&
-		(a[len(a)-len(b)-1] == '/' && a[len(a)-len(b):] == b))
+// describeGetFailure は失敗理由を1行で返す
+// コマンドの出力があれば、その中の fatal:/error: 行 (無ければ最後の行) を付け加える
+func describeGetFailure(r ghq.GetResult) string {
+	msg := r.Err.Error()
+	if ghqxErr, ok := r.Err.(*domain.GhqxError); ok {
+		msg = ghqxErr.Message
+	}
+
+	lines := strings.Split(strings.TrimSpace(r.Output), "\n")
+	detail := strings.TrimSpace(lines[len(lines)-1])
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "fatal:") || strings.HasPrefix(line, "error:") {
+			detail = line
+			break
+		}
+	}
+
+	if detail != "" {
+		msg += " (" + detail + ")"
+	}
+	return msg
 }
@@ -1,12 +1,17 @@
 package main
 
 import (
+	"errors"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/mi8bi/ghqx/internal/app"
 	"github.com/mi8bi/ghqx/internal/config"
+	"github.com/mi8bi/ghqx/internal/domain"
+	"github.com/mi8bi/ghqx/internal/ghq"
 )
 
 func TestRunGetWithLoadAppError(t *testing.T) {
@@ -45,12 +50,15 @@ func TestRunGetWithExistingRepository(t *testing.T) {
 	application = appInstance
 
 	// Test checkRepositoryExists
-	workspace := checkRepositoryExists("github.com/user/repo")
-	if workspace == "" {
+	existing := checkRepositoryExists("github.com/user/repo")
+	if existing == nil {
 		t.Fatalf("expected checkRepositoryExists to find existing repo")
 	}
-	if workspace != "sandbox" {
-		t.Errorf("expected workspace 'sandbox', got %q", workspace)
+	if existing.WorkspaceType != "sandbox" {
+		t.Errorf("expected workspace 'sandbox', got %q", existing.WorkspaceType)
+	}
+	if existing.Path != repo {
+		t.Errorf("expected path %q, got %q", repo, existing.Path)
 	}
 }
 
@@ -68,50 +76,60 @@ func TestCheckRepositoryExistsNotFound(t *testing.T) {
 	appInstance := app.New(cfg)
 	application = appInstance
 
-	workspace := checkRepositoryExists("nonexistent/repo")
-	if workspace != "" {
-		t.Errorf("expected empty string for nonexistent repo, got %q", workspace)
+	existing := checkRepositoryExists("nonexistent/repo")
+	if existing != nil {
+		t.Errorf("expected nil for nonexistent repo, got %q", existing.Path)
 	}
 }
 
-func TestExtractRepoNameVariations(t *testing.T) {
-	testCases := []struct {
-		input    string
-		expected string
-	}{
-		{"github.com/user/repo", "repo"},
-		{"user/repo", "repo"},
-		{"simple", "simple"},
-		{"gitlab.com/org/project/repo", "repo"},
-		{"bitbucket.org/team/repository", "repository"},
+func TestCheckRepositoryExistsMatchesExactRepository(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "ghqx-get-exact")
+	if err != nil {
+		t.Fatalf("tempdir: %v", err)
 	}
+	defer os.RemoveAll(tmp)
 
-	for _, tc := range testCases {
-		result := extractRepoName(tc.input)
-		if result != tc.expected {
-			t.Errorf("extractRepoName(%q) = %q, want %q", tc.input, result, tc.expected)
-		}
+	sandbox := filepath.Join(tmp, "sandbox")
+	dev := filepath.Join(tmp, "dev")
+	if err := os.MkdirAll(filepath.Join(sandbox, "github.com", "bar", "utils"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(dev, "github.com", "foo", "utils"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
 	}
-}
 
-func TestContainsFunction(t *testing.T) {
+	cfg := &config.Config{
+		Roots:   map[string]string{"sandbox": sandbox, "dev": dev},
+		Default: config.DefaultConfig{Root: "sandbox"},
+	}
+	application = app.New(cfg)
+
 	testCases := []struct {
-		a        string
-		b        string
-		expected bool
+		repository string
+		workspace  string
 	}{
-		{"github.com/user/repo", "user/repo", true},
-		{"user/repo", "repo", true},
-		{"simple", "simple", true},
-		{"github.com/user/repo", "other/repo", false},
-		{"short", "longstring", false},
-		{"exact", "exact", true},
+		{"foo/utils", "dev"},
+		{"https://github.com/foo/utils.git", "dev"},
+		{"git@github.com:bar/utils.git", "sandbox"},
+		{"ssh://git@github.com/Bar/Utils", "sandbox"},
+		{"baz/utils", ""},
+		{"gitlab.com/foo/utils", ""},
 	}
 
 	for _, tc := range testCases {
-		result := contains(tc.a, tc.b)
-		if result != tc.expected {
-			t.Errorf("contains(%q, %q) = %v, want %v", tc.a, tc.b, result, tc.expected)
+		got := checkRepositoryExists(tc.repository)
+		if tc.workspace == "" {
+			if got != nil {
+				t.Errorf("checkRepositoryExists(%q) = %s, want nil", tc.repository, got.Path)
+			}
+			continue
+		}
+		if got == nil {
+			t.Errorf("checkRepositoryExists(%q) = nil, want %s", tc.repository, tc.workspace)
+			continue
+		}
+		if string(got.WorkspaceType) != tc.workspace {
+			t.Errorf("checkRepositoryExists(%q) workspace = %s, want %s", tc.repository, got.WorkspaceType, tc.workspace)
 		}
 	}
 }
@@ -169,10 +187,10 @@ func TestCheckRepositoryExistsWithStatusError(t *testing.T) {
 	appInstance := app.New(cfg)
 	application = appInstance
 
-	workspace := checkRepositoryExists("test/repo")
-	// Should return empty string on error
-	if workspace != "" {
-		t.Errorf("expected empty string on error, got %q", workspace)
+	existing := checkRepositoryExists("test/repo")
+	// Should return nil on error
+	if existing != nil {
+		t.Errorf("expected nil on error, got %q", existing.Path)
 	}
 }
 
@@ -197,3 +215,139 @@ func TestRunGetNoArgs(t *testing.T) {
 		t.Error("getCmd should have Args validation")
 	}
 }
+
+func TestGetArgsRejectsRepositoryWithFile(t *testing.T) {
+	if err := getArgs(getCmd, []string{"github.com/user/repo"}); err != nil {
+		t.Errorf("expected a single repository to be accepted, got %v", err)
+	}
+
+	if err := getCmd.Flags().Set("file", "list.txt"); err != nil {
+		t.Fatalf("set flag: %v", err)
+	}
+	defer func() {
+		getListFile = ""
+		getCmd.Flags().Lookup("file").Changed = false
+	}()
+
+	if err := getArgs(getCmd, nil); err != nil {
+		t.Errorf("expected --file alone to be accepted, got %v", err)
+	}
+	err := getArgs(getCmd, []string{"github.com/user/repo"})
+	var gerr *domain.GhqxError
+	if !errors.As(err, &gerr) || !strings.Contains(gerr.Message, "github.com/user/repo") {
+		t.Errorf("expected an error naming the repository, got %v", err)
+	}
+}
+
+func TestParseRepositoryList(t *testing.T) {
+	input := `# team repositories
+github.com/user/one
+
+user/two dev
+   https://github.com/user/three.git   release  
+`
+	opts, err := parseRepositoryList(strings.NewReader(input), "sandbox")
+	if err != nil {
+		t.Fatalf("parseRepositoryList failed: %v", err)
+	}
+
+	want := []ghq.GetOptions{
+		{Repository: "github.com/user/one", Workspace: "sandbox"},
+		{Repository: "user/two", Workspace: "dev"},
+		{Repository: "https://github.com/user/three.git", Workspace: "release"},
+	}
+	if len(opts) != len(want) {
+		t.Fatalf("expected %d entries, got %d", len(want), len(opts))
+	}
+	for i := range want {
+		if opts[i].Repository != want[i].Repository || opts[i].Workspace != want[i].Workspace {
+			t.Errorf("entry %d = %+v, want %+v", i, opts[i], want[i])
+		}
+	}
+
+	if _, err := parseRepositoryList(strings.NewReader("a b c\n"), "sandbox"); err == nil {
+		t.Errorf("expected error for a line with too many fields")
+	}
+}
+
+func TestRunBulkGet(t *testing.T) {
+	if exec.Command("git", "--version").Run() != nil {
+		t.Skip("git not available on this system")
+	}
+
+	tmp := t.TempDir()
+
+	// Local remote to clone from
+	remote := filepath.Join(tmp, "remote", "user", "repo")
+	if err := os.MkdirAll(remote, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	for _, args := range [][]string{
+		{"init"},
+		{"-c", "user.email=t@example.com", "-c", "user.name=t", "commit", "--allow-empty", "-m", "init"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = remote
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	url := "file://" + filepath.ToSlash(remote)
+
+	sandbox := filepath.Join(tmp, "sandbox")
+	dev := filepath.Join(tmp, "dev")
+	for _, p := range []string{sandbox, dev} {
+		if err := os.MkdirAll(p, 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+	cfg := &config.Config{
+		Roots:   map[string]string{"sandbox": sandbox, "dev": dev},
+		Default: config.DefaultConfig{Root: "sandbox"},
+		Clone:   config.CloneConfig{Backend: config.CloneBackendGit},
+	}
+	application = app.New(cfg)
+
+	listFile := filepath.Join(tmp, "repos.txt")
+	content := url + " dev\n" + url + "\n"
+	if err := os.WriteFile(listFile, []byte(content), 0644); err != nil {
+		t.Fatalf("write list: %v", err)
+	}
+
+	oldListFile := getListFile
+	getListFile = listFile
+	defer func() { getListFile = oldListFile }()
+
+	if err := runBulkGet(getCmd, "sandbox"); err != nil {
+		t.Fatalf("runBulkGet failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dev, "localhost", "user", "repo", ".git")); err != nil {
+		t.Fatalf("expected repository cloned into dev: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(sandbox, "localhost", "user", "repo")); !os.IsNotExist(err) {
+		t.Errorf("duplicate entry should have been skipped")
+	}
+
+	// A list with an unreachable repository reports failure
+	if err := os.WriteFile(listFile, []byte("file:///nonexistent/user/missing\n"), 0644); err != nil {
+		t.Fatalf("write list: %v", err)
+	}
+	if err := runBulkGet(getCmd, "sandbox"); err == nil {
+		t.Fatal("expected error when a clone fails")
+	}
+}
+
+func TestDescribeGetFailure(t *testing.T) {
+	r := ghq.GetResult{
+		Err:    errors.New("git clone failed"),
+		Output: "Cloning into 'x'...\nfatal: repository not found\nPlease make sure you have access\n",
+	}
+	if got := describeGetFailure(r); got != "git clone failed (fatal: repository not found)" {
+		t.Errorf("unexpected description: %q", got)
+	}
+
+	r.Output = ""
+	if got := describeGetFailure(r); got != "git clone failed" {
+		t.Errorf("unexpected description without output: %q", got)
+	}
+}
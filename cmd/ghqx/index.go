@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/mi8bi/ghqx/internal/domain"
+	"github.com/mi8bi/ghqx/internal/i18n"
+	"github.com/mi8bi/ghqx/internal/index"
+	"github.com/mi8bi/ghqx/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var indexCmd = &cobra.Command{
+	Use:   "index",
+	Short: "", // Will be set in root.go init() after locale is determined
+	Long:  "", // Will be set in root.go init() after locale is determined
+}
+
+var indexRebuildCmd = &cobra.Command{
+	Use:   "rebuild [workspace]",
+	Short: "", // Will be set in root.go init() after locale is determined
+	Long:  "", // Will be set in root.go init() after locale is determined
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runIndexRebuild,
+
+	ValidArgsFunction: completeWorkspaces,
+}
+
+func init() {
+	indexCmd.AddCommand(indexRebuildCmd)
+}
+
+// runIndexRebuild rescans every root (or the given workspace) and
+// overwrites its index entry, even when --no-cache is given.
+func runIndexRebuild(cmd *cobra.Command, args []string) error {
+	if err := loadApp(); err != nil {
+		return err
+	}
+
+	idx := application.Index
+	if idx == nil {
+		dir, err := index.DefaultDir()
+		if err != nil {
+			return err
+		}
+		idx = index.New(dir, application.Status.Scanner())
+	}
+
+	workspace := ""
+	if len(args) == 1 {
+		workspace = args[0]
+	}
+	return rebuildIndex(cmd.OutOrStdout(), idx, workspace)
+}
+
+// rebuildIndex rebuilds the entries of all roots, or only of workspace when it is not empty,
+// and prints the number of projects indexed per root.
+func rebuildIndex(w io.Writer, idx *index.Index, workspace string) error {
+	roots := application.Config.Roots
+	if workspace != "" {
+		path, ok := application.Config.GetRoot(workspace)
+		if !ok {
+			return domain.ErrRootNotFound(workspace)
+		}
+		roots = map[string]string{workspace: path}
+	}
+
+	names := make([]string, 0, len(roots))
+	for name := range roots {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		projects, err := idx.Rebuild(domain.RootName(name), roots[name])
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(w, ui.FormatSuccess(fmt.Sprintf(i18n.T("index.rebuild.done"), name, len(projects))))
+	}
+
+	fmt.Fprint(w, ui.FormatInfo(fmt.Sprintf(i18n.T("index.rebuild.location"), idx.Dir())))
+	return nil
+}
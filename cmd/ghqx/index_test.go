@@ -0,0 +1,79 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mi8bi/ghqx/internal/app"
+	"github.com/mi8bi/ghqx/internal/config"
+	"github.com/mi8bi/ghqx/internal/index"
+)
+
+func TestRebuildIndex(t *testing.T) {
+	dev := t.TempDir()
+	release := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dev, "github.com", "user", "repo"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	oldApp := application
+	application = app.New(&config.Config{Roots: map[string]string{"dev": dev, "release": release}})
+	defer func() { application = oldApp }()
+
+	idx := index.New(t.TempDir(), application.Status.Scanner())
+
+	var buf strings.Builder
+	if err := rebuildIndex(&buf, idx, ""); err != nil {
+		t.Fatalf("rebuildIndex failed: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "dev") || !strings.Contains(out, "release") || !strings.Contains(out, idx.Dir()) {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+
+	buf.Reset()
+	if err := rebuildIndex(&buf, idx, "dev"); err != nil {
+		t.Fatalf("rebuildIndex(dev) failed: %v", err)
+	}
+	if strings.Contains(buf.String(), "release") {
+		t.Errorf("only dev should be rebuilt:\n%s", buf.String())
+	}
+
+	if err := rebuildIndex(&buf, idx, "unknown"); err == nil {
+		t.Error("expected error for unknown workspace")
+	}
+}
+
+func TestCompleteWorkspacesAndProjects(t *testing.T) {
+	tmp := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmp, "dev", "github.com", "user", "repo"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	cfgPath := filepath.Join(tmp, "config.toml")
+	cfg := &config.Config{
+		Roots:   map[string]string{"dev": filepath.Join(tmp, "dev"), "release": filepath.Join(tmp, "release")},
+		Default: config.DefaultConfig{Root: "dev"},
+	}
+	if err := config.NewLoader().Save(cfg, cfgPath); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if err := os.MkdirAll(cfg.Roots["release"], 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	oldConfigPath, oldApp := configPath, application
+	configPath = cfgPath
+	defer func() { configPath, application = oldConfigPath, oldApp }()
+
+	names, _ := completeWorkspaces(promoteCmd, nil, "d")
+	if len(names) != 1 || names[0] != "dev" {
+		t.Errorf("completeWorkspaces = %v", names)
+	}
+
+	names, _ = completePromoteArgs(promoteCmd, nil, "github.com/")
+	if len(names) != 1 || names[0] != "github.com/user/repo" {
+		t.Errorf("completePromoteArgs = %v", names)
+	}
+}
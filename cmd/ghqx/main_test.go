@@ -0,0 +1,12 @@
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	// Keep tests from reading or writing the user's project index
+	noCache = true
+	os.Exit(m.Run())
+}
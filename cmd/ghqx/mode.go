@@ -85,6 +85,20 @@ func (m ModeSelectorModel) View() string {
 	return s
 }
 
+// saveDefaultRoot makes root the default in the config file that was loaded,
+// changing only that value.
+func saveDefaultRoot(root string) error {
+	_, err := newLoader().Update(configPath, func(cfg *config.Config) error {
+		cfg.Default.Root = root
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	application.Config.Default.Root = root
+	return nil
+}
+
 func runMode(cmd *cobra.Command, args []string) error {
 	if err := loadApp(); err != nil {
 		return err
@@ -130,20 +144,7 @@ func runMode(cmd *cobra.Command, args []string) error {
 			return nil
 		}
 
-		// Update config
-		application.Config.Default.Root = m.selected
-		loader := config.NewLoader()
-
-		// Determine config path for saving
-		savePath := configPath
-		if savePath == "" {
-			savePath, err = config.GetDefaultConfigPath()
-			if err != nil {
-				return err
-			}
-		}
-
-		if err := loader.Save(application.Config, savePath); err != nil {
+		if err := saveDefaultRoot(m.selected); err != nil {
 			return err
 		}
 		// Updated message to use workspace terminology
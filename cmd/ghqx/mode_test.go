@@ -3,6 +3,7 @@ package main
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/mi8bi/ghqx/internal/app"
@@ -239,3 +240,37 @@ func TestRunModeWithSingleRoot(t *testing.T) {
 		t.Errorf("expected 1 root, got %d", len(rootNames))
 	}
 }
+
+func TestSaveDefaultRootUpdatesLoadedFile(t *testing.T) {
+	tmp := t.TempDir()
+	cfgPath := filepath.Join(tmp, "ghqx.toml")
+	original := "# my roots\n[roots]\ndev = \"/tmp/dev\"\nsandbox = \"/tmp/sandbox\"\n\n[default]\nroot = \"dev\"\n"
+	if err := os.WriteFile(cfgPath, []byte(original), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	xdg := filepath.Join(tmp, "xdg")
+	t.Setenv("GHQX_CONFIG", cfgPath)
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+
+	oldConfigPath := configPath
+	configPath = ""
+	defer func() { configPath = oldConfigPath }()
+	oldApp := application
+	application = app.New(&config.Config{Roots: map[string]string{"dev": "/tmp/dev", "sandbox": "/tmp/sandbox"}})
+	defer func() { application = oldApp }()
+
+	if err := saveDefaultRoot("sandbox"); err != nil {
+		t.Fatalf("saveDefaultRoot failed: %v", err)
+	}
+
+	want := strings.Replace(original, `root = "dev"`, `root = "sandbox"`, 1)
+	if data, _ := os.ReadFile(cfgPath); string(data) != want {
+		t.Errorf("loaded config not updated in place:\n%s", data)
+	}
+	if _, err := os.Stat(filepath.Join(xdg, "ghqx", "config.toml")); !os.IsNotExist(err) {
+		t.Error("mode must not create a config at the default location")
+	}
+	if application.Config.Default.Root != "sandbox" {
+		t.Errorf("application default root = %q", application.Config.Default.Root)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mi8bi/ghqx/internal/config"
+	"github.com/mi8bi/ghqx/internal/i18n"
+	"github.com/mi8bi/ghqx/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "", // Will be set in root.go init() after locale is determined
+	Long:  "", // Will be set in root.go init() after locale is determined
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "", // Will be set in root.go init() after locale is determined
+	Long:  "", // Will be set in root.go init() after locale is determined
+	Args:  cobra.NoArgs,
+	RunE:  runProfileList,
+}
+
+var profileUseCmd = &cobra.Command{
+	Use:   "use <profile>",
+	Short: "", // Will be set in root.go init() after locale is determined
+	Long:  "", // Will be set in root.go init() after locale is determined
+	Args:  cobra.ExactArgs(1),
+	RunE:  runProfileUse,
+
+	ValidArgsFunction: completeProfiles,
+}
+
+func init() {
+	profileCmd.AddCommand(profileListCmd)
+	profileCmd.AddCommand(profileUseCmd)
+}
+
+// runProfileList prints every profile, marking the active one with * and
+// the one saved with 'ghqx profile use'.
+func runProfileList(cmd *cobra.Command, args []string) error {
+	if err := loadApp(); err != nil {
+		return err
+	}
+
+	cfg := application.Config
+	w := cmd.OutOrStdout()
+	for _, name := range cfg.Profiles() {
+		marker := " "
+		if name == cfg.ActiveProfile() {
+			marker = "*"
+		}
+		line := marker + " " + name
+		if name == cfg.DefaultProfile() {
+			line += " " + i18n.T("profile.list.saved")
+		}
+		fmt.Fprintln(w, line)
+	}
+	return nil
+}
+
+// runProfileUse saves the profile used when neither --profile nor GHQX_PROFILE is given.
+// The file is read with the default profile active, so a profile selection that
+// no longer exists can still be replaced.
+func runProfileUse(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	loader := config.NewLoader().WithProfile(config.DefaultProfileName)
+	if _, err := loader.Update(configPath, func(cfg *config.Config) error {
+		// Activate the profile so its roots are validated before saving
+		if err := cfg.UseProfile(name); err != nil {
+			return err
+		}
+		return cfg.SetDefaultProfile(name)
+	}); err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	fmt.Fprint(w, ui.FormatSuccess(fmt.Sprintf(i18n.T("profile.use.success"), name)))
+	if env := os.Getenv(config.ProfileEnv); env != "" && env != name {
+		fmt.Fprint(w, ui.FormatInfo(fmt.Sprintf(i18n.T("profile.use.overridden"), config.ProfileEnv, env)))
+	}
+	return nil
+}
+
+// completeProfiles completes the profile names of the config file.
+func completeProfiles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	cfg, err := config.NewLoader().WithProfile(config.DefaultProfileName).Load(configPath)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []string
+	for _, name := range cfg.Profiles() {
+		if strings.HasPrefix(name, toComplete) {
+			names = append(names, name)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mi8bi/ghqx/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func TestRunProfileUseAndList(t *testing.T) {
+	t.Setenv(config.ProfileEnv, "")
+	tmp := t.TempDir()
+	cfgPath := filepath.Join(tmp, "config.toml")
+	data := "version = 2\n\n[default]\nroot = \"dev\"\n\n[roots.dev]\npath = \"" + filepath.ToSlash(filepath.Join(tmp, "dev")) + "\"\n\n" +
+		"[profiles.work.default]\nroot = \"job\"\n\n[profiles.work.roots.job]\npath = \"" + filepath.ToSlash(filepath.Join(tmp, "job")) + "\"\n"
+	if err := os.WriteFile(cfgPath, []byte(data), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	oldConfigPath := configPath
+	configPath = cfgPath
+	defer func() { configPath = oldConfigPath }()
+
+	var out strings.Builder
+	for _, cmd := range []*cobra.Command{profileListCmd, profileUseCmd} {
+		cmd.SetOut(&out)
+		defer cmd.SetOut(nil)
+	}
+
+	if err := runProfileUse(profileUseCmd, []string{"nope"}); err == nil {
+		t.Error("unknown profiles should be rejected")
+	}
+	if err := runProfileUse(profileUseCmd, []string{"work"}); err != nil {
+		t.Fatalf("runProfileUse failed: %v", err)
+	}
+
+	out.Reset()
+	if err := runProfileList(profileListCmd, nil); err != nil {
+		t.Fatalf("runProfileList failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || strings.TrimSpace(lines[0]) != "default" || !strings.HasPrefix(lines[1], "* work") {
+		t.Errorf("unexpected profile list:\n%s", out.String())
+	}
+	if application.Config.Roots["job"] == "" {
+		t.Errorf("work profile should be active, roots: %v", application.Config.Roots)
+	}
+
+	// --profile overrides the saved profile
+	oldProfile := profileName
+	profileName = "default"
+	defer func() { profileName = oldProfile }()
+
+	out.Reset()
+	if err := runProfileList(profileListCmd, nil); err != nil {
+		t.Fatalf("runProfileList failed: %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "* default") {
+		t.Errorf("--profile should select the active profile:\n%s", out.String())
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/mi8bi/ghqx/internal/i18n"
+	"github.com/mi8bi/ghqx/internal/promote"
+	"github.com/mi8bi/ghqx/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	promoteFrom  string
+	promoteForce bool
+)
+
+var promoteCmd = &cobra.Command{
+	Use:     "promote <project> [workspace]",
+	Aliases: []string{"move"},
+	Short:   "", // Will be set in root.go init() after locale is determined
+	Long:    "", // Will be set in root.go init() after locale is determined
+	Args:    cobra.RangeArgs(1, 2),
+	RunE:    runPromote,
+
+	ValidArgsFunction: completePromoteArgs,
+}
+
+func init() {
+	promoteCmd.Flags().StringVar(&promoteFrom, "from", "", i18n.T("promote.flag.from"))
+	promoteCmd.Flags().BoolVar(&promoteForce, "force", false, i18n.T("promote.flag.force"))
+	_ = promoteCmd.RegisterFlagCompletionFunc("from", completeWorkspaces)
+}
+
+// completePromoteArgs completes the project, then the target workspace.
+func completePromoteArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return completeProjectNames(cmd, args, toComplete)
+	case 1:
+		return completeWorkspaces(cmd, args, toComplete)
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+// runPromote moves a project into another workspace root.
+// Without an explicit workspace the project advances to the next lifecycle stage.
+func runPromote(cmd *cobra.Command, args []string) error {
+	if err := loadApp(); err != nil {
+		return err
+	}
+
+	opts := promote.Options{
+		Project: args[0],
+		From:    promoteFrom,
+		Force:   promoteForce,
+	}
+	if len(args) > 1 {
+		opts.To = args[1]
+	}
+
+	service := promote.NewService(application.Config, application.Status)
+	result, err := service.Promote(opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(ui.FormatSuccess(fmt.Sprintf(
+		i18n.T("promote.success"),
+		result.Project.Name, result.Project.Root, result.TargetRoot,
+	)))
+	fmt.Println(result.TargetPath)
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mi8bi/ghqx/internal/config"
+)
+
+func TestRunPromote(t *testing.T) {
+	tmp := t.TempDir()
+
+	cfgPath := filepath.Join(tmp, "config.toml")
+	sandbox := filepath.Join(tmp, "sandbox")
+	dev := filepath.Join(tmp, "dev")
+	cfg := &config.Config{
+		Roots:   map[string]string{"sandbox": sandbox, "dev": dev},
+		Default: config.DefaultConfig{Root: "sandbox"},
+	}
+	if err := config.NewLoader().Save(cfg, cfgPath); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(sandbox, "github.com", "user", "repo"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.MkdirAll(dev, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	oldConfigPath := configPath
+	configPath = cfgPath
+	defer func() { configPath = oldConfigPath }()
+
+	if err := runPromote(promoteCmd, []string{"github.com/user/repo", "dev"}); err != nil {
+		t.Fatalf("runPromote failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dev, "github.com", "user", "repo")); err != nil {
+		t.Fatalf("expected project in dev: %v", err)
+	}
+}
+
+func TestRunPromoteWithLoadAppError(t *testing.T) {
+	oldConfigPath := configPath
+	configPath = "/nonexistent/config.toml"
+	defer func() { configPath = oldConfigPath }()
+
+	if err := runPromote(promoteCmd, []string{"github.com/user/repo"}); err == nil {
+		t.Fatal("expected error when loadApp fails")
+	}
+}
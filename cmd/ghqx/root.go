@@ -1,28 +1,61 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/mi8bi/ghqx/internal/app"
+	"github.com/mi8bi/ghqx/internal/config"
+	"github.com/mi8bi/ghqx/internal/domain"
 	"github.com/mi8bi/ghqx/internal/i18n" // Added missing import
+	"github.com/mi8bi/ghqx/internal/index"
 	"github.com/mi8bi/ghqx/internal/ui"
 	"github.com/spf13/cobra"
 )
 
 var (
 	configPath string
+	// profileName selects the config profile, overriding GHQX_PROFILE
+	profileName string
+	// noCache bypasses the persistent project index
+	noCache bool
+	// strictConfig fails on every problem 'ghqx config validate' reports, not only invalid settings
+	strictConfig bool
 
 	// Global application instance
 	application *app.App
 )
 
+// refreshGracePeriod bounds how long the process waits at exit for
+// background index refreshes.
+const refreshGracePeriod = 500 * time.Millisecond
+
 func main() {
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+
+	// Give background index refreshes a moment to finish; a refresh that
+	// needs longer is abandoned rather than delaying the command
+	if application != nil {
+		application.WaitTimeout(refreshGracePeriod)
+	}
+
+	if err != nil {
 		fmt.Fprint(os.Stderr, ui.FormatDetailedError(err))
-		os.Exit(1)
+		os.Exit(exitCode(err))
+	}
+}
+
+// exitCode returns the process exit code for an error returned by a command.
+// Errors without a specific exit code exit with 1.
+func exitCode(err error) int {
+	var ghqxErr *domain.GhqxError
+	if errors.As(err, &ghqxErr) && ghqxErr.ExitCode > 0 {
+		return ghqxErr.ExitCode
 	}
+	return 1
 }
 
 var rootCmd = &cobra.Command{
@@ -38,6 +71,23 @@ var rootCmd = &cobra.Command{
 			return nil
 		}
 
+		// config validate reports every problem itself, including the ones that stop loading
+		if cmd == configValidateCmd {
+			return nil
+		}
+
+		// profile use must work while the selected profile is missing, and
+		// history and rollback while the config file is broken
+		if cmd == profileUseCmd || cmd == configHistoryCmd || cmd == configRollbackCmd {
+			return nil
+		}
+
+		// Shell completion must work before a config exists;
+		// completion functions load the app themselves
+		if isCompletionCommand(cmd) {
+			return nil
+		}
+
 		// Load app configuration for all other commands
 		if err := loadApp(); err != nil {
 			return err
@@ -75,6 +125,24 @@ func init() {
 	configShowCmd.Long = i18n.T("config.show.command.long")
 	configEditCmd.Short = i18n.T("config.edit.command.short")
 	configEditCmd.Long = i18n.T("config.edit.command.long")
+	configMigrateCmd.Short = i18n.T("config.migrate.command.short")
+	configMigrateCmd.Long = i18n.T("config.migrate.command.long")
+	configValidateCmd.Short = i18n.T("config.validate.command.short")
+	configValidateCmd.Long = i18n.T("config.validate.command.long")
+	configGetCmd.Short = i18n.T("config.get.command.short")
+	configGetCmd.Long = i18n.T("config.get.command.long")
+	configSetCmd.Short = i18n.T("config.set.command.short")
+	configSetCmd.Long = i18n.T("config.set.command.long")
+	configUnsetCmd.Short = i18n.T("config.unset.command.short")
+	configUnsetCmd.Long = i18n.T("config.unset.command.long")
+	configAddRootCmd.Short = i18n.T("config.addRoot.command.short")
+	configAddRootCmd.Long = i18n.T("config.addRoot.command.long")
+	configRemoveRootCmd.Short = i18n.T("config.removeRoot.command.short")
+	configRemoveRootCmd.Long = i18n.T("config.removeRoot.command.long")
+	configHistoryCmd.Short = i18n.T("config.history.command.short")
+	configHistoryCmd.Long = fmt.Sprintf(i18n.T("config.history.command.long"), config.HistoryLimit)
+	configRollbackCmd.Short = i18n.T("config.rollback.command.short")
+	configRollbackCmd.Long = i18n.T("config.rollback.command.long")
 
 	getCmd.Short = i18n.T("get.command.short")
 	getCmd.Long = i18n.T("get.command.long")
@@ -88,7 +156,40 @@ func init() {
 	modeCmd.Short = i18n.T("mode.command.short")
 	modeCmd.Long = i18n.T("mode.command.long")
 
+	promoteCmd.Short = i18n.T("promote.command.short")
+	promoteCmd.Long = i18n.T("promote.command.long")
+
+	updateCmd.Short = i18n.T("update.command.short")
+	updateCmd.Long = i18n.T("update.command.long")
+
+	execCmd.Short = i18n.T("exec.command.short")
+	execCmd.Long = i18n.T("exec.command.long")
+
+	indexCmd.Short = i18n.T("index.command.short")
+	indexCmd.Long = i18n.T("index.command.long")
+	indexRebuildCmd.Short = i18n.T("index.rebuild.command.short")
+	indexRebuildCmd.Long = i18n.T("index.rebuild.command.long")
+
+	worktreeCmd.Short = i18n.T("worktree.command.short")
+	worktreeCmd.Long = i18n.T("worktree.command.long")
+	worktreeAddCmd.Short = i18n.T("worktree.add.command.short")
+	worktreeAddCmd.Long = i18n.T("worktree.add.command.long")
+	worktreeListCmd.Short = i18n.T("worktree.list.command.short")
+	worktreeListCmd.Long = i18n.T("worktree.list.command.long")
+	worktreeRemoveCmd.Short = i18n.T("worktree.remove.command.short")
+	worktreeRemoveCmd.Long = i18n.T("worktree.remove.command.long")
+
+	profileCmd.Short = i18n.T("profile.command.short")
+	profileCmd.Long = i18n.T("profile.command.long")
+	profileListCmd.Short = i18n.T("profile.list.command.short")
+	profileListCmd.Long = i18n.T("profile.list.command.long")
+	profileUseCmd.Short = i18n.T("profile.use.command.short")
+	profileUseCmd.Long = i18n.T("profile.use.command.long")
+
 	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", i18n.T("root.flag.config"))
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", i18n.T("root.flag.profile"))
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, i18n.T("root.flag.noCache"))
+	rootCmd.PersistentFlags().BoolVar(&strictConfig, "strict", false, i18n.T("root.flag.strict"))
 
 	rootCmd.AddCommand(statusCmd)
 	rootCmd.AddCommand(cdCmd)
@@ -98,6 +199,24 @@ func init() {
 	rootCmd.AddCommand(doctorCmd)
 	rootCmd.AddCommand(cleanCmd)
 	rootCmd.AddCommand(modeCmd)
+	rootCmd.AddCommand(promoteCmd)
+	rootCmd.AddCommand(updateCmd)
+	rootCmd.AddCommand(execCmd)
+	rootCmd.AddCommand(indexCmd)
+	rootCmd.AddCommand(worktreeCmd)
+	rootCmd.AddCommand(profileCmd)
+}
+
+// isCompletionCommand reports whether cmd generates completion scripts
+// or answers completion requests from the shell.
+func isCompletionCommand(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		switch c.Name() {
+		case "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+			return true
+		}
+	}
+	return false
 }
 
 // initLocale initializes the locale before any command descriptions are rendered.
@@ -201,11 +320,26 @@ func matchLocaleString(str string) i18n.Locale {
 
 // loadApp initializes the global application instance with configuration.
 // It loads config from the specified path (or default location if not specified).
+// The project index is enabled unless --no-cache is given or no cache directory is available.
 func loadApp() error {
 	var err error
-	application, err = app.NewFromConfigPath(configPath)
+	if strictConfig {
+		application, err = app.NewFromConfigPathStrict(configPath, profileName)
+	} else {
+		application, err = app.NewFromConfigPath(configPath, profileName)
+	}
 	if err != nil {
 		return err
 	}
+	if !noCache {
+		if dir, err := index.DefaultDir(); err == nil {
+			application.EnableIndex(dir)
+		}
+	}
 	return nil
 }
+
+// newLoader returns a config loader that activates the profile given with --profile.
+func newLoader() *config.Loader {
+	return config.NewLoader().WithProfile(profileName)
+}
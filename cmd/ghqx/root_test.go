@@ -1,13 +1,17 @@
 package main
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/mi8bi/ghqx/internal/app"
 	"github.com/mi8bi/ghqx/internal/config"
+	"github.com/mi8bi/ghqx/internal/domain"
 	"github.com/mi8bi/ghqx/internal/i18n"
+	"github.com/spf13/cobra"
 )
 
 func TestMatchLocaleStringCases(t *testing.T) {
@@ -308,7 +312,7 @@ func TestNewFromConfigPathWrapper(t *testing.T) {
 	oldApp := application
 	defer func() { application = oldApp }()
 
-	appInstance, err := app.NewFromConfigPath(cfgPath)
+	appInstance, err := app.NewFromConfigPath(cfgPath, "")
 	if err != nil {
 		t.Fatalf("NewFromConfigPath failed: %v", err)
 	}
@@ -317,3 +321,32 @@ func TestNewFromConfigPathWrapper(t *testing.T) {
 		t.Fatal("appInstance should not be nil")
 	}
 }
+
+func TestExitCode(t *testing.T) {
+	if got := exitCode(errors.New("plain")); got != 1 {
+		t.Errorf("exitCode(plain) = %d, want 1", got)
+	}
+	if got := exitCode(domain.ErrRootNotFound("x")); got != 1 {
+		t.Errorf("exitCode(ghqx error) = %d, want 1", got)
+	}
+	wrapped := fmt.Errorf("wrapped: %w", domain.NewError(domain.ErrCodeDirtyRepo, "dirty").WithExitCode(6))
+	if got := exitCode(wrapped); got != 6 {
+		t.Errorf("exitCode(wrapped) = %d, want 6", got)
+	}
+}
+
+func TestIsCompletionCommand(t *testing.T) {
+	// cobra adds these commands only when executing, so build the same tree here
+	completionCmd := &cobra.Command{Use: "completion"}
+	bashCmd := &cobra.Command{Use: "bash"}
+	completionCmd.AddCommand(bashCmd)
+	if !isCompletionCommand(bashCmd) {
+		t.Error("completion bash should be a completion command")
+	}
+	if !isCompletionCommand(&cobra.Command{Use: cobra.ShellCompRequestCmd}) {
+		t.Error("__complete should be a completion command")
+	}
+	if isCompletionCommand(statusCmd) {
+		t.Error("status should not be a completion command")
+	}
+}
@@ -2,18 +2,28 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"strings"
+	"time"
 
 	"github.com/mattn/go-runewidth"
+	"github.com/mi8bi/ghqx/internal/domain"
+	"github.com/mi8bi/ghqx/internal/fs"
 	"github.com/mi8bi/ghqx/internal/i18n"
 	"github.com/mi8bi/ghqx/internal/status"
 	"github.com/mi8bi/ghqx/internal/tui"
+	"github.com/mi8bi/ghqx/internal/ui"
 	"github.com/spf13/cobra"
 )
 
 var (
-	statusVerbose bool
-	statusTUI     bool
+	statusVerbose  bool
+	statusTUI      bool
+	statusFormat   string
+	statusTemplate string
+	statusFilter   status.Filter
+	statusSort     string
+	statusCheck    bool
 )
 
 var statusCmd = &cobra.Command{
@@ -26,6 +36,31 @@ var statusCmd = &cobra.Command{
 func init() {
 	statusCmd.Flags().BoolVarP(&statusVerbose, "verbose", "v", false, i18n.T("status.flag.verbose"))
 	statusCmd.Flags().BoolVar(&statusTUI, "tui", false, i18n.T("status.flag.tui"))
+	statusCmd.Flags().StringVar(&statusFormat, "format", string(status.FormatTable), i18n.T("status.flag.format"))
+	statusCmd.Flags().StringVar(&statusTemplate, "template", "", i18n.T("status.flag.template"))
+	statusCmd.Flags().BoolVar(&statusCheck, "check", false, i18n.T("status.flag.check"))
+	statusCmd.MarkFlagsMutuallyExclusive("format", "template")
+	statusCmd.MarkFlagsMutuallyExclusive("check", "tui", "format", "template")
+
+	statusCmd.Flags().StringVar(&statusFilter.Workspace, "workspace", "", i18n.T("status.flag.workspace"))
+	statusCmd.Flags().BoolVar(&statusFilter.Dirty, "dirty", false, i18n.T("status.flag.dirty"))
+	statusCmd.Flags().BoolVar(&statusFilter.Clean, "clean", false, i18n.T("status.flag.clean"))
+	statusCmd.Flags().BoolVar(&statusFilter.Git, "git", false, i18n.T("status.flag.git"))
+	statusCmd.Flags().BoolVar(&statusFilter.NoGit, "no-git", false, i18n.T("status.flag.noGit"))
+	statusCmd.Flags().StringVarP(&statusFilter.Query, "query", "q", "", i18n.T("status.flag.query"))
+	statusCmd.Flags().StringVar(&statusSort, "sort", string(status.SortWorkspace), i18n.T("status.flag.sort"))
+	_ = statusCmd.RegisterFlagCompletionFunc("workspace", completeWorkspaces)
+	statusCmd.MarkFlagsMutuallyExclusive("dirty", "clean")
+	statusCmd.MarkFlagsMutuallyExclusive("git", "no-git")
+}
+
+// statusQueryOptions returns the filter and sort options given on the command line.
+func statusQueryOptions() (status.Options, error) {
+	sortKey, err := status.ParseSortKey(statusSort)
+	if err != nil {
+		return status.Options{}, err
+	}
+	return status.Options{Filter: statusFilter, Sort: sortKey}, nil
 }
 
 func runStatus(cmd *cobra.Command, args []string) error {
@@ -33,18 +68,34 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	opts, err := statusQueryOptions()
+	if err != nil {
+		return err
+	}
+
+	// CI check mode
+	if statusCheck {
+		return runStatusCheck(cmd.OutOrStdout(), opts)
+	}
+
 	// TUI mode
 	if statusTUI {
-		return tui.RunStatus(application)
+		return tui.RunStatus(application, opts)
 	}
 
-	// CLI mode
-	opts := status.Options{
-		CheckDirty: true,
-		LoadBranch: false,
+	// Machine-readable output
+	if statusTemplate != "" || status.Format(statusFormat) != status.FormatTable {
+		return runStatusExport(cmd.OutOrStdout(), opts)
 	}
 
-	rawProjects, err := application.Status.GetAll(opts)
+	// CLI mode
+	opts.CheckDirty = true
+	opts.LoadBranch = false
+	// Verbose output shows branch, sync and change details
+	opts.LoadGitStatus = statusVerbose
+
+	// Excluded directories come from the same scan, so --no-cache walks each root once
+	rawProjects, excluded, err := application.Status.GetAllWithExcluded(opts)
 	if err != nil {
 		return err
 	}
@@ -56,12 +107,136 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	}
 
 	if statusVerbose {
-		return outputVerboseTable(displayProjects)
+		if err := outputVerboseTable(displayProjects); err != nil {
+			return err
+		}
+		// Show what exclude patterns hid, to debug missing projects
+		outputExcluded(cmd.OutOrStdout(), excluded)
+		return nil
 	}
 
 	return outputCompactTable(displayProjects)
 }
 
+// runStatusExport writes every project with full git details in a
+// machine-readable format or through the user's template.
+// NDJSON is written per root as soon as each root finishes scanning.
+// opts carries the filter and sort options.
+func runStatusExport(w io.Writer, opts status.Options) error {
+	var enc status.Encoder
+	var err error
+	if statusTemplate != "" {
+		enc, err = status.NewTemplateEncoder(statusTemplate, w)
+	} else {
+		enc, err = status.NewEncoder(status.Format(statusFormat), w)
+	}
+	if err != nil {
+		return err
+	}
+
+	opts.CheckDirty = true
+	opts.LoadBranch = true
+	opts.LoadGitStatus = true
+
+	if status.Format(statusFormat) == status.FormatNDJSON {
+		if err := application.Status.Stream(opts, enc.Write); err != nil {
+			return err
+		}
+		return enc.Close()
+	}
+
+	// Roots finish scanning in any order; keep the output stable for scripts
+	if opts.Sort == status.SortNone {
+		opts.Sort = status.SortWorkspace
+	}
+	projects, err := application.Status.GetAll(opts)
+	if err != nil {
+		return err
+	}
+
+	if err := enc.Write(projects); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// checkGitTimeout bounds each git command of status --check. A status that
+// times out counts as unreadable, so it must cover large repositories.
+const checkGitTimeout = 30 * time.Second
+
+// runStatusCheck lists only the git repositories with uncommitted changes,
+// unpushed commits or stashes, or whose status could not be read.
+// It returns an error whose exit code is the sum of the problem categories
+// found, so CI can tell them apart.
+// opts carries the filter and sort options.
+func runStatusCheck(w io.Writer, opts status.Options) error {
+	opts.LoadGitStatus = true
+	opts.Filter.Git = true
+
+	projects, err := application.Status.WithGitTimeout(checkGitTimeout).GetAll(opts)
+	if err != nil {
+		return err
+	}
+
+	results, problems := status.Check(projects)
+	if len(results) == 0 {
+		fmt.Fprint(w, ui.FormatSuccess(fmt.Sprintf(i18n.T("status.check.ok"), len(projects))))
+		return nil
+	}
+
+	outputCheckTable(w, results)
+
+	return domain.NewError(
+		domain.ErrCodeDirtyRepo,
+		fmt.Sprintf(i18n.T("status.check.failed"), len(results), len(projects)),
+	).WithExitCode(int(problems))
+}
+
+// outputCheckTable prints the offending repositories and their problems.
+func outputCheckTable(w io.Writer, results []status.CheckResult) {
+	headerName := i18n.T("status.header.name")
+	headerWorkspace := i18n.T("status.header.workspace")
+	headerProblems := i18n.T("status.header.problems")
+
+	nameWidth := runewidth.StringWidth(headerName)
+	workspaceWidth := runewidth.StringWidth(headerWorkspace)
+	rows := make([][3]string, len(results))
+	for i, r := range results {
+		display := status.NewProjectDisplay(r.Project)
+		rows[i] = [3]string{display.Repo, display.Workspace, formatCheckProblems(r.Problems)}
+		nameWidth = max(nameWidth, runewidth.StringWidth(display.Repo))
+		workspaceWidth = max(workspaceWidth, runewidth.StringWidth(display.Workspace))
+	}
+
+	fmt.Fprintf(w, "%s  %s  %s\n", padRight(headerName, nameWidth), padRight(headerWorkspace, workspaceWidth), headerProblems)
+	fmt.Fprintf(w, "%s  %s  %s\n",
+		strings.Repeat("-", nameWidth),
+		strings.Repeat("-", workspaceWidth),
+		strings.Repeat("-", runewidth.StringWidth(headerProblems)),
+	)
+	for _, row := range rows {
+		fmt.Fprintf(w, "%s  %s  %s\n", padRight(row[0], nameWidth), padRight(row[1], workspaceWidth), row[2])
+	}
+}
+
+// formatCheckProblems returns the localized, comma-separated problem labels.
+func formatCheckProblems(problems status.Problem) string {
+	var labels []string
+	for _, p := range problems.List() {
+		switch p {
+		case status.ProblemUncommitted:
+			labels = append(labels, i18n.T("status.check.uncommitted"))
+		case status.ProblemUnpushed:
+			labels = append(labels, i18n.T("status.check.unpushed"))
+		case status.ProblemStashed:
+			labels = append(labels, i18n.T("status.check.stashed"))
+		case status.ProblemUnreadable:
+			labels = append(labels, i18n.T("status.check.unreadable"))
+		}
+	}
+	return strings.Join(labels, ", ")
+}
+
 func outputCompactTable(projects []status.ProjectDisplay) error {
 	// Use i18n keys for headers
 	headerName := i18n.T("status.header.name")
@@ -135,6 +310,11 @@ func outputVerboseTable(projects []status.ProjectDisplay) error {
 	headerPath := i18n.T("status.header.path")
 	headerGitManaged := i18n.T("status.header.gitManaged")
 	headerStatus := i18n.T("status.header.status")
+	headerBranch := i18n.T("status.header.branch")
+	headerSync := i18n.T("status.header.sync")
+	headerChanges := i18n.T("status.header.changes")
+	headerStash := i18n.T("status.header.stash")
+	headerLastCommit := i18n.T("status.header.lastCommit")
 
 	// Calculate minimum widths
 	minNameWidth := max(runewidth.StringWidth(headerName), 20)
@@ -143,6 +323,10 @@ func outputVerboseTable(projects []status.ProjectDisplay) error {
 	minPathWidth := max(runewidth.StringWidth(headerPath), 30)
 	minGitManagedWidth := max(runewidth.StringWidth(headerGitManaged), 10)
 	minStatusWidth := max(runewidth.StringWidth(headerStatus), 8)
+	minBranchWidth := runewidth.StringWidth(headerBranch)
+	minSyncWidth := runewidth.StringWidth(headerSync)
+	minChangesWidth := runewidth.StringWidth(headerChanges)
+	minStashWidth := runewidth.StringWidth(headerStash)
 
 	// Calculate max content width
 	for _, proj := range projects {
@@ -175,46 +359,84 @@ func outputVerboseTable(projects []status.ProjectDisplay) error {
 		if statusLen > minStatusWidth {
 			minStatusWidth = statusLen
 		}
+
+		branchLen := runewidth.StringWidth(proj.Branch)
+		if branchLen > minBranchWidth && branchLen < 30 {
+			minBranchWidth = branchLen
+		}
+
+		minSyncWidth = max(minSyncWidth, runewidth.StringWidth(proj.Sync))
+		minChangesWidth = max(minChangesWidth, runewidth.StringWidth(proj.Changes))
+		minStashWidth = max(minStashWidth, runewidth.StringWidth(proj.Stash))
 	}
 
 	// Print header
-	fmt.Printf("%s  %s  %s  %s  %s  %s\n",
+	fmt.Printf("%s  %s  %s  %s  %s  %s  %s  %s  %s  %s  %s\n",
 		padRight(headerName, minNameWidth),
 		padRight(headerWorkspace, minWorkspaceWidth),
 		padRight(headerRoot, minRootWidth),
 		padRight(headerPath, minPathWidth),
 		padRight(headerGitManaged, minGitManagedWidth),
 		padRight(headerStatus, minStatusWidth),
+		padRight(headerBranch, minBranchWidth),
+		padRight(headerSync, minSyncWidth),
+		padRight(headerChanges, minChangesWidth),
+		padRight(headerStash, minStashWidth),
+		headerLastCommit,
 	)
 
 	// Print separator
-	fmt.Printf("%s  %s  %s  %s  %s  %s\n",
+	fmt.Printf("%s  %s  %s  %s  %s  %s  %s  %s  %s  %s  %s\n",
 		strings.Repeat("-", minNameWidth),
 		strings.Repeat("-", minWorkspaceWidth),
 		strings.Repeat("-", minRootWidth),
 		strings.Repeat("-", minPathWidth),
 		strings.Repeat("-", minGitManagedWidth),
 		strings.Repeat("-", minStatusWidth),
+		strings.Repeat("-", minBranchWidth),
+		strings.Repeat("-", minSyncWidth),
+		strings.Repeat("-", minChangesWidth),
+		strings.Repeat("-", minStashWidth),
+		strings.Repeat("-", runewidth.StringWidth(headerLastCommit)),
 	)
 
 	// Print data
 	for _, proj := range projects {
 		displayName := truncateString(proj.Repo, minNameWidth)
 		displayPath := truncateString(proj.FullPath, minPathWidth)
+		displayBranch := truncateString(proj.Branch, minBranchWidth)
 
-		fmt.Printf("%s  %s  %s  %s  %s  %s\n",
+		fmt.Printf("%s  %s  %s  %s  %s  %s  %s  %s  %s  %s  %s\n",
 			padRight(displayName, minNameWidth),
 			padRight(proj.Workspace, minWorkspaceWidth),
 			padRight(string(proj.RawProject.Root), minRootWidth),
 			padRight(displayPath, minPathWidth),
 			padRight(proj.GitManaged, minGitManagedWidth),
 			padRight(proj.Status, minStatusWidth),
+			padRight(displayBranch, minBranchWidth),
+			padRight(proj.Sync, minSyncWidth),
+			padRight(proj.Changes, minChangesWidth),
+			padRight(proj.Stash, minStashWidth),
+			proj.LastCommit,
 		)
 	}
 
 	return nil
 }
 
+// outputExcluded lists the directories skipped by exclude patterns, if any.
+func outputExcluded(w io.Writer, excluded []fs.Exclusion) {
+	if len(excluded) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, i18n.T("status.excluded.header")+"\n", len(excluded))
+	for _, e := range excluded {
+		fmt.Fprintf(w, "  %s  (%s)\n", e.Path, e.Pattern)
+	}
+}
+
 // padRight pads a string to the right with spaces to match the specified width
 func padRight(s string, width int) string {
 	return runewidth.FillRight(s, width)
@@ -4,12 +4,16 @@ import (
 	"io"
 
 	"os"
+	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
 	"github.com/mi8bi/ghqx/internal/app"
 	"github.com/mi8bi/ghqx/internal/config"
+	"github.com/mi8bi/ghqx/internal/fs"
+	"github.com/mi8bi/ghqx/internal/i18n"
 	"github.com/mi8bi/ghqx/internal/status"
 )
 
@@ -357,3 +361,201 @@ func TestOutputVerboseTableWithLongPaths(t *testing.T) {
 	os.Stdout = oldStdout
 	_, _ = io.ReadAll(r)
 }
+
+func TestRunStatusExportFormats(t *testing.T) {
+	tmp := t.TempDir()
+	repo := filepath.Join(tmp, "github.com", "user", "repo")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	oldApp := application
+	application = app.New(&config.Config{Roots: map[string]string{"sandbox": tmp}})
+	defer func() { application = oldApp }()
+
+	oldFormat, oldTemplate := statusFormat, statusTemplate
+	defer func() { statusFormat, statusTemplate = oldFormat, oldTemplate }()
+
+	testCases := []struct {
+		format   string
+		template string
+		want     string
+	}{
+		{"json", "", `"path": "` + filepath.ToSlash(repo)},
+		{"ndjson", "", `"name":"github.com/user/repo"`},
+		{"csv", "", "name,display_name,root"},
+		{"tsv", "", "name\tdisplay_name\troot"},
+		{"table", "{{.Root}}={{.Name}}", "sandbox=github.com/user/repo\n"},
+	}
+
+	for _, tc := range testCases {
+		statusFormat, statusTemplate = tc.format, tc.template
+		var buf strings.Builder
+		if err := runStatusExport(&buf, status.Options{}); err != nil {
+			t.Fatalf("%s: runStatusExport failed: %v", tc.format, err)
+		}
+		if !strings.Contains(filepath.ToSlash(buf.String()), tc.want) {
+			t.Errorf("%s: output missing %q:\n%s", tc.format, tc.want, buf.String())
+		}
+	}
+
+	statusFormat, statusTemplate = "xml", ""
+	if err := runStatusExport(io.Discard, status.Options{}); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestStatusQueryOptions(t *testing.T) {
+	oldFilter, oldSort := statusFilter, statusSort
+	defer func() { statusFilter, statusSort = oldFilter, oldSort }()
+
+	statusFilter = status.Filter{Workspace: "dev", Dirty: true, Query: "ghqx"}
+	statusSort = "last-commit"
+	opts, err := statusQueryOptions()
+	if err != nil {
+		t.Fatalf("statusQueryOptions failed: %v", err)
+	}
+	if opts.Filter != statusFilter || opts.Sort != status.SortLastCommit {
+		t.Errorf("unexpected options: %+v", opts)
+	}
+
+	statusSort = "size"
+	if _, err := statusQueryOptions(); err == nil {
+		t.Error("expected error for unknown sort key")
+	}
+}
+
+func TestRunStatusCheck(t *testing.T) {
+	if exec.Command("git", "--version").Run() != nil {
+		t.Skip("git not available on this system")
+	}
+
+	tmp := t.TempDir()
+	clean := filepath.Join(tmp, "github.com", "user", "clean")
+	dirty := filepath.Join(tmp, "github.com", "user", "dirty")
+	plain := filepath.Join(tmp, "github.com", "user", "plain")
+	for _, dir := range []string{clean, dirty, plain} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+	for _, dir := range []string{clean, dirty} {
+		if out, err := exec.Command("git", "-C", dir, "init").CombinedOutput(); err != nil {
+			t.Fatalf("git init: %v: %s", err, out)
+		}
+	}
+
+	oldApp := application
+	application = app.New(&config.Config{Roots: map[string]string{"sandbox": tmp}})
+	defer func() { application = oldApp }()
+
+	var buf strings.Builder
+	if err := runStatusCheck(&buf, status.Options{}); err != nil {
+		t.Fatalf("expected clean repositories to pass: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dirty, "new.txt"), []byte("x"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	buf.Reset()
+	err := runStatusCheck(&buf, status.Options{})
+	if err == nil {
+		t.Fatal("expected error for dirty repository")
+	}
+	if code := exitCode(err); code != int(status.ProblemUncommitted) {
+		t.Errorf("exit code = %d, want %d", code, status.ProblemUncommitted)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "user/dirty") || strings.Contains(out, "user/clean") || strings.Contains(out, "user/plain") {
+		t.Errorf("unexpected check output:\n%s", out)
+	}
+}
+
+func TestRunStatusCheckToleratesSlowGit(t *testing.T) {
+	realGit, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git not available on this system")
+	}
+	if runtime.GOOS == "windows" {
+		t.Skip("git wrapper script requires a POSIX shell")
+	}
+
+	tmp := t.TempDir()
+	repo := filepath.Join(tmp, "root", "github.com", "user", "large")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if out, err := exec.Command(realGit, "-C", repo, "init").CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v: %s", err, out)
+	}
+
+	// Every git call takes longer than the default interactive timeout
+	bin := filepath.Join(tmp, "bin")
+	if err := os.Mkdir(bin, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	script := "#!/bin/sh\nsleep 0.5\nexec '" + realGit + "' \"$@\"\n"
+	if err := os.WriteFile(filepath.Join(bin, "git"), []byte(script), 0755); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	oldApp := application
+	application = app.New(&config.Config{Roots: map[string]string{"sandbox": filepath.Join(tmp, "root")}})
+	defer func() { application = oldApp }()
+
+	var buf strings.Builder
+	if err := runStatusCheck(&buf, status.Options{}); err != nil {
+		t.Errorf("slow repository should not be reported as unreadable: %v\n%s", err, buf.String())
+	}
+}
+
+func TestRunStatusCheckUnreadable(t *testing.T) {
+	if exec.Command("git", "--version").Run() != nil {
+		t.Skip("git not available on this system")
+	}
+
+	tmp := t.TempDir()
+	broken := filepath.Join(tmp, "github.com", "user", "broken")
+	if err := os.MkdirAll(filepath.Join(broken, ".git"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	oldApp := application
+	application = app.New(&config.Config{Roots: map[string]string{"sandbox": tmp}})
+	defer func() { application = oldApp }()
+
+	var buf strings.Builder
+	err := runStatusCheck(&buf, status.Options{})
+	if err == nil {
+		t.Fatal("expected error for repository whose status cannot be read")
+	}
+	if code := exitCode(err); code != int(status.ProblemUnreadable) {
+		t.Errorf("exit code = %d, want %d", code, status.ProblemUnreadable)
+	}
+	if !strings.Contains(buf.String(), i18n.T("status.check.unreadable")) {
+		t.Errorf("unexpected check output:\n%s", buf.String())
+	}
+}
+
+func TestFormatCheckProblems(t *testing.T) {
+	got := formatCheckProblems(status.ProblemUncommitted | status.ProblemStashed)
+	want := i18n.T("status.check.uncommitted") + ", " + i18n.T("status.check.stashed")
+	if got != want {
+		t.Errorf("formatCheckProblems = %q, want %q", got, want)
+	}
+}
+
+func TestOutputExcluded(t *testing.T) {
+	var buf strings.Builder
+	outputExcluded(&buf, nil)
+	if buf.Len() != 0 {
+		t.Errorf("expected no output without exclusions, got %q", buf.String())
+	}
+
+	outputExcluded(&buf, []fs.Exclusion{{Path: "/root/dev/mnt", Pattern: "/mnt"}})
+	if !strings.Contains(buf.String(), "/root/dev/mnt  (/mnt)") {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+}
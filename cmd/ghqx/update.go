@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/mi8bi/ghqx/internal/domain"
+	"github.com/mi8bi/ghqx/internal/i18n"
+	"github.com/mi8bi/ghqx/internal/update"
+	"github.com/spf13/cobra"
+)
+
+var (
+	updateWorkspace string
+	updateFetchOnly bool
+	updateParallel  int
+)
+
+var updateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "", // Will be set in root.go init() after locale is determined
+	Long:  "", // Will be set in root.go init() after locale is determined
+	Args:  cobra.NoArgs,
+	RunE:  runUpdate,
+}
+
+func init() {
+	updateCmd.Flags().StringVar(&updateWorkspace, "workspace", "", i18n.T("update.flag.workspace"))
+	updateCmd.Flags().BoolVar(&updateFetchOnly, "fetch-only", false, i18n.T("update.flag.fetchOnly"))
+	updateCmd.Flags().IntVarP(&updateParallel, "parallel", "p", 4, i18n.T("update.flag.parallel"))
+	_ = updateCmd.RegisterFlagCompletionFunc("workspace", completeWorkspaces)
+}
+
+// runUpdate fetches every repository and fast-forwards the clean ones,
+// then prints a per-repository result table.
+func runUpdate(cmd *cobra.Command, args []string) error {
+	if err := loadApp(); err != nil {
+		return err
+	}
+
+	service := update.NewService(application.Config, application.Status)
+	opts := update.Options{
+		Workspace: updateWorkspace,
+		FetchOnly: updateFetchOnly,
+		Parallel:  updateParallel,
+	}
+
+	results, err := service.Run(opts, nil)
+	if err != nil {
+		return err
+	}
+
+	outputUpdateTable(results)
+
+	counts := make(map[update.ResultStatus]int)
+	for _, r := range results {
+		counts[r.Status]++
+	}
+
+	fmt.Println()
+	fmt.Printf(i18n.T("update.summary")+"\n",
+		counts[update.StatusUpdated],
+		counts[update.StatusUpToDate],
+		counts[update.StatusSkippedDirty]+counts[update.StatusNoUpstream],
+		counts[update.StatusFailed],
+	)
+
+	if counts[update.StatusFailed] > 0 {
+		return domain.NewError(
+			domain.ErrCodeGitError,
+			fmt.Sprintf(i18n.T("update.failed"), counts[update.StatusFailed]),
+		)
+	}
+
+	return nil
+}
+
+// outputUpdateTable prints the update results as an aligned table.
+func outputUpdateTable(results []update.Result) {
+	headerName := i18n.T("status.header.name")
+	headerWorkspace := i18n.T("status.header.workspace")
+	headerResult := i18n.T("update.header.result")
+
+	nameWidth := runewidth.StringWidth(headerName)
+	workspaceWidth := runewidth.StringWidth(headerWorkspace)
+	resultWidth := runewidth.StringWidth(headerResult)
+
+	for _, r := range results {
+		nameWidth = max(nameWidth, runewidth.StringWidth(r.Project.DisplayName))
+		workspaceWidth = max(workspaceWidth, runewidth.StringWidth(string(r.Project.Root)))
+		resultWidth = max(resultWidth, runewidth.StringWidth(formatUpdateStatus(r.Status)))
+	}
+
+	fmt.Printf("%s  %s  %s\n",
+		padRight(headerName, nameWidth),
+		padRight(headerWorkspace, workspaceWidth),
+		headerResult,
+	)
+	fmt.Printf("%s  %s  %s\n",
+		strings.Repeat("-", nameWidth),
+		strings.Repeat("-", workspaceWidth),
+		strings.Repeat("-", resultWidth),
+	)
+
+	for _, r := range results {
+		line := fmt.Sprintf("%s  %s  %s",
+			padRight(r.Project.DisplayName, nameWidth),
+			padRight(string(r.Project.Root), workspaceWidth),
+			formatUpdateStatus(r.Status),
+		)
+		if r.Err != nil {
+			line += "  " + describeUpdateError(r.Err)
+		}
+		fmt.Println(line)
+	}
+}
+
+// formatUpdateStatus returns the localized label for an update result.
+func formatUpdateStatus(s update.ResultStatus) string {
+	return i18n.T("update.status." + string(s))
+}
+
+// describeUpdateError returns a one-line description of an update failure.
+// Git's own stderr is kept in the error's internal details and is the most useful part.
+func describeUpdateError(err error) string {
+	if ghqxErr, ok := err.(*domain.GhqxError); ok {
+		if ghqxErr.Internal != "" {
+			lines := strings.Split(ghqxErr.Internal, "\n")
+			return ghqxErr.Message + " (" + strings.TrimSpace(lines[0]) + ")"
+		}
+		return ghqxErr.Message
+	}
+	return err.Error()
+}
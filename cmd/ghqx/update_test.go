@@ -0,0 +1,54 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mi8bi/ghqx/internal/domain"
+	"github.com/mi8bi/ghqx/internal/update"
+)
+
+func TestRunUpdateWithLoadAppError(t *testing.T) {
+	oldConfigPath := configPath
+	configPath = "/nonexistent/config.toml"
+	defer func() { configPath = oldConfigPath }()
+
+	if err := runUpdate(updateCmd, []string{}); err == nil {
+		t.Fatal("expected error when loadApp fails")
+	}
+}
+
+func TestOutputUpdateTable(t *testing.T) {
+	results := []update.Result{
+		{Project: domain.Project{DisplayName: "user/a", Root: "dev"}, Status: update.StatusUpdated},
+		{Project: domain.Project{DisplayName: "user/b", Root: "dev"}, Status: update.StatusFailed, Err: errors.New("boom")},
+	}
+	outputUpdateTable(results)
+}
+
+func TestFormatUpdateStatus(t *testing.T) {
+	for _, s := range []update.ResultStatus{
+		update.StatusUpdated,
+		update.StatusUpToDate,
+		update.StatusSkippedDirty,
+		update.StatusNoUpstream,
+		update.StatusFailed,
+	} {
+		if got := formatUpdateStatus(s); got == "" || strings.HasPrefix(got, "MISSING_TRANSLATION") {
+			t.Errorf("missing label for %s: %q", s, got)
+		}
+	}
+}
+
+func TestDescribeUpdateError(t *testing.T) {
+	err := domain.ErrGitCommandFailed("merge", errors.New("exit 128")).
+		WithInternal("fatal: Not possible to fast-forward, aborting.\nmore")
+	got := describeUpdateError(err)
+	if got == "" || got == err.Message {
+		t.Errorf("expected git stderr in description, got %q", got)
+	}
+	if describeUpdateError(errors.New("plain")) != "plain" {
+		t.Errorf("unexpected description for plain error")
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/mi8bi/ghqx/internal/domain"
+	"github.com/mi8bi/ghqx/internal/i18n"
+	"github.com/mi8bi/ghqx/internal/status"
+	"github.com/mi8bi/ghqx/internal/ui"
+	"github.com/mi8bi/ghqx/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+var (
+	worktreeAddFrom         string
+	worktreeAddWorkspace    string
+	worktreeRemoveWorkspace string
+	worktreeRemoveForce     bool
+)
+
+var worktreeCmd = &cobra.Command{
+	Use:   "worktree",
+	Short: "", // Will be set in root.go init() after locale is determined
+	Long:  "", // Will be set in root.go init() after locale is determined
+}
+
+var worktreeAddCmd = &cobra.Command{
+	Use:   "add <project> <branch>",
+	Short: "", // Will be set in root.go init() after locale is determined
+	Long:  "", // Will be set in root.go init() after locale is determined
+	Args:  cobra.ExactArgs(2),
+	RunE:  runWorktreeAdd,
+
+	ValidArgsFunction: completeWorktreeAddArgs,
+}
+
+var worktreeListCmd = &cobra.Command{
+	Use:     "list [project]",
+	Aliases: []string{"ls"},
+	Short:   "", // Will be set in root.go init() after locale is determined
+	Long:    "", // Will be set in root.go init() after locale is determined
+	Args:    cobra.MaximumNArgs(1),
+	RunE:    runWorktreeList,
+
+	ValidArgsFunction: completeProjectNames,
+}
+
+var worktreeRemoveCmd = &cobra.Command{
+	Use:     "remove <worktree>",
+	Aliases: []string{"rm"},
+	Short:   "", // Will be set in root.go init() after locale is determined
+	Long:    "", // Will be set in root.go init() after locale is determined
+	Args:    cobra.ExactArgs(1),
+	RunE:    runWorktreeRemove,
+
+	ValidArgsFunction: completeProjectNames,
+}
+
+func init() {
+	worktreeAddCmd.Flags().StringVar(&worktreeAddFrom, "from", "", i18n.T("worktree.add.flag.from"))
+	worktreeAddCmd.Flags().StringVar(&worktreeAddWorkspace, "workspace", "", i18n.T("worktree.add.flag.workspace"))
+	worktreeRemoveCmd.Flags().StringVar(&worktreeRemoveWorkspace, "workspace", "", i18n.T("worktree.remove.flag.workspace"))
+	worktreeRemoveCmd.Flags().BoolVar(&worktreeRemoveForce, "force", false, i18n.T("worktree.remove.flag.force"))
+	_ = worktreeAddCmd.RegisterFlagCompletionFunc("from", completeWorkspaces)
+	_ = worktreeAddCmd.RegisterFlagCompletionFunc("workspace", completeWorkspaces)
+	_ = worktreeRemoveCmd.RegisterFlagCompletionFunc("workspace", completeWorkspaces)
+
+	worktreeCmd.AddCommand(worktreeAddCmd)
+	worktreeCmd.AddCommand(worktreeListCmd)
+	worktreeCmd.AddCommand(worktreeRemoveCmd)
+}
+
+// completeWorktreeAddArgs completes the project; branch names are left to the user.
+func completeWorktreeAddArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return completeProjectNames(cmd, args, toComplete)
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+// runWorktreeAdd creates a worktree of a project on a branch and prints its path.
+func runWorktreeAdd(cmd *cobra.Command, args []string) error {
+	if err := loadApp(); err != nil {
+		return err
+	}
+
+	service := worktree.NewService(application.Config, application.Status)
+	result, err := service.Add(worktree.AddOptions{
+		Project:   args[0],
+		Branch:    args[1],
+		From:      worktreeAddFrom,
+		Workspace: worktreeAddWorkspace,
+	})
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	fmt.Fprint(w, ui.FormatSuccess(fmt.Sprintf(i18n.T("worktree.add.success"), args[1], result.Root)))
+	fmt.Fprintln(w, result.Path)
+	return nil
+}
+
+// runWorktreeList prints the linked worktrees, optionally of a single repository.
+func runWorktreeList(cmd *cobra.Command, args []string) error {
+	if err := loadApp(); err != nil {
+		return err
+	}
+
+	project := ""
+	if len(args) == 1 {
+		project = args[0]
+	}
+
+	service := worktree.NewService(application.Config, application.Status)
+	worktrees, err := service.List(project)
+	if err != nil {
+		return err
+	}
+
+	outputWorktreeTable(cmd.OutOrStdout(), worktrees)
+	return nil
+}
+
+// runWorktreeRemove removes a linked worktree, refusing dirty ones unless --force is given.
+func runWorktreeRemove(cmd *cobra.Command, args []string) error {
+	if err := loadApp(); err != nil {
+		return err
+	}
+
+	service := worktree.NewService(application.Config, application.Status)
+	result, err := service.Remove(args[0], worktreeRemoveWorkspace, worktreeRemoveForce)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), ui.FormatSuccess(fmt.Sprintf(i18n.T("worktree.remove.success"), result.Path)))
+	return nil
+}
+
+// outputWorktreeTable prints worktrees with their branch and main repository.
+func outputWorktreeTable(w io.Writer, worktrees []domain.Project) {
+	if len(worktrees) == 0 {
+		fmt.Fprint(w, ui.FormatInfo(i18n.T("worktree.list.empty")))
+		return
+	}
+
+	headers := [4]string{
+		i18n.T("status.header.name"),
+		i18n.T("status.header.workspace"),
+		i18n.T("status.header.branch"),
+		i18n.T("worktree.header.repository"),
+	}
+
+	var widths [4]int
+	for i, h := range headers {
+		widths[i] = runewidth.StringWidth(h)
+	}
+	rows := make([][4]string, len(worktrees))
+	for i, p := range worktrees {
+		display := status.NewProjectDisplay(p)
+		rows[i] = [4]string{display.Repo, display.Workspace, display.Branch, p.WorktreeOf}
+		for j, cell := range rows[i] {
+			widths[j] = max(widths[j], runewidth.StringWidth(cell))
+		}
+	}
+
+	fmt.Fprintf(w, "%s  %s  %s  %s\n", padRight(headers[0], widths[0]), padRight(headers[1], widths[1]), padRight(headers[2], widths[2]), headers[3])
+	fmt.Fprintf(w, "%s  %s  %s  %s\n",
+		strings.Repeat("-", widths[0]),
+		strings.Repeat("-", widths[1]),
+		strings.Repeat("-", widths[2]),
+		strings.Repeat("-", widths[3]),
+	)
+	for _, row := range rows {
+		fmt.Fprintf(w, "%s  %s  %s  %s\n", padRight(row[0], widths[0]), padRight(row[1], widths[1]), padRight(row[2], widths[2]), row[3])
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mi8bi/ghqx/internal/domain"
+	"github.com/mi8bi/ghqx/internal/i18n"
+)
+
+func TestOutputWorktreeTable(t *testing.T) {
+	var buf strings.Builder
+	outputWorktreeTable(&buf, nil)
+	if !strings.Contains(buf.String(), i18n.T("worktree.list.empty")) {
+		t.Errorf("expected empty message, got %q", buf.String())
+	}
+
+	buf.Reset()
+	outputWorktreeTable(&buf, []domain.Project{{
+		Name:          "github.com/user/repo@fix",
+		DisplayName:   "user/repo@fix",
+		WorkspaceType: domain.WorkspaceTypeDev,
+		Branch:        "fix",
+		WorktreeOf:    "/work/dev/github.com/user/repo",
+	}})
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header, separator and one row, got:\n%s", buf.String())
+	}
+	for _, want := range []string{"user/repo@fix", "dev", "fix", "/work/dev/github.com/user/repo"} {
+		if !strings.Contains(lines[2], want) {
+			t.Errorf("row %q should contain %q", lines[2], want)
+		}
+	}
+}
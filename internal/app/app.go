@@ -1,7 +1,11 @@
 package app
 
 import (
+	"time"
+
 	"github.com/mi8bi/ghqx/internal/config"
+	"github.com/mi8bi/ghqx/internal/ghq"
+	"github.com/mi8bi/ghqx/internal/index"
 	"github.com/mi8bi/ghqx/internal/status"
 )
 
@@ -12,6 +16,8 @@ type App struct {
 	Config *config.Config
 	// Status provides project scanning and status management services
 	Status *status.Service
+	// Index is the persistent project index (nil when caching is disabled)
+	Index *index.Index
 }
 
 // New creates a new application instance with the given configuration.
@@ -23,11 +29,36 @@ func New(cfg *config.Config) *App {
 	}
 }
 
+// EnableIndex turns on the persistent project index stored in dir,
+// so project scans are served from the cache while it is up to date.
+func (a *App) EnableIndex(dir string) {
+	a.Index = index.New(dir, a.Status.Scanner())
+	a.Status.SetIndex(a.Index)
+}
+
+// Wait blocks until background work such as index refreshes has finished.
+func (a *App) Wait() {
+	if a.Index != nil {
+		a.Index.Wait()
+	}
+}
+
+// WaitTimeout is like Wait but gives up after timeout, so a slow refresh
+// never holds up the command that started it. It reports whether all
+// background work finished.
+func (a *App) WaitTimeout(timeout time.Duration) bool {
+	if a.Index != nil {
+		return a.Index.WaitTimeout(timeout)
+	}
+	return true
+}
+
 // NewFromConfigPath creates an app instance by loading configuration from a file path.
 // This is a convenience constructor that handles loading the config file.
 // If configPath is empty, it will use the default config location.
-func NewFromConfigPath(configPath string) (*App, error) {
-	loader := config.NewLoader()
+// If profile is empty, the profile is selected by GHQX_PROFILE or the config file.
+func NewFromConfigPath(configPath, profile string) (*App, error) {
+	loader := config.NewLoader().WithProfile(profile)
 	cfg, err := loader.Load(configPath)
 	if err != nil {
 		return nil, err
@@ -35,3 +66,15 @@ func NewFromConfigPath(configPath string) (*App, error) {
 
 	return New(cfg), nil
 }
+
+// NewFromConfigPathStrict is NewFromConfigPath, but fails on every problem
+// 'ghqx config validate' reports, such as missing or overlapping root directories.
+func NewFromConfigPathStrict(configPath, profile string) (*App, error) {
+	loader := config.NewLoader().WithProfile(profile)
+	cfg, err := loader.LoadStrict(configPath, ghq.Roots())
+	if err != nil {
+		return nil, err
+	}
+
+	return New(cfg), nil
+}
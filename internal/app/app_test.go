@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/mi8bi/ghqx/internal/config"
 )
@@ -31,7 +32,7 @@ func TestNewAndNewFromConfigPath(t *testing.T) {
 		t.Fatalf("Save failed: %v", err)
 	}
 
-	a2, err := NewFromConfigPath(path)
+	a2, err := NewFromConfigPath(path, "")
 	if err != nil {
 		t.Fatalf("NewFromConfigPath failed: %v", err)
 	}
@@ -39,3 +40,18 @@ func TestNewAndNewFromConfigPath(t *testing.T) {
 		t.Fatalf("NewFromConfigPath returned invalid App")
 	}
 }
+
+func TestEnableIndex(t *testing.T) {
+	a := New(config.NewDefaultConfig())
+	a.Wait() // No index yet
+	if !a.WaitTimeout(time.Second) {
+		t.Error("WaitTimeout without an index should finish")
+	}
+
+	dir := t.TempDir()
+	a.EnableIndex(dir)
+	if a.Index == nil || a.Index.Dir() != dir {
+		t.Fatalf("index not enabled")
+	}
+	a.Wait()
+}
@@ -3,18 +3,45 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/mi8bi/ghqx/internal/domain"
+	"github.com/mi8bi/ghqx/internal/fs"
 )
 
 // Config represents the ghqx application configuration.
 // It defines the workspace roots and default settings.
+// Its TOML tags describe the version 1 file layout; files are written
+// in the current layout (see SchemaVersion).
 type Config struct {
+	// Version is the schema version of the file the configuration was read from
+	// (zero for configurations not read from a file)
+	Version int `toml:"-"`
 	// Roots maps root names to their filesystem paths
 	// Example: {"dev": "/home/user/ghqx/dev", "sandbox": "/home/user/ghqx/sandbox"}
 	Roots map[string]string `toml:"roots"`
 	// Default specifies default settings like which root to use
 	Default DefaultConfig `toml:"default"`
+	// Clone configures how `ghqx get` clones repositories
+	Clone CloneConfig `toml:"clone,omitempty"`
+	// Scan configures how roots are searched for projects
+	Scan ScanConfig `toml:"scan,omitempty"`
+	// RootOptions holds settings for individual roots, keyed by root name
+	RootOptions map[string]RootOptions `toml:"root_options,omitempty"`
+
+	// rawRoots keeps root paths as written (e.g. "~/src/dev") when they differ
+	// from the expanded paths in Roots, so Save writes them back unchanged
+	rawRoots map[string]string
+	// profile is the active profile ("" for the top-level roots); see UseProfile
+	profile string
+	// defaultProfile is the profile selected in the file ("" for the top-level roots)
+	defaultProfile string
+	// profiles holds the roots of the profiles that are not active,
+	// including the top-level roots under "" while a profile is active
+	profiles map[string]rootSet
 }
 
 // DefaultConfig represents default application settings.
@@ -23,21 +50,241 @@ type DefaultConfig struct {
 	Root string `toml:"root"`
 }
 
-// Validate checks if the configuration is valid.
+// Clone backends supported by `ghqx get`.
+const (
+	// CloneBackendAuto uses ghq when it is installed and falls back to git otherwise
+	CloneBackendAuto = "auto"
+	// CloneBackendGhq always delegates to the ghq command
+	CloneBackendGhq = "ghq"
+	// CloneBackendGit clones with plain git into <root>/<host>/<owner>/<repo>
+	CloneBackendGit = "git"
+)
+
+// CloneConfig represents repository cloning settings.
+type CloneConfig struct {
+	// Backend selects the clone implementation (auto, ghq or git). Empty means auto.
+	Backend string `toml:"backend,omitempty"`
+}
+
+// ScanConfig represents project discovery settings.
+type ScanConfig struct {
+	// MaxDepth is the deepest directory level below a root checked for projects.
+	// Zero means the default (5), which covers host/owner/repo plus nested groups.
+	MaxDepth int `toml:"max_depth,omitempty"`
+	// Exclude lists gitignore-style patterns of directories to skip in every root
+	Exclude []string `toml:"exclude,omitempty"`
+}
+
+// RootOptions represents settings that apply to a single root.
+type RootOptions struct {
+	// Exclude lists gitignore-style patterns of directories to skip in this root,
+	// in addition to scan.exclude and the root's .ghqxignore file
+	Exclude []string `toml:"exclude,omitempty"`
+	// Layout is how projects are arranged in this root: "ghq" (host/owner/repo, default),
+	// "owner/repo", "flat" or a custom depth such as "4"
+	Layout string `toml:"layout,omitempty"`
+	// Type is the workspace type of this root (e.g. "dev", "archive").
+	// Empty means the type named like the root ("sandbox", "dev", "release") or "unknown".
+	Type string `toml:"type,omitempty"`
+	// Description is a free-form note shown next to the root
+	Description string `toml:"description,omitempty"`
+	// Color is the terminal color of the workspace: an ANSI number (0-255) or a hex color
+	Color string `toml:"color,omitempty"`
+	// CloneBackend overrides clone.backend for clones into this root
+	CloneBackend string `toml:"clone_backend,omitempty"`
+	// CloneFlags are extra arguments passed to `git clone` or `ghq get` for this root
+	CloneFlags []string `toml:"clone_flags,omitempty"`
+	// ReadOnly protects the root: ghqx never clones, moves or removes projects in it
+	ReadOnly bool `toml:"read_only,omitempty"`
+}
+
+// isZero reports whether no option is set.
+func (o RootOptions) isZero() bool {
+	return reflect.ValueOf(o).IsZero()
+}
+
+// Validate checks if the configuration is valid and returns the first problem.
+// Problems returns all of them.
 func (c *Config) Validate() error {
+	if problems := c.Problems(); len(problems) > 0 {
+		return problems[0]
+	}
+	return nil
+}
+
+// Problems checks the configuration itself (not the directories it names) and
+// returns every problem found, in a stable order.
+func (c *Config) Problems() []*domain.GhqxError {
+	var problems []*domain.GhqxError
+	report := func(err *domain.GhqxError) {
+		if err != nil {
+			problems = append(problems, err)
+		}
+	}
+
 	if len(c.Roots) == 0 {
-		return domain.ErrConfigNoRoots
+		report(domain.ErrConfigNoRoots)
+	}
+
+	if !c.HasProfile(c.DefaultProfile()) {
+		report(domain.ErrProfileNotFound(c.DefaultProfile(), c.Profiles()))
+	}
+
+	// An unset variable would silently turn "$WORK/dev" into "/dev"
+	for _, name := range sortedKeys(c.Roots) {
+		for _, variable := range UnsetPathVariables(c.RawRootPath(name)) {
+			report(domain.ErrConfigUnsetVariable(name, variable))
+		}
 	}
 
 	if c.Default.Root != "" {
 		if _, exists := c.Roots[c.Default.Root]; !exists {
-			return domain.ErrConfigInvalidDefaultRoot
+			report(domain.ErrConfigInvalidDefaultRoot)
+		}
+	}
+
+	if !isValidCloneBackend(c.Clone.Backend) {
+		report(domain.ErrConfigInvalidCloneBackend(c.Clone.Backend))
+	}
+
+	if c.Scan.MaxDepth < 0 {
+		report(domain.ErrConfigInvalidMaxDepth(c.Scan.MaxDepth))
+	}
+
+	report(validateExcludes(c.Scan.Exclude))
+	for _, name := range sortedKeys(c.RootOptions) {
+		opts := c.RootOptions[name]
+		if _, exists := c.Roots[name]; !exists {
+			report(domain.ErrConfigUnknownRootOptions(name))
 		}
+		report(validateExcludes(opts.Exclude))
+		if _, ok := domain.ParseLayout(opts.Layout); !ok {
+			report(domain.ErrConfigInvalidLayout(name, opts.Layout))
+		}
+		if opts.Type != "" && !domain.IsValidWorkspaceType(opts.Type) {
+			report(domain.ErrConfigInvalidWorkspaceType(name, opts.Type))
+		}
+		if opts.Color != "" && !isValidColor(opts.Color) {
+			report(domain.ErrConfigInvalidColor(name, opts.Color))
+		}
+		if !isValidCloneBackend(opts.CloneBackend) {
+			report(domain.ErrConfigInvalidCloneBackend(opts.CloneBackend))
+		}
+	}
+
+	return problems
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
+	return keys
+}
 
+// isValidCloneBackend reports whether backend is a known clone backend or empty.
+func isValidCloneBackend(backend string) bool {
+	switch backend {
+	case "", CloneBackendAuto, CloneBackendGhq, CloneBackendGit:
+		return true
+	}
+	return false
+}
+
+// isValidColor reports whether color is an ANSI color number (0-255)
+// or a hex color (#rgb or #rrggbb).
+func isValidColor(color string) bool {
+	if hex, ok := strings.CutPrefix(color, "#"); ok {
+		if len(hex) != 3 && len(hex) != 6 {
+			return false
+		}
+		_, err := strconv.ParseUint(hex, 16, 32)
+		return err == nil
+	}
+	n, err := strconv.Atoi(color)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// validateExcludes checks the glob syntax of exclude patterns.
+func validateExcludes(patterns []string) *domain.GhqxError {
+	for _, pattern := range patterns {
+		if !fs.ValidateExcludePattern(pattern) {
+			return domain.ErrConfigInvalidExclude(pattern)
+		}
+	}
 	return nil
 }
 
+// RootExcludes returns the exclude patterns of each root that has its own.
+func (c *Config) RootExcludes() map[string][]string {
+	excludes := make(map[string][]string)
+	for name, opts := range c.RootOptions {
+		if len(opts.Exclude) > 0 {
+			excludes[name] = opts.Exclude
+		}
+	}
+	return excludes
+}
+
+// RootLayout returns the layout of a root, defaulting to the ghq layout.
+func (c *Config) RootLayout(name string) domain.Layout {
+	layout, ok := domain.ParseLayout(c.RootOptions[name].Layout)
+	if !ok {
+		return domain.LayoutGhq
+	}
+	return layout
+}
+
+// RootLayouts returns the layout of each root that does not use the ghq layout.
+func (c *Config) RootLayouts() map[string]domain.Layout {
+	layouts := make(map[string]domain.Layout)
+	for name := range c.RootOptions {
+		if layout := c.RootLayout(name); layout != domain.LayoutGhq {
+			layouts[name] = layout
+		}
+	}
+	return layouts
+}
+
+// RootWorkspaceType returns the workspace type of a root: its declared type,
+// or the type matching its name when none is declared.
+func (c *Config) RootWorkspaceType(name string) domain.WorkspaceType {
+	if t := c.RootOptions[name].Type; t != "" {
+		return domain.WorkspaceType(t)
+	}
+	return domain.DetermineWorkspaceType(domain.RootName(name))
+}
+
+// RootWorkspaceTypes returns the workspace type of every root.
+func (c *Config) RootWorkspaceTypes() map[string]domain.WorkspaceType {
+	types := make(map[string]domain.WorkspaceType, len(c.Roots))
+	for name := range c.Roots {
+		types[name] = c.RootWorkspaceType(name)
+	}
+	return types
+}
+
+// Workspaces returns every root with its workspace type, description and color,
+// sorted by name.
+func (c *Config) Workspaces() []domain.Root {
+	roots := make([]domain.Root, 0, len(c.Roots))
+	for name, path := range c.Roots {
+		opts := c.RootOptions[name]
+		roots = append(roots, domain.Root{
+			Name:          domain.RootName(name),
+			Path:          path,
+			WorkspaceType: c.RootWorkspaceType(name),
+			Description:   opts.Description,
+			Color:         opts.Color,
+		})
+	}
+	sort.Slice(roots, func(i, j int) bool { return roots[i].Name < roots[j].Name })
+	return roots
+}
+
 // GetRoot returns the filesystem path for the given root name.
 // It returns empty string and false if the root name doesn't exist.
 func (c *Config) GetRoot(name string) (string, bool) {
@@ -60,6 +307,33 @@ func (c *Config) GetDefaultRoot() string {
 	return ""
 }
 
+// GetCloneBackend returns the configured clone backend, defaulting to auto.
+func (c *Config) GetCloneBackend() string {
+	if c.Clone.Backend == "" {
+		return CloneBackendAuto
+	}
+	return c.Clone.Backend
+}
+
+// RootCloneBackend returns the clone backend for a root: its own setting,
+// or clone.backend when it has none.
+func (c *Config) RootCloneBackend(name string) string {
+	if backend := c.RootOptions[name].CloneBackend; backend != "" {
+		return backend
+	}
+	return c.GetCloneBackend()
+}
+
+// RootCloneFlags returns the extra clone arguments of a root.
+func (c *Config) RootCloneFlags(name string) []string {
+	return c.RootOptions[name].CloneFlags
+}
+
+// IsReadOnly reports whether a root is protected from changes by ghqx.
+func (c *Config) IsReadOnly(name string) bool {
+	return c.RootOptions[name].ReadOnly
+}
+
 // NewDefaultConfig creates a default configuration with standard workspace roots.
 // Creates three roots: sandbox, dev, and release under $HOME/ghqx with sandbox as default.
 // This is the single source of truth for default configuration values.
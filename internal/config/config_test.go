@@ -81,3 +81,139 @@ func TestEnsureDirectoryErrors(t *testing.T) {
 		}
 	}
 }
+
+func TestCloneBackend(t *testing.T) {
+	c := &Config{Roots: map[string]string{"dev": "/tmp/dev"}}
+	if got := c.GetCloneBackend(); got != CloneBackendAuto {
+		t.Errorf("expected default backend auto, got %q", got)
+	}
+
+	for _, backend := range []string{CloneBackendAuto, CloneBackendGhq, CloneBackendGit} {
+		c.Clone.Backend = backend
+		if err := c.Validate(); err != nil {
+			t.Errorf("unexpected validate error for %q: %v", backend, err)
+		}
+		if got := c.GetCloneBackend(); got != backend {
+			t.Errorf("GetCloneBackend() = %q, want %q", got, backend)
+		}
+	}
+
+	c.Clone.Backend = "svn"
+	if err := c.Validate(); err == nil {
+		t.Errorf("expected error for unknown backend")
+	}
+}
+
+func TestScanMaxDepth(t *testing.T) {
+	c := &Config{Roots: map[string]string{"dev": "/tmp/dev"}}
+	for _, depth := range []int{0, 3, 8} {
+		c.Scan.MaxDepth = depth
+		if err := c.Validate(); err != nil {
+			t.Errorf("unexpected validate error for depth %d: %v", depth, err)
+		}
+	}
+
+	c.Scan.MaxDepth = -1
+	if err := c.Validate(); err == nil {
+		t.Errorf("expected error for negative depth")
+	}
+}
+
+func TestExcludesValidationAndRootExcludes(t *testing.T) {
+	c := &Config{
+		Roots: map[string]string{"dev": "/tmp/dev", "sandbox": "/tmp/sandbox"},
+		Scan:  ScanConfig{Exclude: []string{"tmp/", "**/cache"}},
+		RootOptions: map[string]RootOptions{
+			"dev":     {Exclude: []string{"github.com/archive"}},
+			"sandbox": {},
+		},
+	}
+	if err := c.Validate(); err != nil {
+		t.Fatalf("unexpected validate error: %v", err)
+	}
+
+	excludes := c.RootExcludes()
+	if len(excludes) != 1 || excludes["dev"][0] != "github.com/archive" {
+		t.Errorf("unexpected root excludes: %v", excludes)
+	}
+
+	c.Scan.Exclude = []string{"a/[b"}
+	if err := c.Validate(); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+
+	c.Scan.Exclude = nil
+	c.RootOptions["missing"] = RootOptions{}
+	if err := c.Validate(); err == nil {
+		t.Error("expected error for options of an unknown root")
+	}
+}
+
+func TestRootLayouts(t *testing.T) {
+	c := &Config{
+		Roots: map[string]string{"dev": "/tmp/dev", "sandbox": "/tmp/sandbox", "work": "/tmp/work"},
+		RootOptions: map[string]RootOptions{
+			"dev":     {Layout: "ghq"},
+			"sandbox": {Layout: "flat"},
+			"work":    {Layout: "4"},
+		},
+	}
+	if err := c.Validate(); err != nil {
+		t.Fatalf("unexpected validate error: %v", err)
+	}
+
+	layouts := c.RootLayouts()
+	if len(layouts) != 2 || layouts["sandbox"] != domain.LayoutFlat || layouts["work"] != domain.Layout(4) {
+		t.Errorf("unexpected root layouts: %v", layouts)
+	}
+	if got := c.RootLayout("missing"); got != domain.LayoutGhq {
+		t.Errorf("expected ghq layout by default, got %v", got)
+	}
+
+	c.RootOptions["work"] = RootOptions{Layout: "host/repo"}
+	if err := c.Validate(); err == nil {
+		t.Error("expected error for an unknown layout")
+	}
+}
+
+func TestRootWorkspaceTypes(t *testing.T) {
+	c := &Config{
+		Roots: map[string]string{"work": "/tmp/work", "oss": "/tmp/oss", "sandbox": "/tmp/sandbox", "misc": "/tmp/misc"},
+		RootOptions: map[string]RootOptions{
+			"work": {Type: "dev", Description: "Company projects", Color: "#ff8700"},
+			"oss":  {Type: "dev", Color: "81"},
+			"misc": {Type: "archive"},
+		},
+	}
+	if err := c.Validate(); err != nil {
+		t.Fatalf("unexpected validate error: %v", err)
+	}
+
+	want := map[string]domain.WorkspaceType{
+		"work":    domain.WorkspaceTypeDev,
+		"oss":     domain.WorkspaceTypeDev,
+		"sandbox": domain.WorkspaceTypeSandbox, // Named like a built-in type
+		"misc":    "archive",
+	}
+	types := c.RootWorkspaceTypes()
+	for name, wantType := range want {
+		if types[name] != wantType {
+			t.Errorf("type of %s = %q, want %q", name, types[name], wantType)
+		}
+	}
+
+	workspaces := c.Workspaces()
+	if len(workspaces) != 4 || workspaces[0].Name != "misc" || workspaces[3].Name != "work" {
+		t.Fatalf("workspaces should be sorted by name: %+v", workspaces)
+	}
+	if workspaces[3].Description != "Company projects" || workspaces[3].Color != "#ff8700" {
+		t.Errorf("unexpected workspace %+v", workspaces[3])
+	}
+
+	for _, opts := range []RootOptions{{Type: "Dev"}, {Type: "a b"}, {Color: "256"}, {Color: "#12345"}, {Color: "red"}} {
+		c.RootOptions["misc"] = opts
+		if err := c.Validate(); err == nil {
+			t.Errorf("expected error for %+v", opts)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/mi8bi/ghqx/internal/domain"
+)
+
+// Diagnose returns every problem of the configuration: those reported by Problems,
+// plus roots that are relative, missing, not directories, not writable (read_only
+// roots excepted), overlapping each other, or overlapping one of ghqRoots, the
+// directories managed by ghq itself.
+func (c *Config) Diagnose(ghqRoots []string) []*domain.GhqxError {
+	problems := c.Problems()
+
+	names := sortedKeys(c.Roots)
+	for _, name := range names {
+		if err := c.checkRootDir(name); err != nil {
+			problems = append(problems, err)
+		}
+	}
+
+	for i, name := range names {
+		for _, other := range names[i+1:] {
+			if PathsOverlap(c.Roots[name], c.Roots[other]) {
+				problems = append(problems, domain.ErrRootsOverlap(name, other))
+			}
+		}
+		for _, ghqRoot := range ghqRoots {
+			if PathsOverlap(c.Roots[name], ghqRoot) {
+				problems = append(problems, domain.ErrRootOverlapsGhq(name, ghqRoot))
+			}
+		}
+	}
+
+	return problems
+}
+
+// checkRootDir checks the directory of a root on disk.
+func (c *Config) checkRootDir(name string) *domain.GhqxError {
+	path := c.Roots[name]
+	if !filepath.IsAbs(path) {
+		// Anything else depends on the working directory
+		return domain.ErrRootRelativePath(name, path)
+	}
+
+	info, err := os.Stat(path)
+	switch {
+	case os.IsNotExist(err):
+		return domain.ErrRootDirNotExist(name, path)
+	case err != nil:
+		return domain.ErrRootNotWritable(name, path, err)
+	case !info.IsDir():
+		return domain.ErrRootNotDirectory(name, path)
+	}
+
+	if c.IsReadOnly(name) {
+		return nil
+	}
+	if err := checkWritable(path); err != nil {
+		return domain.ErrRootNotWritable(name, path, err)
+	}
+	return nil
+}
+
+// checkWritable creates and removes a temporary file in dir.
+// Permission bits alone miss ACLs, read-only mounts and running as root.
+func checkWritable(dir string) error {
+	f, err := os.CreateTemp(dir, ".ghqx-write-check-*")
+	if err != nil {
+		return err
+	}
+	name := f.Name()
+	f.Close()
+	return os.Remove(name)
+}
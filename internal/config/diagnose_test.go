@@ -0,0 +1,144 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mi8bi/ghqx/internal/domain"
+)
+
+func TestProblemsReportsEverything(t *testing.T) {
+	cfg := &Config{
+		Roots:   map[string]string{"dev": "/tmp/dev"},
+		Default: DefaultConfig{Root: "missing"},
+		Clone:   CloneConfig{Backend: "svn"},
+		Scan:    ScanConfig{MaxDepth: -1},
+		RootOptions: map[string]RootOptions{
+			"dev":   {Layout: "spiral", Color: "red"},
+			"ghost": {},
+		},
+	}
+
+	problems := cfg.Problems()
+	if len(problems) != 6 {
+		t.Fatalf("expected 6 problems, got %d: %v", len(problems), problems)
+	}
+	if err := cfg.Validate(); err != problems[0] {
+		t.Errorf("Validate should return the first problem, got %v", err)
+	}
+	if (&Config{Roots: map[string]string{"dev": "/tmp/dev"}}).Problems() != nil {
+		t.Error("valid configs should have no problems")
+	}
+}
+
+func TestDiagnose(t *testing.T) {
+	tmp := t.TempDir()
+	file := filepath.Join(tmp, "file")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	ghqRoot := filepath.Join(tmp, "ghq")
+	for _, dir := range []string{"dev", "dev/nested", "ghq/sub", "ok"} {
+		if err := os.MkdirAll(filepath.Join(tmp, dir), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+
+	cfg := &Config{
+		Roots: map[string]string{
+			"dev":      filepath.Join(tmp, "dev"),
+			"nested":   filepath.Join(tmp, "dev", "nested"),
+			"missing":  filepath.Join(tmp, "missing"),
+			"file":     file,
+			"relative": "relative/path",
+			"shared":   filepath.Join(ghqRoot, "sub"),
+			"ok":       filepath.Join(tmp, "ok"),
+		},
+		Default: DefaultConfig{Root: "ok"},
+	}
+
+	var messages []string
+	for _, problem := range cfg.Diagnose([]string{ghqRoot}) {
+		messages = append(messages, problem.Message)
+	}
+	want := []string{
+		domain.ErrRootNotDirectory("file", file).Message,
+		domain.ErrRootDirNotExist("missing", "").Message,
+		domain.ErrRootRelativePath("relative", "relative/path").Message,
+		domain.ErrRootsOverlap("dev", "nested").Message,
+		domain.ErrRootOverlapsGhq("shared", ghqRoot).Message,
+	}
+	if strings.Join(messages, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected problems:\n%s\nwant:\n%s", strings.Join(messages, "\n"), strings.Join(want, "\n"))
+	}
+
+	valid := &Config{Roots: map[string]string{"ok": filepath.Join(tmp, "ok")}}
+	if problems := valid.Diagnose(nil); len(problems) != 0 {
+		t.Errorf("expected no problems, got %v", problems)
+	}
+}
+
+func TestDiagnoseNotWritable(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("root can write to read-only directories")
+	}
+	dir := filepath.Join(t.TempDir(), "locked")
+	if err := os.Mkdir(dir, 0555); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	defer os.Chmod(dir, 0755)
+
+	cfg := &Config{Roots: map[string]string{"archive": dir}}
+	if problems := cfg.Diagnose(nil); len(problems) != 1 || problems[0].Code != domain.ErrCodeInvalidPath {
+		t.Errorf("expected a not writable problem, got %v", problems)
+	}
+
+	// read_only roots only need to be readable
+	cfg.RootOptions = map[string]RootOptions{"archive": {ReadOnly: true}}
+	if problems := cfg.Diagnose(nil); len(problems) != 0 {
+		t.Errorf("read_only roots need not be writable, got %v", problems)
+	}
+}
+
+func TestLoaderStrictAndDiagnose(t *testing.T) {
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, "config.toml")
+	data := "version = 2\n\n[roots.dev]\npath = \"" + filepath.ToSlash(filepath.Join(tmp, "missing")) + "\"\nlayout = \"spiral\"\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	loader := NewLoader()
+	found, problems, err := loader.Diagnose(path, nil)
+	if err != nil {
+		t.Fatalf("Diagnose should report problems, not fail: %v", err)
+	}
+	if found != path || len(problems) != 2 {
+		t.Errorf("expected 2 problems in %s, got %v", found, problems)
+	}
+
+	// Fix the layout: Load succeeds, LoadStrict still reports the missing directory
+	data = strings.Replace(data, "layout = \"spiral\"\n", "", 1)
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := loader.Load(path); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	_, err = loader.LoadStrict(path, nil)
+	if err == nil || !strings.Contains(err.(*domain.GhqxError).Message, domain.ErrRootDirNotExist("dev", "").Message) {
+		t.Errorf("expected the missing root in the strict error, got %v", err)
+	}
+	if err != nil && !strings.Contains(err.(*domain.GhqxError).Message, domain.ErrRootDirNotExist("dev", "").Hint) {
+		t.Errorf("expected the missing root's hint in the strict error, got %v", err)
+	}
+
+	if err := os.Mkdir(filepath.Join(tmp, "missing"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if _, err := loader.LoadStrict(path, nil); err != nil {
+		t.Errorf("LoadStrict failed on a sound config: %v", err)
+	}
+}
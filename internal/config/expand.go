@@ -0,0 +1,96 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// xdgDefaults are the XDG base directories used when their variable is unset,
+// relative to the home directory.
+var xdgDefaults = map[string]string{
+	"XDG_CONFIG_HOME": ".config",
+	"XDG_DATA_HOME":   filepath.Join(".local", "share"),
+	"XDG_STATE_HOME":  filepath.Join(".local", "state"),
+	"XDG_CACHE_HOME":  ".cache",
+}
+
+// ExpandPath expands a leading "~" to the home directory and $VAR or ${VAR}
+// to the value of the environment variable. Unset XDG base directory variables
+// (XDG_CONFIG_HOME, XDG_DATA_HOME, XDG_STATE_HOME, XDG_CACHE_HOME) take their
+// default under the home directory; other unset variables expand to "", so
+// root paths using them are reported by Config.Problems (see UnsetPathVariables).
+func ExpandPath(path string) string {
+	home, _ := os.UserHomeDir()
+
+	if home != "" && (path == "~" || strings.HasPrefix(path, "~/") || strings.HasPrefix(path, `~\`)) {
+		path = home + path[1:]
+	}
+
+	if !strings.Contains(path, "$") {
+		return path
+	}
+	return os.Expand(path, func(name string) string {
+		if value, ok := os.LookupEnv(name); ok {
+			return value
+		}
+		if dir, ok := xdgDefaults[name]; ok && home != "" {
+			return filepath.Join(home, dir)
+		}
+		return ""
+	})
+}
+
+// UnsetPathVariables returns the variables in path that ExpandPath cannot expand:
+// those that are unset and have no XDG default.
+func UnsetPathVariables(path string) []string {
+	if !strings.Contains(path, "$") {
+		return nil
+	}
+	var unset []string
+	os.Expand(path, func(name string) string {
+		if _, ok := os.LookupEnv(name); !ok {
+			if _, ok := xdgDefaults[name]; !ok {
+				unset = append(unset, name)
+			}
+		}
+		return ""
+	})
+	return unset
+}
+
+// SetRootPath sets the path of a root. The path may use "~" and environment
+// variables: Roots holds the expanded path, while Save writes it as given.
+func (c *Config) SetRootPath(name, path string) {
+	if c.Roots == nil {
+		c.Roots = make(map[string]string)
+	}
+	expanded := ExpandPath(path)
+	c.Roots[name] = expanded
+
+	if expanded == path {
+		delete(c.rawRoots, name)
+		return
+	}
+	if c.rawRoots == nil {
+		c.rawRoots = make(map[string]string)
+	}
+	c.rawRoots[name] = path
+}
+
+// RawRootPath returns the path of a root as written in the configuration file.
+// Paths changed through Roots directly since they were set are returned as is.
+func (c *Config) RawRootPath(name string) string {
+	path := c.Roots[name]
+	if raw, ok := c.rawRoots[name]; ok && ExpandPath(raw) == path {
+		return raw
+	}
+	return path
+}
+
+// expandRoots expands the root paths read from a file, remembering their raw form.
+func (c *Config) expandRoots() {
+	for name, path := range c.Roots {
+		c.SetRootPath(name, path)
+	}
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mi8bi/ghqx/internal/domain"
+)
+
+func TestExpandPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv("GHQX_TEST_SRC", "/srv/src")
+	t.Setenv("XDG_DATA_HOME", "")
+	os.Unsetenv("XDG_DATA_HOME")
+
+	tests := []struct {
+		in, want string
+	}{
+		{"/tmp/dev", "/tmp/dev"},
+		{"~", home},
+		{"~/src/dev", home + "/src/dev"},
+		{"$HOME/ghqx", home + "/ghqx"},
+		{"${GHQX_TEST_SRC}/dev", "/srv/src/dev"},
+		{"$XDG_DATA_HOME/ghqx", filepath.Join(home, ".local", "share") + "/ghqx"},
+		{"~user/dev", "~user/dev"},
+		{"/tmp/a~b", "/tmp/a~b"},
+	}
+	for _, tt := range tests {
+		if got := ExpandPath(tt.in); got != tt.want {
+			t.Errorf("ExpandPath(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRawRootPathsSurviveSave(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	path := filepath.Join(t.TempDir(), "config.toml")
+	data := "version = 2\n\n[roots.dev]\npath = \"~/src/dev\"\n\n[roots.sandbox]\npath = \"$HOME/sandbox\"\n\n[roots.tmp]\npath = \"/tmp/ghqx\"\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	loader := NewLoader()
+	cfg, err := loader.Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Roots["dev"] != filepath.Join(home, "src", "dev") || cfg.RawRootPath("dev") != "~/src/dev" {
+		t.Errorf("dev = %q (raw %q)", cfg.Roots["dev"], cfg.RawRootPath("dev"))
+	}
+	if cfg.RawRootPath("tmp") != "/tmp/ghqx" {
+		t.Errorf("plain paths should stay as they are: %q", cfg.RawRootPath("tmp"))
+	}
+
+	// Changing a path directly drops its raw form
+	cfg.Roots["sandbox"] = "/elsewhere"
+	if err := loader.Save(cfg, path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	saved, _ := os.ReadFile(path)
+	for _, want := range []string{`path = "~/src/dev"`, `path = "/elsewhere"`} {
+		if !strings.Contains(string(saved), want) {
+			t.Errorf("saved config missing %q:\n%s", want, saved)
+		}
+	}
+
+	// Keys read and write the raw form
+	if err := cfg.Set("roots.sandbox", "~/sb"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if got, _ := cfg.Get("roots.sandbox.path"); got != "~/sb" || cfg.Roots["sandbox"] != filepath.Join(home, "sb") {
+		t.Errorf("sandbox = %q (get %q)", cfg.Roots["sandbox"], got)
+	}
+	if err := cfg.RenameRoot("sandbox", "play"); err != nil || cfg.RawRootPath("play") != "~/sb" {
+		t.Errorf("rename should keep the raw path, got %q (%v)", cfg.RawRootPath("play"), err)
+	}
+}
+
+func TestUnsetVariablesAreReported(t *testing.T) {
+	// t.Setenv first so the original values come back after the test
+	t.Setenv("GHQX_TEST_UNSET", "")
+	t.Setenv("XDG_STATE_HOME", "")
+	os.Unsetenv("GHQX_TEST_UNSET")
+	os.Unsetenv("XDG_STATE_HOME")
+	t.Setenv("GHQX_TEST_SRC", "/srv/src")
+
+	if got := UnsetPathVariables("$GHQX_TEST_UNSET/dev:${GHQX_TEST_SRC}:$XDG_STATE_HOME"); len(got) != 1 || got[0] != "GHQX_TEST_UNSET" {
+		t.Errorf("UnsetPathVariables = %v", got)
+	}
+
+	path := filepath.Join(t.TempDir(), "config.toml")
+	data := "version = 2\n\n[roots.work]\npath = \"$GHQX_TEST_UNSET/dev\"\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	// "/dev" exists and is absolute, so only the variable check can catch it
+	_, err := NewLoader().Load(path)
+	want := domain.ErrConfigUnsetVariable("work", "GHQX_TEST_UNSET").Message
+	if err == nil || err.(*domain.GhqxError).Message != want {
+		t.Errorf("expected %q, got %v", want, err)
+	}
+}
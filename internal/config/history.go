@@ -0,0 +1,278 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/mi8bi/ghqx/internal/domain"
+)
+
+// HistoryLimit is the number of backups kept of a configuration file.
+// Every save that changes the file first copies it into its history directory
+// (config.toml.history next to config.toml), dropping the oldest copies.
+const HistoryLimit = 10
+
+// backupTimeFormat names backups after the time they were taken, so that
+// names sort in time order.
+const backupTimeFormat = "20060102-150405.000000000"
+
+// backupExt is the extension of backup files.
+const backupExt = ".toml"
+
+// Backup is a copy of the configuration file taken before it was overwritten.
+type Backup struct {
+	// Path is the backup file
+	Path string
+	// Time is when the configuration was replaced
+	Time time.Time
+}
+
+// historyDir returns the directory holding the backups of the file at path,
+// next to the file a symlinked path points to.
+func historyDir(path string) string {
+	return resolveConfigPath(path) + ".history"
+}
+
+// resolveConfigPath follows symlinks to the file that holds the configuration,
+// so saving a linked file (e.g. one managed by a dotfiles tool) updates its
+// target instead of replacing the link. Paths that cannot be resolved, such as
+// files not created yet, are returned as is.
+func resolveConfigPath(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return path
+}
+
+// History finds the configuration file like Load and returns its path with its
+// backups, newest first.
+func (l *Loader) History(configPath string) (string, []Backup, error) {
+	path, err := l.findConfigPath(configPath)
+	if err != nil {
+		return "", nil, err
+	}
+
+	backups, err := listBackups(path)
+	if err != nil {
+		return "", nil, err
+	}
+	return path, backups, nil
+}
+
+// Rollback restores the n-th newest backup (starting at 1) of the configuration
+// file found like Load. The file being replaced is backed up first, so a rollback
+// can itself be rolled back. The backup must be readable, but it is not validated,
+// so a configuration can be restored before the roots it names exist again.
+func (l *Loader) Rollback(configPath string, n int) (*Backup, error) {
+	path, err := l.findConfigPath(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	backups, err := listBackups(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(backups) == 0 {
+		return nil, domain.ErrConfigNoHistory(path)
+	}
+	if n < 1 || n > len(backups) {
+		return nil, domain.ErrConfigBackupNotFound(strconv.Itoa(n), len(backups))
+	}
+
+	backup := backups[n-1]
+	data, err := os.ReadFile(backup.Path)
+	if err != nil {
+		return nil, domain.ErrConfigInvalidTOML(err).WithInternal("path: " + backup.Path)
+	}
+	if _, decodeErr := decode(string(data)); decodeErr != nil {
+		return nil, decodeErr.WithInternal("path: " + backup.Path)
+	}
+
+	if err := writeConfigFile(path, data); err != nil {
+		return nil, err
+	}
+	return &backup, nil
+}
+
+// encodeConfig returns cfg in the file layout of its schema version (see fileVersion).
+func encodeConfig(cfg *Config) ([]byte, error) {
+	var file any = toFileV2(cfg)
+	if fileVersion(cfg) == 1 {
+		file = toFileV1(cfg)
+	}
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(file); err != nil {
+		return nil, domain.NewErrorWithCause(
+			domain.ErrCodeConfigInvalid,
+			"Failed to write config",
+			err,
+		)
+	}
+	return buf.Bytes(), nil
+}
+
+// updateConfigFile returns the contents of the file at path changed to hold cfg.
+// Files in the schema cfg is written in are patched line by line (see patchTOML),
+// keeping comments and layout; others, and files the patch cannot handle, are
+// rewritten from scratch.
+func updateConfigFile(path string, cfg *Config) ([]byte, error) {
+	data, err := encodeConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	old, err := os.ReadFile(path)
+	if err != nil {
+		return data, nil
+	}
+	if current, err := decode(string(old)); err != nil || current.Version != fileVersion(cfg) {
+		return data, nil
+	}
+
+	patched, ok := patchTOML(string(old), string(data))
+	if !ok || !sameConfig(patched, data) {
+		return data, nil
+	}
+	return []byte(patched), nil
+}
+
+// sameConfig reports whether the patched file reads back as the configuration
+// encoded in data.
+func sameConfig(patched string, data []byte) bool {
+	cfg, err := decode(patched)
+	if err != nil {
+		return false
+	}
+	reencoded, encodeErr := encodeConfig(cfg)
+	return encodeErr == nil && bytes.Equal(reencoded, data)
+}
+
+// writeConfigFile replaces the file at path with data. The current file is
+// backed up first, and data is written to a temporary file that is renamed
+// over path, so the configuration is never left half-written.
+// A symlinked path is written through to its target (see resolveConfigPath).
+// Nothing happens when the file already holds data.
+func writeConfigFile(path string, data []byte) error {
+	path = resolveConfigPath(path)
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+		if old, err := os.ReadFile(path); err == nil && bytes.Equal(old, data) {
+			return nil
+		}
+		if err := backupToHistory(path); err != nil {
+			return err
+		}
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return domain.ErrFSCreateDir(err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return domain.NewErrorWithCause(
+			domain.ErrCodeFSError,
+			"Failed to create config file",
+			err,
+		).WithInternal("dir: " + dir)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), mode)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		return domain.NewErrorWithCause(
+			domain.ErrCodeFSError,
+			"Failed to write config file",
+			err,
+		).WithInternal("path: " + path)
+	}
+	return nil
+}
+
+// backupToHistory copies the file at path into its history directory and
+// removes the backups beyond HistoryLimit.
+func backupToHistory(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return domain.NewErrorWithCause(
+			domain.ErrCodeFSError,
+			"Failed to read config file",
+			err,
+		).WithInternal("path: " + path)
+	}
+
+	dir := historyDir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return domain.ErrFSCreateDir(err)
+	}
+	backup := filepath.Join(dir, time.Now().Format(backupTimeFormat)+backupExt)
+	if err := os.WriteFile(backup, data, 0600); err != nil {
+		return domain.NewErrorWithCause(
+			domain.ErrCodeFSError,
+			"Failed to back up config file",
+			err,
+		).WithInternal("backup: " + backup)
+	}
+
+	backups, err := listBackups(path)
+	if err != nil {
+		return err
+	}
+	for _, old := range backups[min(len(backups), HistoryLimit):] {
+		os.Remove(old.Path)
+	}
+	return nil
+}
+
+// listBackups returns the backups of the file at path, newest first.
+// Files in the history directory that are not named like backups are ignored.
+func listBackups(path string) ([]Backup, error) {
+	dir := historyDir(path)
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, domain.ErrFSReadDir(err)
+	}
+
+	var backups []Backup
+	for _, entry := range entries {
+		stamp, ok := strings.CutSuffix(entry.Name(), backupExt)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		t, err := time.ParseInLocation(backupTimeFormat, stamp, time.Local)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, Backup{Path: filepath.Join(dir, entry.Name()), Time: t})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Time.After(backups[j].Time)
+	})
+	return backups, nil
+}
@@ -0,0 +1,157 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mi8bi/ghqx/internal/domain"
+)
+
+func TestSaveKeepsCommentsAndHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(commentedConfig), 0600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	loader := NewLoader()
+	if _, err := loader.Update(path, func(cfg *Config) error {
+		return cfg.Set("default.root", "sandbox")
+	}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	want := strings.Replace(commentedConfig, `root = "dev"`, `root = "sandbox"`, 1)
+	data, _ := os.ReadFile(path)
+	if string(data) != want {
+		t.Errorf("saved config:\n%s\nwant:\n%s", data, want)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("file mode should be kept: %v", info.Mode())
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 2 {
+		t.Errorf("expected only the config and its history, found %d entries", len(entries))
+	}
+
+	_, backups, err := loader.History(path)
+	if err != nil || len(backups) != 1 {
+		t.Fatalf("expected one backup, got %v, %v", backups, err)
+	}
+	if backup, _ := os.ReadFile(backups[0].Path); string(backup) != commentedConfig {
+		t.Errorf("backup should hold the previous file:\n%s", backup)
+	}
+
+	// Saving the same configuration leaves the file and its history alone
+	cfg, _ := loader.Load(path)
+	if err := loader.Save(cfg, path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if _, backups, _ := loader.History(path); len(backups) != 1 {
+		t.Errorf("unchanged saves should not be backed up, got %d backups", len(backups))
+	}
+}
+
+func TestSaveWritesThroughSymlink(t *testing.T) {
+	tmp := t.TempDir()
+	target := filepath.Join(tmp, "dotfiles", "ghqx.toml")
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(target, []byte(commentedConfig), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	link := filepath.Join(tmp, "config.toml")
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	loader := NewLoader()
+	if _, err := loader.Update(link, func(cfg *Config) error {
+		return cfg.Set("default.root", "sandbox")
+	}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Fatalf("config link should be kept: %v", err)
+	}
+	want := strings.Replace(commentedConfig, `root = "dev"`, `root = "sandbox"`, 1)
+	if data, _ := os.ReadFile(target); string(data) != want {
+		t.Errorf("link target not updated:\n%s", data)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(target)); len(entries) != 2 {
+		t.Errorf("expected the target and its history next to each other, found %d entries", len(entries))
+	}
+
+	if _, backups, err := loader.History(link); err != nil || len(backups) != 1 {
+		t.Fatalf("expected one backup through the link, got %v, %v", backups, err)
+	}
+	if _, err := loader.Rollback(link, 1); err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+	if data, _ := os.ReadFile(target); string(data) != commentedConfig {
+		t.Errorf("rollback should restore the link target:\n%s", data)
+	}
+}
+
+func TestHistoryLimit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	cfg := &Config{Roots: map[string]string{"dev": "/tmp/dev"}, Default: DefaultConfig{Root: "dev"}}
+
+	loader := NewLoader()
+	for depth := 0; depth <= HistoryLimit+2; depth++ {
+		cfg.Scan.MaxDepth = depth
+		if err := loader.Save(cfg, path); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+
+	_, backups, err := loader.History(path)
+	if err != nil || len(backups) != HistoryLimit {
+		t.Fatalf("expected %d backups, got %d (%v)", HistoryLimit, len(backups), err)
+	}
+	newest, _ := os.ReadFile(backups[0].Path)
+	if !strings.Contains(string(newest), "max_depth = 11") {
+		t.Errorf("newest backup should hold the previous save:\n%s", newest)
+	}
+}
+
+func TestRollback(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(commentedConfig), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	loader := NewLoader()
+	_, err := loader.Rollback(path, 1)
+	if !isCode(err, domain.ErrCodeConfigInvalid) {
+		t.Errorf("expected no history error, got %v", err)
+	}
+
+	if _, err := loader.Update(path, func(cfg *Config) error {
+		return cfg.AddRoot("work", "/tmp/work")
+	}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	changed, _ := os.ReadFile(path)
+
+	if _, err := loader.Rollback(path, 2); err == nil {
+		t.Error("expected an error for a backup that does not exist")
+	}
+	if _, err := loader.Rollback(path, 1); err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != commentedConfig {
+		t.Errorf("rollback should restore the previous file:\n%s", data)
+	}
+
+	// The rolled back file is kept, so the rollback can be undone
+	if _, err := loader.Rollback(path, 1); err != nil {
+		t.Fatalf("second Rollback failed: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != string(changed) {
+		t.Errorf("second rollback should undo the first:\n%s", data)
+	}
+}
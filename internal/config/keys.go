@@ -0,0 +1,283 @@
+package config
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mi8bi/ghqx/internal/domain"
+	"github.com/mi8bi/ghqx/internal/fs"
+)
+
+// Keys name single settings the way they appear in a version 2 file:
+// "default.root", "clone.backend", "scan.max_depth", "roots.<name>.path" and
+// "roots.<name>.<setting>" for every RootOptions field ("roots.<name>" is short
+// for its path). Fields are found through their TOML tags, so new settings
+// become addressable without changes here.
+
+// sections maps the top-level tables to the structs holding their keys.
+var sections = map[string]func(c *Config) any{
+	"default": func(c *Config) any { return &c.Default },
+	"clone":   func(c *Config) any { return &c.Clone },
+	"scan":    func(c *Config) any { return &c.Scan },
+}
+
+// rootPathKey is the key of a root's path inside its table.
+const rootPathKey = "path"
+
+// Get returns the value of key: a string, int, bool or []string.
+// Settings that are not set return their zero value.
+func (c *Config) Get(key string) (any, error) {
+	field, _, err := c.lookup(key)
+	if err != nil {
+		return nil, err
+	}
+	return field.Interface(), nil
+}
+
+// Set parses value and stores it under key. Booleans take true/false and
+// lists take comma-separated items. The result is not validated; see Validate.
+// Setting the path of a root that does not exist adds it, like AddRoot.
+func (c *Config) Set(key, value string) error {
+	if root, name, ok := splitRootKey(key); ok && name == rootPathKey {
+		if _, exists := c.Roots[root]; !exists {
+			return c.AddRoot(root, value)
+		}
+	}
+
+	field, commit, err := c.lookup(key)
+	if err != nil {
+		return err
+	}
+	if err := parseValue(field, value); err != nil {
+		return domain.ErrConfigInvalidValue(key, value)
+	}
+	commit()
+	return nil
+}
+
+// Unset resets key to its default. A root's path cannot be unset; use RemoveRoot.
+func (c *Config) Unset(key string) error {
+	field, commit, err := c.lookup(key)
+	if err != nil {
+		return err
+	}
+	if _, name, ok := splitRootKey(key); ok && name == rootPathKey {
+		return domain.ErrConfigUnsetRootPath(key)
+	}
+	field.SetZero()
+	commit()
+	return nil
+}
+
+// ValidateRootName checks that name can be used for a new root.
+// Root names become part of keys, so they cannot contain dots.
+func (c *Config) ValidateRootName(name string) error {
+	if !fs.IsSafeName(name) || strings.Contains(name, ".") {
+		return domain.ErrConfigInvalidRootName(name)
+	}
+	if _, exists := c.Roots[name]; exists {
+		return domain.ErrConfigRootExists(name)
+	}
+	return nil
+}
+
+// AddRoot adds a root whose name passes ValidateRootName.
+func (c *Config) AddRoot(name, path string) error {
+	if err := c.ValidateRootName(name); err != nil {
+		return err
+	}
+	c.SetRootPath(name, path)
+	return nil
+}
+
+// RemoveRoot removes a root and its settings. The default root cannot be removed.
+func (c *Config) RemoveRoot(name string) error {
+	if _, exists := c.Roots[name]; !exists {
+		return domain.ErrRootNotFound(name)
+	}
+	if c.Default.Root == name {
+		return domain.ErrConfigRemoveDefaultRoot(name)
+	}
+	delete(c.Roots, name)
+	delete(c.rawRoots, name)
+	delete(c.RootOptions, name)
+	return nil
+}
+
+// RenameRoot renames a root, keeping its path and settings. The default root follows the rename.
+func (c *Config) RenameRoot(oldName, newName string) error {
+	if _, exists := c.Roots[oldName]; !exists {
+		return domain.ErrRootNotFound(oldName)
+	}
+	if newName == oldName {
+		return nil
+	}
+	if err := c.AddRoot(newName, c.RawRootPath(oldName)); err != nil {
+		return err
+	}
+	delete(c.Roots, oldName)
+	delete(c.rawRoots, oldName)
+
+	if opts, ok := c.RootOptions[oldName]; ok {
+		c.RootOptions[newName] = opts
+		delete(c.RootOptions, oldName)
+	}
+	if c.Default.Root == oldName {
+		c.Default.Root = newName
+	}
+	return nil
+}
+
+// Keys returns every key of the configuration, including the settings of each root, sorted.
+func (c *Config) Keys() []string {
+	var keys []string
+	for section, ptr := range sections {
+		for _, name := range tagNames(reflect.TypeOf(ptr(c)).Elem()) {
+			keys = append(keys, section+"."+name)
+		}
+	}
+	for root := range c.Roots {
+		keys = append(keys, "roots."+root+"."+rootPathKey)
+		for _, name := range tagNames(reflect.TypeOf(RootOptions{})) {
+			keys = append(keys, "roots."+root+"."+name)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Values returns the keys that are set, with their values.
+func (c *Config) Values() map[string]any {
+	values := make(map[string]any)
+	for _, key := range c.Keys() {
+		field, _, err := c.lookup(key)
+		if err == nil && !field.IsZero() {
+			values[key] = field.Interface()
+		}
+	}
+	return values
+}
+
+// lookup resolves key to the settable field holding its value.
+// commit stores the change; root settings live in a map and are copied out.
+func (c *Config) lookup(key string) (reflect.Value, func(), error) {
+	if root, name, ok := splitRootKey(key); ok {
+		if _, exists := c.Roots[root]; !exists {
+			return reflect.Value{}, nil, domain.ErrRootNotFound(root)
+		}
+
+		if name == rootPathKey {
+			// Paths are read and written as they appear in the file (e.g. "~/src")
+			path := reflect.New(reflect.TypeOf("")).Elem()
+			path.SetString(c.RawRootPath(root))
+			return path, func() { c.SetRootPath(root, path.String()) }, nil
+		}
+
+		opts := reflect.New(reflect.TypeOf(RootOptions{})).Elem()
+		opts.Set(reflect.ValueOf(c.RootOptions[root]))
+		field, ok := fieldByTag(opts, name)
+		if !ok {
+			return reflect.Value{}, nil, domain.ErrConfigUnknownKey(key)
+		}
+		commit := func() {
+			if c.RootOptions == nil {
+				c.RootOptions = make(map[string]RootOptions)
+			}
+			updated := opts.Interface().(RootOptions)
+			if updated.isZero() {
+				delete(c.RootOptions, root)
+			} else {
+				c.RootOptions[root] = updated
+			}
+		}
+		return field, commit, nil
+	}
+
+	section, name, _ := strings.Cut(key, ".")
+	ptr, ok := sections[section]
+	if !ok {
+		return reflect.Value{}, nil, domain.ErrConfigUnknownKey(key)
+	}
+	field, ok := fieldByTag(reflect.ValueOf(ptr(c)).Elem(), name)
+	if !ok {
+		return reflect.Value{}, nil, domain.ErrConfigUnknownKey(key)
+	}
+	return field, func() {}, nil
+}
+
+// splitRootKey splits "roots.<root>.<name>" into root and name.
+// "roots.<root>" is short for the root's path.
+func splitRootKey(key string) (root, name string, ok bool) {
+	rest, ok := strings.CutPrefix(key, "roots.")
+	if !ok || rest == "" {
+		return "", "", false
+	}
+	root, name, found := strings.Cut(rest, ".")
+	if !found {
+		name = rootPathKey
+	}
+	return root, name, true
+}
+
+// tagNames returns the TOML key of every tagged field of t.
+func tagNames(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		if name := tagName(t.Field(i)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// tagName returns the TOML key of a field, or "" for untagged and ignored fields.
+func tagName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("toml"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// fieldByTag returns the field of the struct v whose TOML key is name.
+func fieldByTag(v reflect.Value, name string) (reflect.Value, bool) {
+	for i := 0; i < v.NumField(); i++ {
+		if name != "" && tagName(v.Type().Field(i)) == name {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// parseValue parses s into the field according to its type.
+func parseValue(field reflect.Value, s string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(s)
+	case reflect.Int:
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(n))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(s, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		field.Set(reflect.ValueOf(items))
+	default:
+		return strconv.ErrSyntax
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/mi8bi/ghqx/internal/domain"
+)
+
+func newKeysConfig() *Config {
+	return &Config{
+		Roots:   map[string]string{"dev": "/tmp/dev", "sandbox": "/tmp/sandbox"},
+		Default: DefaultConfig{Root: "dev"},
+	}
+}
+
+func TestConfigGetSet(t *testing.T) {
+	cfg := newKeysConfig()
+
+	tests := []struct {
+		key   string
+		value string
+		want  any
+	}{
+		{"default.root", "sandbox", "sandbox"},
+		{"clone.backend", "git", "git"},
+		{"scan.max_depth", "5", 5},
+		{"scan.exclude", "tmp/, **/.cache", []string{"tmp/", "**/.cache"}},
+		{"roots.dev", "/srv/dev", "/srv/dev"},
+		{"roots.sandbox.path", "/srv/sandbox", "/srv/sandbox"},
+		{"roots.sandbox.layout", "flat", "flat"},
+		{"roots.sandbox.read_only", "true", true},
+		{"roots.sandbox.clone_flags", "--depth=1", []string{"--depth=1"}},
+	}
+	for _, tt := range tests {
+		if err := cfg.Set(tt.key, tt.value); err != nil {
+			t.Fatalf("Set(%q) failed: %v", tt.key, err)
+		}
+		got, err := cfg.Get(tt.key)
+		if err != nil {
+			t.Fatalf("Get(%q) failed: %v", tt.key, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Get(%q) = %#v, want %#v", tt.key, got, tt.want)
+		}
+	}
+
+	if cfg.Roots["dev"] != "/srv/dev" || cfg.RootLayout("sandbox") != domain.LayoutFlat || !cfg.IsReadOnly("sandbox") {
+		t.Errorf("settings were not stored: %+v", cfg)
+	}
+
+	// Unset settings read as their zero value
+	if got, err := cfg.Get("roots.dev.type"); err != nil || got != "" {
+		t.Errorf("Get(roots.dev.type) = %#v, %v", got, err)
+	}
+}
+
+func TestConfigKeyErrors(t *testing.T) {
+	cfg := newKeysConfig()
+
+	for _, key := range []string{"", "default", "default.nope", "nope.root", "roots.dev.nope", "roots.dev."} {
+		if _, err := cfg.Get(key); !isCode(err, domain.ErrCodeConfigInvalid) {
+			t.Errorf("Get(%q): expected unknown key error, got %v", key, err)
+		}
+	}
+	if _, err := cfg.Get("roots.missing.layout"); !isCode(err, domain.ErrCodeRootNotFound) {
+		t.Errorf("expected root not found, got %v", err)
+	}
+	if err := cfg.Set("scan.max_depth", "deep"); !isCode(err, domain.ErrCodeConfigInvalid) {
+		t.Errorf("expected invalid value error, got %v", err)
+	}
+	if err := cfg.Set("roots.dev.read_only", "maybe"); !isCode(err, domain.ErrCodeConfigInvalid) {
+		t.Errorf("expected invalid value error, got %v", err)
+	}
+	if cfg.RootOptions != nil {
+		t.Errorf("failed sets must not store anything: %+v", cfg.RootOptions)
+	}
+}
+
+func TestConfigUnset(t *testing.T) {
+	cfg := newKeysConfig()
+	cfg.Scan.MaxDepth = 4
+	cfg.RootOptions = map[string]RootOptions{"sandbox": {Layout: "flat", ReadOnly: true}}
+
+	if err := cfg.Unset("scan.max_depth"); err != nil || cfg.Scan.MaxDepth != 0 {
+		t.Errorf("Unset(scan.max_depth) = %v, depth %d", err, cfg.Scan.MaxDepth)
+	}
+	if err := cfg.Unset("roots.sandbox.layout"); err != nil {
+		t.Fatalf("Unset(layout) failed: %v", err)
+	}
+	if !reflect.DeepEqual(cfg.RootOptions["sandbox"], RootOptions{ReadOnly: true}) {
+		t.Errorf("other settings should be kept: %+v", cfg.RootOptions["sandbox"])
+	}
+	if err := cfg.Unset("roots.sandbox.read_only"); err != nil {
+		t.Fatalf("Unset(read_only) failed: %v", err)
+	}
+	if _, ok := cfg.RootOptions["sandbox"]; ok {
+		t.Error("roots without settings should have no options")
+	}
+
+	for _, key := range []string{"roots.dev", "roots.dev.path"} {
+		if err := cfg.Unset(key); !isCode(err, domain.ErrCodeConfigInvalid) {
+			t.Errorf("Unset(%q): expected error, got %v", key, err)
+		}
+	}
+}
+
+func TestConfigAddRemoveRoot(t *testing.T) {
+	cfg := newKeysConfig()
+	cfg.RootOptions = map[string]RootOptions{"sandbox": {Layout: "flat"}}
+
+	if err := cfg.AddRoot("work", "/tmp/work"); err != nil || cfg.Roots["work"] != "/tmp/work" {
+		t.Errorf("AddRoot failed: %v", err)
+	}
+	if err := cfg.AddRoot("work", "/tmp/other"); !isCode(err, domain.ErrCodeConfigInvalid) {
+		t.Errorf("expected root exists error, got %v", err)
+	}
+	for _, name := range []string{"", "a.b", "a/b", ".."} {
+		if err := cfg.AddRoot(name, "/tmp/x"); !isCode(err, domain.ErrCodeConfigInvalid) {
+			t.Errorf("AddRoot(%q): expected invalid name error, got %v", name, err)
+		}
+	}
+
+	// Setting the path of an unknown root adds it, other settings need the root first
+	if err := cfg.Set("roots.tools", "/tmp/tools"); err != nil || cfg.Roots["tools"] != "/tmp/tools" {
+		t.Errorf("Set(roots.tools) failed: %v", err)
+	}
+	if err := cfg.Set("roots.a/b.path", "/tmp/x"); !isCode(err, domain.ErrCodeConfigInvalid) {
+		t.Errorf("expected invalid name error, got %v", err)
+	}
+	if err := cfg.Set("roots.missing.layout", "flat"); !isCode(err, domain.ErrCodeRootNotFound) {
+		t.Errorf("expected root not found, got %v", err)
+	}
+
+	if err := cfg.RemoveRoot("sandbox"); err != nil {
+		t.Fatalf("RemoveRoot failed: %v", err)
+	}
+	if _, ok := cfg.Roots["sandbox"]; ok {
+		t.Error("root was not removed")
+	}
+	if _, ok := cfg.RootOptions["sandbox"]; ok {
+		t.Error("root settings were not removed")
+	}
+	if err := cfg.RemoveRoot("dev"); !isCode(err, domain.ErrCodeConfigInvalid) {
+		t.Errorf("expected default root error, got %v", err)
+	}
+	if err := cfg.RemoveRoot("missing"); !isCode(err, domain.ErrCodeRootNotFound) {
+		t.Errorf("expected root not found, got %v", err)
+	}
+}
+
+func TestConfigKeysAndValues(t *testing.T) {
+	cfg := newKeysConfig()
+	cfg.RootOptions = map[string]RootOptions{"sandbox": {Layout: "flat"}}
+
+	keys := cfg.Keys()
+	for _, want := range []string{"default.root", "clone.backend", "scan.max_depth", "roots.dev.path", "roots.sandbox.layout", "roots.sandbox.read_only"} {
+		found := false
+		for _, key := range keys {
+			found = found || key == want
+		}
+		if !found {
+			t.Errorf("Keys() missing %q", want)
+		}
+	}
+
+	want := map[string]any{
+		"default.root":         "dev",
+		"roots.dev.path":       "/tmp/dev",
+		"roots.sandbox.path":   "/tmp/sandbox",
+		"roots.sandbox.layout": "flat",
+	}
+	if got := cfg.Values(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Values() = %v, want %v", got, want)
+	}
+}
+
+func TestLoaderUpdate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(v1Config), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	loader := NewLoader()
+	updated, err := loader.Update(path, func(cfg *Config) error {
+		return cfg.Set("roots.dev.read_only", "true")
+	})
+	if err != nil || updated != path {
+		t.Fatalf("Update = %q, %v", updated, err)
+	}
+	if _, err := os.Stat(path + ".v1.bak"); !os.IsNotExist(err) {
+		t.Error("version 1 files should only be migrated by Migrate")
+	}
+	cfg, err := loader.Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Version != 1 || !cfg.IsReadOnly("dev") || cfg.RootLayout("sandbox") != domain.LayoutFlat {
+		t.Errorf("unexpected config after update: %+v", cfg)
+	}
+
+	// Invalid results are not written
+	before, _ := os.ReadFile(path)
+	if _, err := loader.Update(path, func(cfg *Config) error {
+		return cfg.Set("roots.dev.layout", "spiral")
+	}); err == nil {
+		t.Fatal("expected validation error")
+	}
+	after, _ := os.ReadFile(path)
+	if string(before) != string(after) {
+		t.Error("invalid update must not change the file")
+	}
+
+	// Errors from the change are returned as is
+	sentinel := errors.New("stop")
+	if _, err := loader.Update(path, func(*Config) error { return sentinel }); err != sentinel {
+		t.Errorf("expected the change's error, got %v", err)
+	}
+}
+
+// isCode reports whether err is a GhqxError with the given code.
+func isCode(err error, code domain.ErrorCode) bool {
+	var gerr *domain.GhqxError
+	return errors.As(err, &gerr) && gerr.Code == code
+}
+
+func TestConfigRenameRoot(t *testing.T) {
+	cfg := newKeysConfig()
+	cfg.RootOptions = map[string]RootOptions{"dev": {Layout: "flat"}}
+
+	if err := cfg.RenameRoot("dev", "work"); err != nil {
+		t.Fatalf("RenameRoot failed: %v", err)
+	}
+	if cfg.Roots["work"] != "/tmp/dev" || cfg.Default.Root != "work" || cfg.RootLayout("work") != domain.LayoutFlat {
+		t.Errorf("path, default root and settings should follow the rename: %+v", cfg)
+	}
+	if _, ok := cfg.Roots["dev"]; ok {
+		t.Error("old name should be gone")
+	}
+	if _, ok := cfg.RootOptions["dev"]; ok {
+		t.Error("old settings should be gone")
+	}
+
+	if err := cfg.RenameRoot("work", "sandbox"); !isCode(err, domain.ErrCodeConfigInvalid) {
+		t.Errorf("expected root exists error, got %v", err)
+	}
+	if err := cfg.RenameRoot("work", "a.b"); !isCode(err, domain.ErrCodeConfigInvalid) {
+		t.Errorf("expected invalid name error, got %v", err)
+	}
+	if err := cfg.RenameRoot("missing", "x"); !isCode(err, domain.ErrCodeRootNotFound) {
+		t.Errorf("expected root not found, got %v", err)
+	}
+}
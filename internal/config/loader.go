@@ -1,21 +1,32 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
-	"github.com/BurntSushi/toml"
 	"github.com/mi8bi/ghqx/internal/domain"
 )
 
 // Loader handles configuration file discovery and loading.
-type Loader struct{}
+type Loader struct {
+	// profile is the profile to activate ("" selects it from GHQX_PROFILE or the file)
+	profile string
+}
 
 // NewLoader creates a new config loader.
 func NewLoader() *Loader {
 	return &Loader{}
 }
 
+// WithProfile makes the loader activate the named profile instead of the one
+// selected by GHQX_PROFILE or the file. An empty name keeps that selection.
+func (l *Loader) WithProfile(name string) *Loader {
+	l.profile = name
+	return l
+}
+
 // Load finds and loads the configuration file.
 // Search order:
 // 1. configPath argument (if provided)
@@ -23,6 +34,9 @@ func NewLoader() *Loader {
 // 3. $XDG_CONFIG_HOME/ghqx/config.toml
 // 4. ~/.config/ghqx/config.toml
 // 5. ~/.ghqx.toml
+//
+// The profile given with WithProfile is activated, or else the one named by
+// GHQX_PROFILE or by the profile key of the file.
 func (l *Loader) Load(configPath string) (*Config, error) {
 	path, err := l.findConfigPath(configPath)
 	if err != nil {
@@ -32,8 +46,149 @@ func (l *Loader) Load(configPath string) (*Config, error) {
 	return l.loadFromPath(path)
 }
 
-// Save writes configuration to the specified path.
+// Path returns the configuration file Load would read, found in the same order.
+// Commands that save an edited configuration write it back to this file.
+func (l *Loader) Path(configPath string) (string, error) {
+	return l.findConfigPath(configPath)
+}
+
+// LoadStrict loads the configuration like Load, but also fails on every problem
+// Config.Diagnose reports, such as missing or overlapping root directories.
+// ghqRoots are the directories managed by ghq (see ghq.Roots).
+func (l *Loader) LoadStrict(configPath string, ghqRoots []string) (*Config, error) {
+	cfg, err := l.Load(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if problems := cfg.Diagnose(ghqRoots); len(problems) > 0 {
+		return nil, domain.ErrConfigProblems(problems)
+	}
+	return cfg, nil
+}
+
+// Diagnose finds the configuration file like Load and returns its path with every
+// problem Config.Diagnose reports. An invalid configuration is not an error here;
+// the error is only set when the file cannot be found or parsed.
+func (l *Loader) Diagnose(configPath string, ghqRoots []string) (string, []*domain.GhqxError, error) {
+	path, err := l.findConfigPath(configPath)
+	if err != nil {
+		return "", nil, err
+	}
+
+	cfg, err := l.readFromPath(path)
+	if err != nil {
+		return path, nil, err
+	}
+	return path, cfg.Diagnose(ghqRoots), nil
+}
+
+// MigrateResult describes a configuration file rewritten by Migrate.
+type MigrateResult struct {
+	// Path is the configuration file
+	Path string
+	// FromVersion is the schema version the file had before migration
+	FromVersion int
+	// Backup is the copy of the original file ("" when nothing was migrated)
+	Backup string
+}
+
+// Migrated reports whether the file was rewritten.
+func (r MigrateResult) Migrated() bool {
+	return r.Backup != ""
+}
+
+// Migrate rewrites the configuration file found like Load in the current schema.
+// The original file is copied next to it first (e.g. config.toml.v1.bak).
+// Files already in the current schema are left untouched.
+func (l *Loader) Migrate(configPath string) (*MigrateResult, error) {
+	path, err := l.findConfigPath(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := l.loadFromPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MigrateResult{Path: path, FromVersion: cfg.Version}
+	if cfg.Version >= SchemaVersion {
+		return result, nil
+	}
+
+	backup, err := backupConfig(path, cfg.Version)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Version = SchemaVersion
+	if err := l.Save(cfg, path); err != nil {
+		return nil, err
+	}
+
+	result.Backup = backup
+	return result, nil
+}
+
+// Update applies change to the configuration file found like Load and saves it.
+// Nothing is written unless the changed configuration is valid.
+// It returns the path of the updated file.
+func (l *Loader) Update(configPath string, change func(cfg *Config) error) (string, error) {
+	path, err := l.findConfigPath(configPath)
+	if err != nil {
+		return "", err
+	}
+
+	cfg, err := l.loadFromPath(path)
+	if err != nil {
+		return "", err
+	}
+
+	if err := change(cfg); err != nil {
+		return "", err
+	}
+	if err := cfg.Validate(); err != nil {
+		return "", err
+	}
+
+	if err := l.Save(cfg, path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// backupConfig copies the file at path to <path>.v<version>.bak, adding a
+// timestamp when that backup already exists, and returns the backup's path.
+func backupConfig(path string, version int) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", domain.NewErrorWithCause(
+			domain.ErrCodeFSError,
+			"Failed to read config file",
+			err,
+		).WithInternal("path: " + path)
+	}
+
+	backup := fmt.Sprintf("%s.v%d.bak", path, version)
+	if _, err := os.Stat(backup); err == nil {
+		backup = fmt.Sprintf("%s.v%d-%s.bak", path, version, time.Now().Format("20060102-150405"))
+	}
+
+	if err := os.WriteFile(backup, data, 0600); err != nil {
+		return "", domain.NewErrorWithCause(
+			domain.ErrCodeFSError,
+			"Failed to back up config file",
+			err,
+		).WithInternal("backup: " + backup)
+	}
+	return backup, nil
+}
+
+// Save writes configuration to the specified path. Configurations read from a
+// version 1 file are written in that schema (see Migrate), others in the current one.
 // If path is empty, uses the default config location.
+// Existing files keep their comments and layout where possible; they are
+// backed up to the file's history and replaced atomically (see Rollback).
 func (l *Loader) Save(cfg *Config, configPath string) error {
 	path := configPath
 	if path == "" {
@@ -49,33 +204,11 @@ func (l *Loader) Save(cfg *Config, configPath string) error {
 		return err
 	}
 
-	// Ensure directory exists
-	dir := filepath.Dir(path)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return domain.ErrFSCreateDir(err)
-	}
-
-	// Write config file
-	f, err := os.Create(path)
+	data, err := updateConfigFile(path, cfg)
 	if err != nil {
-		return domain.NewErrorWithCause(
-			domain.ErrCodeFSError,
-			"Failed to create config file",
-			err,
-		)
-	}
-	defer f.Close()
-
-	enc := toml.NewEncoder(f)
-	if err := enc.Encode(cfg); err != nil {
-		return domain.NewErrorWithCause(
-			domain.ErrCodeConfigInvalid,
-			"Failed to write config",
-			err,
-		)
+		return err
 	}
-
-	return nil
+	return writeConfigFile(path, data)
 }
 
 // GetDefaultConfigPath returns the default config file path.
@@ -144,17 +277,47 @@ func (l *Loader) findConfigPath(explicitPath string) (string, error) {
 	return "", domain.ErrConfigNotFoundAny
 }
 
-// loadFromPath reads and parses a TOML config file.
+// loadFromPath reads, parses and validates a TOML config file of any supported schema version.
 func (l *Loader) loadFromPath(path string) (*Config, error) {
-	var cfg Config
+	cfg, err := l.readFromPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
 
-	if _, err := toml.DecodeFile(path, &cfg); err != nil {
+	return cfg, nil
+}
+
+// readFromPath reads and parses a TOML config file and activates the selected
+// profile, without validating it.
+func (l *Loader) readFromPath(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
 		return nil, domain.ErrConfigInvalidTOML(err).WithInternal("path: " + path)
 	}
 
-	if err := cfg.Validate(); err != nil {
+	cfg, decodeErr := decode(string(data))
+	if decodeErr != nil {
+		return nil, decodeErr.WithInternal("path: " + path)
+	}
+
+	if err := cfg.UseProfile(l.selectProfile(cfg)); err != nil {
 		return nil, err
 	}
+	return cfg, nil
+}
 
-	return &cfg, nil
+// selectProfile returns the profile to use: the one given with WithProfile,
+// then GHQX_PROFILE, then the profile selected in the file.
+func (l *Loader) selectProfile(cfg *Config) string {
+	if l.profile != "" {
+		return l.profile
+	}
+	if env := os.Getenv(ProfileEnv); env != "" {
+		return env
+	}
+	return cfg.DefaultProfile()
 }
@@ -0,0 +1,289 @@
+package config
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Saving a configuration file rewrites only the lines whose values changed, so
+// comments, blank lines and the order of keys survive edits. patchTOML works on
+// single lines: files using multi-line values, inline tables, dotted or quoted
+// keys or arrays of tables are written from scratch instead.
+
+// tomlLine is a line of a TOML file as far as patchTOML understands it.
+type tomlLine struct {
+	text   string
+	indent string
+	// table is the dotted name of the table the line belongs to ("" at the top level)
+	table  string
+	header bool
+	// key, value, valueText and comment are set for key/value lines
+	key       string
+	value     any
+	valueText string
+	comment   string
+}
+
+// id identifies the key of a key/value line across files.
+func (l tomlLine) id() string {
+	return l.table + "\x00" + l.key
+}
+
+var (
+	tomlHeaderPattern = regexp.MustCompile(`^(\s*)\[\s*([A-Za-z0-9_-]+(?:\s*\.\s*[A-Za-z0-9_-]+)*)\s*\]\s*(#.*)?$`)
+	tomlKeyPattern    = regexp.MustCompile(`^(\s*)([A-Za-z0-9_-]+)\s*=\s*(.*)$`)
+)
+
+// parseTOMLLines splits a TOML file into lines. It returns false when the file
+// uses anything that cannot be edited line by line.
+func parseTOMLLines(lines []string) ([]tomlLine, bool) {
+	parsed := make([]tomlLine, 0, len(lines))
+	table := ""
+	for _, text := range lines {
+		line := tomlLine{text: text, table: table}
+		trimmed := strings.TrimSpace(text)
+
+		switch {
+		case trimmed == "" || strings.HasPrefix(trimmed, "#"):
+		case tomlHeaderPattern.MatchString(text):
+			m := tomlHeaderPattern.FindStringSubmatch(text)
+			parts := strings.Split(m[2], ".")
+			for i := range parts {
+				parts[i] = strings.TrimSpace(parts[i])
+			}
+			table = strings.Join(parts, ".")
+			line.table, line.header, line.indent = table, true, m[1]
+		case tomlKeyPattern.MatchString(text):
+			m := tomlKeyPattern.FindStringSubmatch(text)
+			value, valueText, comment, ok := parseTOMLValue(m[3])
+			if !ok {
+				return nil, false
+			}
+			line.indent, line.key = m[1], m[2]
+			line.value, line.valueText, line.comment = value, valueText, comment
+		default:
+			return nil, false
+		}
+		parsed = append(parsed, line)
+	}
+	return parsed, true
+}
+
+// parseTOMLValue splits what follows "key =" into the value and a trailing comment.
+func parseTOMLValue(rest string) (value any, valueText, comment string, ok bool) {
+	for i := 0; i <= len(rest); i++ {
+		if i < len(rest) && rest[i] != '#' {
+			continue
+		}
+		var m map[string]any
+		if _, err := toml.Decode("v = "+rest[:i], &m); err != nil {
+			continue
+		}
+		switch m["v"].(type) {
+		case map[string]any, []map[string]any:
+			return nil, "", "", false
+		}
+		return m["v"], strings.TrimSpace(rest[:i]), rest[i:], true
+	}
+	return nil, "", "", false
+}
+
+// isZeroTOMLValue reports whether a value is what ghqx leaves out when writing
+// (empty strings and lists, zero and false).
+func isZeroTOMLValue(v any) bool {
+	if items, ok := v.([]any); ok {
+		return len(items) == 0
+	}
+	return v == nil || reflect.ValueOf(v).IsZero()
+}
+
+// patchTOML rewrites the file old so it holds the values of the file updated.
+// Changed values are replaced in place, keeping trailing comments; keys that are
+// gone are removed, along with tables left without keys; new keys are added to
+// their table and new tables after their siblings (or at the end).
+// It returns false when either file cannot be edited line by line.
+func patchTOML(old, updated string) (string, bool) {
+	newline := "\n"
+	if strings.Contains(old, "\r\n") {
+		newline = "\r\n"
+	}
+	oldText := strings.TrimSuffix(strings.ReplaceAll(old, "\r\n", "\n"), "\n")
+	oldLines, ok := parseTOMLLines(strings.Split(oldText, "\n"))
+	if !ok {
+		return "", false
+	}
+	newLines, ok := parseTOMLLines(strings.Split(strings.TrimSuffix(updated, "\n"), "\n"))
+	if !ok {
+		return "", false
+	}
+
+	values := make(map[string]tomlLine)
+	for _, l := range newLines {
+		if l.key != "" {
+			values[l.id()] = l
+		}
+	}
+
+	// Update or drop the existing keys
+	keep := make([]bool, len(oldLines))
+	written := make(map[string]bool)
+	for i, l := range oldLines {
+		keep[i] = true
+		if l.key == "" {
+			continue
+		}
+		written[l.id()] = true
+
+		nl, exists := values[l.id()]
+		switch {
+		case !exists:
+			// Unset keys that still hold the default may stay as written
+			keep[i] = isZeroTOMLValue(l.value)
+		case !reflect.DeepEqual(l.value, nl.value):
+			text := l.indent + l.key + " = " + nl.valueText
+			if l.comment != "" {
+				text += " " + l.comment
+			}
+			oldLines[i].text = text
+		}
+	}
+
+	// Drop tables whose keys were all removed, together with the comments
+	// between the header and the last key. anchors holds the line after which
+	// new keys of each remaining table are added (-1 at the top of the file).
+	anchors := map[string]int{"": -1}
+	var tableOrder []string
+	for start := 0; start < len(oldLines); {
+		end := start + 1
+		for end < len(oldLines) && !oldLines[end].header {
+			end++
+		}
+
+		table := oldLines[start].table
+		anchor, lastKey, kept := start, -1, false
+		if !oldLines[start].header {
+			anchor = -1
+		}
+		for i := start; i < end; i++ {
+			if oldLines[i].key == "" {
+				continue
+			}
+			lastKey = i
+			if keep[i] {
+				anchor, kept = i, true
+			}
+		}
+
+		if oldLines[start].header && lastKey >= 0 && !kept {
+			dropTable(oldLines, keep, start, lastKey)
+		} else {
+			anchors[table] = anchor
+			tableOrder = append(tableOrder, table)
+		}
+		start = end
+	}
+
+	// Add the new keys: first to existing tables, then in new tables
+	after := make(map[int][]string)
+	var newTables []string
+	newKeys := make(map[string][]tomlLine)
+	for _, l := range newLines {
+		if l.key == "" || written[l.id()] || isZeroTOMLValue(l.value) {
+			continue
+		}
+		if _, exists := anchors[l.table]; !exists && len(newKeys[l.table]) == 0 {
+			newTables = append(newTables, l.table)
+		}
+		newKeys[l.table] = append(newKeys[l.table], l)
+	}
+	for _, table := range append([]string{""}, tableOrder...) {
+		anchor, exists := anchors[table]
+		if !exists || len(newKeys[table]) == 0 {
+			continue
+		}
+		indent := ""
+		if anchor >= 0 {
+			indent = oldLines[anchor].indent
+		}
+		for _, l := range newKeys[table] {
+			after[anchor] = append(after[anchor], indent+l.key+" = "+l.valueText)
+		}
+		delete(newKeys, table)
+	}
+
+	var tail []string
+	for _, table := range newTables {
+		lines := []string{"", "[" + table + "]"}
+		for _, l := range newKeys[table] {
+			lines = append(lines, l.key+" = "+l.valueText)
+		}
+
+		if anchor, ok := siblingAnchor(table, tableOrder, anchors); ok {
+			after[anchor] = append(after[anchor], lines...)
+			// Later siblings follow this one
+			anchors[table] = anchor
+			tableOrder = append(tableOrder, table)
+			continue
+		}
+		tail = append(tail, lines...)
+	}
+
+	out := after[-1]
+	for i, l := range oldLines {
+		if keep[i] {
+			out = append(out, l.text)
+		}
+		out = append(out, after[i]...)
+	}
+	if len(tail) > 0 && len(out) > 0 && strings.TrimSpace(out[len(out)-1]) == "" {
+		tail = tail[1:]
+	}
+	out = append(out, tail...)
+	return strings.Join(out, newline) + newline, true
+}
+
+// dropTable removes a table from its header at start to its last key, with the
+// comments directly above and inside it, and the blank line separating it from
+// the previous table.
+func dropTable(lines []tomlLine, keep []bool, start, lastKey int) {
+	isComment := func(i int) bool {
+		return strings.HasPrefix(strings.TrimSpace(lines[i].text), "#")
+	}
+	isBlank := func(i int) bool {
+		return i >= len(lines) || strings.TrimSpace(lines[i].text) == ""
+	}
+
+	first := start
+	for first > 0 && isComment(first-1) {
+		first--
+	}
+	for i := first; i <= lastKey; i++ {
+		if !isBlank(i) {
+			keep[i] = false
+		}
+	}
+	if first > 0 && isBlank(first-1) && isBlank(lastKey+1) {
+		keep[first-1] = false
+	}
+}
+
+// siblingAnchor returns the anchor of the last table sharing the parent of table,
+// so [roots.new] is added after the other roots rather than at the end of the file.
+func siblingAnchor(table string, tableOrder []string, anchors map[string]int) (int, bool) {
+	dot := strings.LastIndex(table, ".")
+	if dot < 0 {
+		return 0, false
+	}
+	parent := table[:dot]
+
+	found, anchor := false, 0
+	for _, t := range tableOrder {
+		if t == parent || strings.HasPrefix(t, parent+".") {
+			found, anchor = true, anchors[t]
+		}
+	}
+	return anchor, found && anchor >= 0
+}
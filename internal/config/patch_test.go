@@ -0,0 +1,93 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+const commentedConfig = `# my ghqx config
+version = 2
+
+[default]
+root = "dev" # new clones go here
+
+# scanning
+[scan]
+max_depth = 4
+
+# sandbox for experiments
+[roots.sandbox]
+path = "/tmp/sandbox"
+layout = "flat"
+
+[roots.dev]
+path = "/tmp/dev"
+`
+
+func TestPatchTOML(t *testing.T) {
+	cfg, err := decode(commentedConfig)
+	if err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	cfg.SetRootPath("work", "~/work")
+	cfg.Default.Root = "work"
+	cfg.Scan.MaxDepth = 6
+	cfg.Clone.Backend = CloneBackendGit
+	if err := cfg.RemoveRoot("sandbox"); err != nil {
+		t.Fatalf("RemoveRoot failed: %v", err)
+	}
+
+	data, encodeErr := encodeConfig(cfg)
+	if encodeErr != nil {
+		t.Fatalf("encode failed: %v", encodeErr)
+	}
+	patched, ok := patchTOML(commentedConfig, string(data))
+	if !ok {
+		t.Fatal("patchTOML should handle single-line files")
+	}
+	if !sameConfig(patched, data) {
+		t.Fatalf("patched file does not hold the configuration:\n%s", patched)
+	}
+
+	for _, want := range []string{
+		"# my ghqx config\n",
+		`root = "work" # new clones go here`,
+		"# scanning\n[scan]\nmax_depth = 6\n\n[roots.dev]\npath = \"/tmp/dev\"\n\n[roots.work]\npath = \"~/work\"\n",
+		"[clone]\nbackend = \"git\"\n",
+	} {
+		if !strings.Contains(patched, want) {
+			t.Errorf("patched file missing %q:\n%s", want, patched)
+		}
+	}
+	for _, gone := range []string{"sandbox", "layout"} {
+		if strings.Contains(patched, gone) {
+			t.Errorf("patched file should not contain %q:\n%s", gone, patched)
+		}
+	}
+}
+
+func TestPatchTOMLKeepsLineEndings(t *testing.T) {
+	old := strings.ReplaceAll(commentedConfig, "\n", "\r\n")
+	updated := strings.Replace(commentedConfig, "max_depth = 4", "max_depth = 5", 1)
+
+	patched, ok := patchTOML(old, updated)
+	if !ok {
+		t.Fatal("patchTOML failed")
+	}
+	if want := strings.ReplaceAll(updated, "\n", "\r\n"); patched != want {
+		t.Errorf("patched = %q, want %q", patched, want)
+	}
+}
+
+func TestPatchTOMLUnsupported(t *testing.T) {
+	for _, old := range []string{
+		"[scan]\nexclude = [\n  \"tmp/\",\n]\n",
+		"roots.dev.path = \"/tmp/dev\"\n",
+		"[roots.dev]\nopts = { layout = \"flat\" }\n",
+		"[[roots]]\npath = \"/tmp/dev\"\n",
+	} {
+		if _, ok := patchTOML(old, commentedConfig); ok {
+			t.Errorf("patchTOML should refuse %q", old)
+		}
+	}
+}
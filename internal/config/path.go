@@ -2,6 +2,8 @@ package config
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/mi8bi/ghqx/internal/domain"
 )
@@ -49,3 +51,27 @@ func ensureDirectory(path string) error {
 	// その他のエラー（権限不足など）
 	return err
 }
+
+// PathsOverlap は 2 つのパスが同じか、一方がもう一方の中にあるかを返す
+// ルート同士が重なると同じリポジトリが複数のワークスペースに現れる
+func PathsOverlap(a, b string) bool {
+	a, b = absPath(a), absPath(b)
+	return a == b || isWithin(a, b) || isWithin(b, a)
+}
+
+// isWithin は child が parent の下にあるかを返す
+func isWithin(parent, child string) bool {
+	rel, err := filepath.Rel(parent, child)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// absPath は比較用に path を絶対パスにする（失敗した場合は整形のみ）
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return filepath.Clean(path)
+}
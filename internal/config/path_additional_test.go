@@ -32,3 +32,23 @@ func TestEnsureRootDirectoriesFileConflict(t *testing.T) {
 		}
 	}
 }
+
+func TestPathsOverlap(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"/tmp/dev", "/tmp/dev", true},
+		{"/tmp/dev/", "/tmp/dev", true},
+		{"/tmp/dev", "/tmp/dev/sub", true},
+		{"/tmp/dev/sub", "/tmp/dev", true},
+		{"/tmp/dev", "/tmp/devel", false},
+		{"/tmp/dev", "/tmp/release", false},
+		{"/tmp/a/../dev", "/tmp/dev/x", true},
+	}
+	for _, tt := range tests {
+		if got := PathsOverlap(tt.a, tt.b); got != tt.want {
+			t.Errorf("PathsOverlap(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"sort"
+
+	"github.com/mi8bi/ghqx/internal/domain"
+)
+
+// DefaultProfileName names the top-level roots of the configuration file,
+// which are used when no profile is selected.
+const DefaultProfileName = "default"
+
+// ProfileEnv is the environment variable selecting the profile, like --profile.
+const ProfileEnv = "GHQX_PROFILE"
+
+// rootSet is what each profile has of its own: its roots, their settings
+// and the default root.
+type rootSet struct {
+	roots       map[string]string
+	rawRoots    map[string]string
+	rootOptions map[string]RootOptions
+	def         DefaultConfig
+}
+
+// rootSet returns the roots of the active profile.
+func (c *Config) rootSet() rootSet {
+	return rootSet{roots: c.Roots, rawRoots: c.rawRoots, rootOptions: c.RootOptions, def: c.Default}
+}
+
+// setRootSet makes s the roots of the active profile.
+func (c *Config) setRootSet(s rootSet) {
+	c.Roots, c.rawRoots, c.RootOptions, c.Default = s.roots, s.rawRoots, s.rootOptions, s.def
+}
+
+// rootSets returns the roots of every profile, keyed by profile ("" for the top level).
+func (c *Config) rootSets() map[string]rootSet {
+	sets := make(map[string]rootSet, len(c.profiles)+1)
+	for name, s := range c.profiles {
+		sets[name] = s
+	}
+	sets[c.profile] = c.rootSet()
+	return sets
+}
+
+// addProfile declares a profile that is not active.
+func (c *Config) addProfile(name string, s rootSet) {
+	if c.profiles == nil {
+		c.profiles = make(map[string]rootSet)
+	}
+	c.profiles[name] = s
+}
+
+// Profiles returns the names of all profiles, starting with DefaultProfileName
+// followed by the declared profiles in sorted order.
+func (c *Config) Profiles() []string {
+	var names []string
+	for name := range c.rootSets() {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return append([]string{DefaultProfileName}, names...)
+}
+
+// ActiveProfile returns the name of the active profile.
+func (c *Config) ActiveProfile() string {
+	return profileName(c.profile)
+}
+
+// HasProfile reports whether the profile is declared. DefaultProfileName always is.
+func (c *Config) HasProfile(name string) bool {
+	_, ok := c.rootSets()[profileKey(name)]
+	return ok
+}
+
+// UseProfile makes name the active profile: Roots, Default and RootOptions then
+// hold its settings, and changes to them are saved in the profile's tables.
+// Empty and DefaultProfileName select the top-level roots.
+func (c *Config) UseProfile(name string) error {
+	key := profileKey(name)
+	if key == c.profile {
+		return nil
+	}
+	s, ok := c.profiles[key]
+	if !ok {
+		return domain.ErrProfileNotFound(name, c.Profiles())
+	}
+
+	c.addProfile(c.profile, c.rootSet())
+	delete(c.profiles, key)
+	c.setRootSet(s)
+	c.profile = key
+	return nil
+}
+
+// DefaultProfile returns the profile used when none is selected with --profile
+// or GHQX_PROFILE (the profile key of the file).
+func (c *Config) DefaultProfile() string {
+	return profileName(c.defaultProfile)
+}
+
+// SetDefaultProfile sets the profile used when none is selected.
+func (c *Config) SetDefaultProfile(name string) error {
+	if !c.HasProfile(name) {
+		return domain.ErrProfileNotFound(name, c.Profiles())
+	}
+	c.defaultProfile = profileKey(name)
+	return nil
+}
+
+// profileKey returns the key of a profile in Config ("" for the top level).
+func profileKey(name string) string {
+	if name == DefaultProfileName {
+		return ""
+	}
+	return name
+}
+
+// profileName returns the name of a profile shown to users.
+func profileName(key string) string {
+	if key == "" {
+		return DefaultProfileName
+	}
+	return key
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mi8bi/ghqx/internal/domain"
+)
+
+const profilesConfig = `version = 2
+profile = "work"
+
+[default]
+root = "dev"
+
+[roots.dev]
+path = "/tmp/dev"
+
+[profiles.work.default]
+root = "job"
+
+[profiles.work.roots.job]
+path = "/tmp/job"
+type = "dev"
+
+[profiles.home.roots.oss]
+path = "/tmp/oss"
+`
+
+func writeProfilesConfig(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(profilesConfig), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	return path
+}
+
+func TestUseProfile(t *testing.T) {
+	cfg, err := decode(profilesConfig)
+	if err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if !reflect.DeepEqual(cfg.Profiles(), []string{"default", "home", "work"}) {
+		t.Errorf("Profiles() = %v", cfg.Profiles())
+	}
+	if cfg.ActiveProfile() != DefaultProfileName || cfg.DefaultProfile() != "work" {
+		t.Errorf("active %q, default %q", cfg.ActiveProfile(), cfg.DefaultProfile())
+	}
+
+	if err := cfg.UseProfile("work"); err != nil {
+		t.Fatalf("UseProfile failed: %v", err)
+	}
+	if cfg.Roots["job"] != "/tmp/job" || cfg.Default.Root != "job" || cfg.RootWorkspaceType("job") != domain.WorkspaceTypeDev {
+		t.Errorf("work profile not active: %+v", cfg)
+	}
+	if _, ok := cfg.Roots["dev"]; ok {
+		t.Error("top-level roots should not be active")
+	}
+	if !cfg.HasProfile(DefaultProfileName) || !cfg.HasProfile("home") || cfg.HasProfile("nope") {
+		t.Error("HasProfile mismatch")
+	}
+
+	var ghqxErr *domain.GhqxError
+	if !errors.As(cfg.UseProfile("nope"), &ghqxErr) || !strings.Contains(ghqxErr.Hint, "default, home, work") {
+		t.Errorf("expected profile not found with the available profiles, got %v", ghqxErr)
+	}
+
+	if err := cfg.UseProfile(DefaultProfileName); err != nil || cfg.Roots["dev"] != "/tmp/dev" {
+		t.Errorf("default profile not restored: %v, %+v", err, cfg.Roots)
+	}
+
+	if _, err := decode("version = 2\n[profiles.default.roots.x]\npath = \"/tmp/x\"\n"); err == nil {
+		t.Error("[profiles.default] should be rejected")
+	}
+}
+
+func TestLoaderSelectsProfile(t *testing.T) {
+	path := writeProfilesConfig(t)
+
+	tests := []struct {
+		name string
+		flag string
+		env  string
+		want string
+	}{
+		{"file", "", "", "work"},
+		{"env", "", "home", "home"},
+		{"flag over env", "default", "home", "default"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(ProfileEnv, tt.env)
+			cfg, err := NewLoader().WithProfile(tt.flag).Load(path)
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			if cfg.ActiveProfile() != tt.want {
+				t.Errorf("active profile = %q, want %q", cfg.ActiveProfile(), tt.want)
+			}
+		})
+	}
+
+	t.Setenv(ProfileEnv, "nope")
+	if _, err := NewLoader().Load(path); err == nil {
+		t.Error("loading a missing profile should fail")
+	}
+}
+
+func TestSaveKeepsProfiles(t *testing.T) {
+	t.Setenv(ProfileEnv, "")
+	path := writeProfilesConfig(t)
+
+	loader := NewLoader()
+	if _, err := loader.Update(path, func(cfg *Config) error {
+		if err := cfg.SetDefaultProfile("home"); err != nil {
+			return err
+		}
+		// Changes apply to the active profile
+		return cfg.AddRoot("extra", "/tmp/extra")
+	}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	for _, want := range []string{`profile = "home"`, "[roots.dev]", "[profiles.work.roots.extra]", "[profiles.home.roots.oss]"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("saved config missing %q:\n%s", want, data)
+		}
+	}
+
+	cfg, err := loader.WithProfile("work").Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Roots["extra"] != "/tmp/extra" || cfg.Roots["job"] != "/tmp/job" {
+		t.Errorf("work profile lost roots: %+v", cfg.Roots)
+	}
+
+	if err := cfg.SetDefaultProfile("nope"); err == nil {
+		t.Error("SetDefaultProfile should reject unknown profiles")
+	}
+}
@@ -0,0 +1,181 @@
+package config
+
+import (
+	"github.com/BurntSushi/toml"
+	"github.com/mi8bi/ghqx/internal/domain"
+)
+
+// SchemaVersion is the configuration file format written by ghqx.
+//
+// Version 1 files have no version key: root paths live in [roots] as plain
+// strings and per-root settings in [root_options.<name>] (the TOML tags of Config).
+// Version 2 files keep everything about a root in its own [roots.<name>] table.
+const SchemaVersion = 2
+
+// fileV1 is the on-disk layout of a version 1 configuration file.
+type fileV1 struct {
+	Roots       map[string]string      `toml:"roots"`
+	Default     DefaultConfig          `toml:"default"`
+	Clone       CloneConfig            `toml:"clone,omitempty"`
+	Scan        ScanConfig             `toml:"scan,omitempty"`
+	RootOptions map[string]RootOptions `toml:"root_options,omitempty"`
+}
+
+// fileV2 is the on-disk layout of a version 2 configuration file.
+type fileV2 struct {
+	Version  int                      `toml:"version"`
+	Profile  string                   `toml:"profile,omitempty"`
+	Default  DefaultConfig            `toml:"default"`
+	Clone    CloneConfig              `toml:"clone,omitempty"`
+	Scan     ScanConfig               `toml:"scan,omitempty"`
+	Roots    map[string]rootFileV2    `toml:"roots"`
+	Profiles map[string]profileFileV2 `toml:"profiles,omitempty"`
+}
+
+// rootFileV2 is a [roots.<name>] table: the root's path and its settings.
+type rootFileV2 struct {
+	Path string `toml:"path"`
+	RootOptions
+}
+
+// profileFileV2 is a [profiles.<name>] table, holding the default root and the
+// roots of a profile in the same layout as the top level.
+type profileFileV2 struct {
+	Default DefaultConfig         `toml:"default"`
+	Roots   map[string]rootFileV2 `toml:"roots"`
+}
+
+// decode parses a configuration file of any supported version.
+// The returned config records the version it was read from, and its root
+// paths are expanded (see ExpandPath).
+func decode(data string) (*Config, *domain.GhqxError) {
+	var probe struct {
+		Version int `toml:"version"`
+	}
+	if _, err := toml.Decode(data, &probe); err != nil {
+		return nil, domain.ErrConfigInvalidTOML(err)
+	}
+
+	switch probe.Version {
+	case 0, 1:
+		var cfg Config
+		if _, err := toml.Decode(data, &cfg); err != nil {
+			return nil, domain.ErrConfigInvalidTOML(err)
+		}
+		cfg.Version = 1
+		cfg.expandRoots()
+		return &cfg, nil
+	case SchemaVersion:
+		var f fileV2
+		if _, err := toml.Decode(data, &f); err != nil {
+			return nil, domain.ErrConfigInvalidTOML(err)
+		}
+		if _, ok := f.Profiles[DefaultProfileName]; ok {
+			return nil, domain.ErrProfileReserved(DefaultProfileName)
+		}
+		return fromFileV2(f), nil
+	default:
+		return nil, domain.ErrConfigUnsupportedVersion(probe.Version, SchemaVersion)
+	}
+}
+
+// fileVersion returns the schema version cfg is written in. Files read as
+// version 1 stay in that layout until 'ghqx config migrate' rewrites them,
+// unless they gained profiles, which only the current layout can hold.
+func fileVersion(cfg *Config) int {
+	if cfg.Version == 1 && cfg.profile == "" && cfg.defaultProfile == "" && len(cfg.profiles) == 0 {
+		return 1
+	}
+	return SchemaVersion
+}
+
+// toFileV1 converts a Config into the version 1 file layout,
+// writing paths as they were given.
+func toFileV1(cfg *Config) fileV1 {
+	f := fileV1{
+		Roots:   make(map[string]string, len(cfg.Roots)),
+		Default: cfg.Default,
+		Clone:   cfg.Clone,
+		Scan:    cfg.Scan,
+	}
+	for name := range cfg.Roots {
+		f.Roots[name] = cfg.RawRootPath(name)
+	}
+	for name, opts := range cfg.RootOptions {
+		if opts.isZero() {
+			continue
+		}
+		if f.RootOptions == nil {
+			f.RootOptions = make(map[string]RootOptions)
+		}
+		f.RootOptions[name] = opts
+	}
+	return f
+}
+
+// fromFileV2 converts a version 2 file into a Config with the top-level roots active.
+func fromFileV2(f fileV2) *Config {
+	cfg := &Config{
+		Version:        SchemaVersion,
+		Clone:          f.Clone,
+		Scan:           f.Scan,
+		defaultProfile: profileKey(f.Profile),
+	}
+	cfg.setRootSet(rootSetFromFileV2(f.Default, f.Roots))
+	for name, p := range f.Profiles {
+		cfg.addProfile(name, rootSetFromFileV2(p.Default, p.Roots))
+	}
+	return cfg
+}
+
+// rootSetFromFileV2 converts the default root and the [roots.<name>] tables of the
+// top level or of a profile.
+func rootSetFromFileV2(def DefaultConfig, roots map[string]rootFileV2) rootSet {
+	c := &Config{Default: def, Roots: make(map[string]string, len(roots))}
+	for name, root := range roots {
+		c.SetRootPath(name, root.Path)
+		if !root.RootOptions.isZero() {
+			if c.RootOptions == nil {
+				c.RootOptions = make(map[string]RootOptions)
+			}
+			c.RootOptions[name] = root.RootOptions
+		}
+	}
+	return c.rootSet()
+}
+
+// toFileV2 converts a Config into the version 2 file layout.
+// Every profile is written to its own tables, whichever is active.
+func toFileV2(cfg *Config) fileV2 {
+	f := fileV2{
+		Version: SchemaVersion,
+		Profile: cfg.defaultProfile,
+		Clone:   cfg.Clone,
+		Scan:    cfg.Scan,
+	}
+	for name, s := range cfg.rootSets() {
+		def, roots := rootSetToFileV2(s)
+		if name == "" {
+			f.Default, f.Roots = def, roots
+			continue
+		}
+		if f.Profiles == nil {
+			f.Profiles = make(map[string]profileFileV2)
+		}
+		f.Profiles[name] = profileFileV2{Default: def, Roots: roots}
+	}
+	return f
+}
+
+// rootSetToFileV2 converts the roots of a profile into [roots.<name>] tables,
+// writing paths as they were given.
+func rootSetToFileV2(s rootSet) (DefaultConfig, map[string]rootFileV2) {
+	c := &Config{}
+	c.setRootSet(s)
+
+	roots := make(map[string]rootFileV2, len(c.Roots))
+	for name := range c.Roots {
+		roots[name] = rootFileV2{Path: c.RawRootPath(name), RootOptions: c.RootOptions[name]}
+	}
+	return c.Default, roots
+}
@@ -0,0 +1,181 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mi8bi/ghqx/internal/domain"
+)
+
+const v1Config = `[roots]
+dev = "/tmp/dev"
+sandbox = "/tmp/sandbox"
+
+[default]
+root = "dev"
+
+[root_options.sandbox]
+layout = "flat"
+exclude = ["tmp/"]
+`
+
+const v2Config = `version = 2
+
+[default]
+root = "dev"
+
+[roots.dev]
+path = "/tmp/dev"
+
+[roots.archive]
+path = "/tmp/archive"
+type = "archive"
+read_only = true
+clone_backend = "git"
+clone_flags = ["--depth=1"]
+`
+
+func TestDecodeVersions(t *testing.T) {
+	cfg, err := decode(v1Config)
+	if err != nil {
+		t.Fatalf("decode v1 failed: %v", err)
+	}
+	if cfg.Version != 1 || cfg.Roots["sandbox"] != "/tmp/sandbox" || cfg.RootOptions["sandbox"].Layout != "flat" {
+		t.Errorf("unexpected v1 config: %+v", cfg)
+	}
+
+	cfg, err = decode(v2Config)
+	if err != nil {
+		t.Fatalf("decode v2 failed: %v", err)
+	}
+	if cfg.Version != SchemaVersion || cfg.Roots["archive"] != "/tmp/archive" || cfg.Default.Root != "dev" {
+		t.Errorf("unexpected v2 config: %+v", cfg)
+	}
+	want := RootOptions{Type: "archive", ReadOnly: true, CloneBackend: CloneBackendGit, CloneFlags: []string{"--depth=1"}}
+	if !reflect.DeepEqual(cfg.RootOptions["archive"], want) {
+		t.Errorf("archive options = %+v, want %+v", cfg.RootOptions["archive"], want)
+	}
+	if _, ok := cfg.RootOptions["dev"]; ok {
+		t.Error("roots without settings should have no options")
+	}
+	if !cfg.IsReadOnly("archive") || cfg.RootCloneBackend("archive") != CloneBackendGit || cfg.RootCloneBackend("dev") != CloneBackendAuto {
+		t.Error("per-root settings are not applied")
+	}
+
+	_, err = decode("version = 3\n")
+	if err == nil || err.Code != domain.ErrCodeConfigInvalid {
+		t.Errorf("expected unsupported version error, got %v", err)
+	}
+}
+
+func TestSaveWritesCurrentSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	cfg, _ := decode(v1Config)
+	cfg.Version = 0 // Not read from a file, like a new configuration
+
+	loader := NewLoader()
+	if err := loader.Save(cfg, path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	for _, want := range []string{"version = 2", "[roots.sandbox]", `path = "/tmp/sandbox"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("saved config missing %q:\n%s", want, data)
+		}
+	}
+	if strings.Contains(string(data), "root_options") {
+		t.Errorf("saved config should not use root_options:\n%s", data)
+	}
+
+	loaded, err := loader.Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	cfg.Version = SchemaVersion
+	if !reflect.DeepEqual(loaded, cfg) {
+		t.Errorf("round trip mismatch:\n got %+v\nwant %+v", loaded, cfg)
+	}
+}
+
+func TestSaveKeepsVersion1Files(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	original := "# my roots\n" + v1Config
+	if err := os.WriteFile(path, []byte(original), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	loader := NewLoader()
+	cfg, err := loader.Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	cfg.Default.Root = "sandbox"
+	if err := loader.Save(cfg, path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// Only the changed value is rewritten, in the version 1 layout
+	want := strings.Replace(original, `root = "dev"`, `root = "sandbox"`, 1)
+	if data, _ := os.ReadFile(path); string(data) != want {
+		t.Errorf("saved config:\n%s\nwant:\n%s", data, want)
+	}
+	if _, err := os.Stat(path + ".v1.bak"); !os.IsNotExist(err) {
+		t.Error("saving must not migrate the file")
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(v1Config), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	loader := NewLoader()
+	result, err := loader.Migrate(path)
+	if err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+	if !result.Migrated() || result.FromVersion != 1 || result.Backup != path+".v1.bak" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	backup, err := os.ReadFile(result.Backup)
+	if err != nil || string(backup) != v1Config {
+		t.Errorf("backup should hold the original file: %v", err)
+	}
+	cfg, err := loader.Load(path)
+	if err != nil {
+		t.Fatalf("Load after migrate failed: %v", err)
+	}
+	if cfg.Version != SchemaVersion || cfg.RootLayout("sandbox") != domain.LayoutFlat {
+		t.Errorf("migrated config lost settings: %+v", cfg)
+	}
+
+	// Current files are left alone
+	result, err = loader.Migrate(path)
+	if err != nil || result.Migrated() {
+		t.Errorf("expected no migration, got %+v, %v", result, err)
+	}
+
+	// An existing backup is never overwritten
+	if err := os.WriteFile(path, []byte(v1Config), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	result, err = loader.Migrate(path)
+	if err != nil {
+		t.Fatalf("second Migrate failed: %v", err)
+	}
+	if result.Backup == path+".v1.bak" {
+		t.Error("second backup must not overwrite the first")
+	}
+	if _, err := os.Stat(result.Backup); err != nil {
+		t.Errorf("second backup missing: %v", err)
+	}
+}
@@ -38,6 +38,12 @@ func NewServiceWithConfigPath(configPath string) *Service {
 	}
 }
 
+// WithProfile は診断で読み込むプロファイルを指定します (空の場合は GHQX_PROFILE または設定ファイルで選択)
+func (s *Service) WithProfile(name string) *Service {
+	s.configLoader.WithProfile(name)
+	return s
+}
+
 // RunChecks はすべての診断を実行します
 func (s *Service) RunChecks() []CheckResult {
 	return []CheckResult{
@@ -49,7 +55,7 @@ func (s *Service) RunChecks() []CheckResult {
 
 // CheckConfig は設定ファイルを診断します
 func (s *Service) CheckConfig() CheckResult {
-	_, err := s.configLoader.Load(s.configPath)
+	cfg, err := s.configLoader.Load(s.configPath)
 	if err != nil {
 		return CheckResult{
 			Name:    i18n.T("doctor.check.config.name"),
@@ -61,7 +67,7 @@ func (s *Service) CheckConfig() CheckResult {
 	return CheckResult{
 		Name:    i18n.T("doctor.check.config.name"),
 		OK:      true,
-		Message: i18n.T("doctor.check.config.ok"),
+		Message: i18n.T("doctor.check.config.ok") + " " + fmt.Sprintf(i18n.T("doctor.check.config.profile"), cfg.ActiveProfile()),
 	}
 }
 
@@ -69,6 +75,14 @@ func (s *Service) CheckConfig() CheckResult {
 func (s *Service) CheckGhq() CheckResult {
 	path, err := exec.LookPath("ghq")
 	if err != nil {
+		// ghq is optional when repositories can be cloned with plain git
+		if s.canCloneWithoutGhq() {
+			return CheckResult{
+				Name:    i18n.T("doctor.check.ghq.name"),
+				OK:      true,
+				Message: i18n.T("doctor.check.ghq.notRequired"),
+			}
+		}
 		return CheckResult{
 			Name:    i18n.T("doctor.check.ghq.name"),
 			OK:      false,
@@ -94,6 +108,16 @@ func (s *Service) CheckGhq() CheckResult {
 	}
 }
 
+// canCloneWithoutGhq は ghq なしで git clone にフォールバックできるかを判定します
+func (s *Service) canCloneWithoutGhq() bool {
+	cfg, err := s.configLoader.Load(s.configPath)
+	if err != nil || cfg.GetCloneBackend() == config.CloneBackendGhq {
+		return false
+	}
+	_, err = exec.LookPath("git")
+	return err == nil
+}
+
 // CheckGit は git コマンドを診断します
 func (s *Service) CheckGit() CheckResult {
 	path, err := exec.LookPath("git")
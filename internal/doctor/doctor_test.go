@@ -311,3 +311,34 @@ func TestCheckResultStructure(t *testing.T) {
 		t.Error("Hint mismatch")
 	}
 }
+
+func TestCheckGhqNotRequiredWithGitBackend(t *testing.T) {
+	gitPath, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git not available, skipping test")
+	}
+
+	tmp := t.TempDir()
+	cfgPath := filepath.Join(tmp, "config.toml")
+	content := "[roots]\ndev = \"" + filepath.ToSlash(tmp) + "\"\n\n[clone]\nbackend = \"git\"\n"
+	if err := os.WriteFile(cfgPath, []byte(content), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	// Only git is on PATH
+	binDir := filepath.Join(tmp, "bin")
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.Symlink(gitPath, filepath.Join(binDir, filepath.Base(gitPath))); err != nil {
+		t.Skipf("symlink not supported: %v", err)
+	}
+	origPath := os.Getenv("PATH")
+	os.Setenv("PATH", binDir)
+	defer os.Setenv("PATH", origPath)
+
+	s := NewServiceWithConfigPath(cfgPath)
+	if result := s.CheckGhq(); !result.OK {
+		t.Errorf("CheckGhq should pass without ghq when clone.backend is git: %s", result.Message)
+	}
+}
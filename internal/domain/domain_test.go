@@ -13,10 +13,18 @@ func TestFormatDisplayName(t *testing.T) {
 	}
 
 	for in, want := range cases {
-		if got := FormatDisplayName(in); got != want {
+		if got := FormatDisplayName(in, LayoutGhq); got != want {
 			t.Fatalf("FormatDisplayName(%q) = %q, want %q", in, got, want)
 		}
 	}
+
+	// Layouts without a host keep the whole name
+	if got := FormatDisplayName("group/sub/repo", LayoutOwnerRepo); got != "group/sub/repo" {
+		t.Fatalf("FormatDisplayName(owner/repo layout) = %q", got)
+	}
+	if got := FormatDisplayName("scratch", LayoutFlat); got != "scratch" {
+		t.Fatalf("FormatDisplayName(flat layout) = %q", got)
+	}
 }
 
 func TestDetermineWorkspaceType(t *testing.T) {
@@ -65,3 +73,64 @@ func TestGhqxErrorBehaviors(t *testing.T) {
 		t.Fatalf("DetailedError should be non-empty")
 	}
 }
+
+func TestNextWorkspaceType(t *testing.T) {
+	testCases := []struct {
+		in   WorkspaceType
+		want WorkspaceType
+		ok   bool
+	}{
+		{WorkspaceTypeSandbox, WorkspaceTypeDev, true},
+		{WorkspaceTypeDev, WorkspaceTypeRelease, true},
+		{WorkspaceTypeRelease, "", false},
+		{WorkspaceTypeUnknown, "", false},
+	}
+
+	for _, tc := range testCases {
+		got, ok := NextWorkspaceType(tc.in)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("NextWorkspaceType(%q) = %q, %v; want %q, %v", tc.in, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestGitStatusPredicates(t *testing.T) {
+	var g GitStatus
+	if g.HasUpstream() || g.HasChanges() || g.UntrackedOnly() {
+		t.Errorf("zero GitStatus should report nothing: %+v", g)
+	}
+
+	g = GitStatus{Upstream: "origin/main", Untracked: 2}
+	if !g.HasUpstream() || !g.HasChanges() || !g.UntrackedOnly() {
+		t.Errorf("expected upstream and untracked-only changes: %+v", g)
+	}
+
+	g.Unstaged = 1
+	if g.UntrackedOnly() {
+		t.Errorf("unstaged changes are not untracked-only: %+v", g)
+	}
+}
+
+func TestWorkspaceTypeNamesAndRepositoryType(t *testing.T) {
+	for name, want := range map[string]bool{
+		"dev": true, "archive": true, "oss-2": true, "my_work": true,
+		"": false, "Dev": false, "2nd": false, "with space": false, "a/b": false,
+	} {
+		if got := IsValidWorkspaceType(name); got != want {
+			t.Errorf("IsValidWorkspaceType(%q) = %v, want %v", name, got, want)
+		}
+	}
+
+	testCases := map[WorkspaceType]ProjectType{
+		WorkspaceTypeSandbox: ProjectTypeSandboxGit,
+		WorkspaceTypeDev:     ProjectTypeDev,
+		WorkspaceTypeRelease: ProjectTypeRelease,
+		WorkspaceTypeUnknown: ProjectTypeDir,
+		"archive":            "archive",
+	}
+	for in, want := range testCases {
+		if got := in.RepositoryType(); got != want {
+			t.Errorf("%q.RepositoryType() = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -15,6 +15,7 @@ const (
 	ErrCodeInvalidPath      ErrorCode = "INVALID_PATH"
 	ErrCodeGitError         ErrorCode = "GIT_ERROR"
 	ErrCodeFSError          ErrorCode = "FS_ERROR"
+	ErrCodeCommandFailed    ErrorCode = "COMMAND_FAILED"
 )
 
 // GhqxError represents a domain error with user-friendly output.
@@ -25,6 +26,7 @@ type GhqxError struct {
 	Hint    string // Actionable advice for user
 	Cause   error  // Internal error (not shown to user by default)
 	Internal string // Internal debug information
+	ExitCode int    // Process exit code (0 means the default of 1)
 }
 
 // Error implements the error interface.
@@ -79,6 +81,12 @@ func (e *GhqxError) WithHint(hint string) *GhqxError {
 	return e
 }
 
+// WithExitCode sets the process exit code used when this error ends a command.
+func (e *GhqxError) WithExitCode(code int) *GhqxError {
+	e.ExitCode = code
+	return e
+}
+
 // WithInternal adds internal debug information.
 func (e *GhqxError) WithInternal(internal string) *GhqxError {
 	e.Internal = internal
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/mi8bi/ghqx/internal/i18n"
 )
@@ -31,6 +32,13 @@ var (
 		).WithHint(i18n.T("error.config.invalidToml.hint"))
 	}
 
+	ErrConfigUnsupportedVersion = func(version, supported int) *GhqxError {
+		return NewError(
+			ErrCodeConfigInvalid,
+			fmt.Sprintf(i18n.T("error.config.unsupportedVersion.message"), version),
+		).WithHint(fmt.Sprintf(i18n.T("error.config.unsupportedVersion.hint"), supported))
+	}
+
 	ErrConfigNoRoots = NewError(
 		ErrCodeConfigInvalid,
 		i18n.T("error.config.noRoots.message"),
@@ -40,6 +48,153 @@ var (
 		ErrCodeConfigInvalid,
 		i18n.T("error.config.invalidDefaultRoot.message"),
 	).WithHint(i18n.T("error.config.invalidDefaultRoot.hint"))
+
+	ErrConfigInvalidCloneBackend = func(backend string) *GhqxError {
+		return NewError(
+			ErrCodeConfigInvalid,
+			fmt.Sprintf(i18n.T("error.config.invalidCloneBackend.message"), backend),
+		).WithHint(i18n.T("error.config.invalidCloneBackend.hint"))
+	}
+
+	ErrConfigInvalidMaxDepth = func(depth int) *GhqxError {
+		return NewError(
+			ErrCodeConfigInvalid,
+			fmt.Sprintf(i18n.T("error.config.invalidMaxDepth.message"), depth),
+		).WithHint(i18n.T("error.config.invalidMaxDepth.hint"))
+	}
+
+	ErrConfigInvalidExclude = func(pattern string) *GhqxError {
+		return NewError(
+			ErrCodeConfigInvalid,
+			fmt.Sprintf(i18n.T("error.config.invalidExclude.message"), pattern),
+		).WithHint(i18n.T("error.config.invalidExclude.hint"))
+	}
+
+	ErrConfigUnsetVariable = func(root, variable string) *GhqxError {
+		return NewError(
+			ErrCodeConfigInvalid,
+			fmt.Sprintf(i18n.T("error.config.unsetVariable.message"), variable, root),
+		).WithHint(i18n.T("error.config.unsetVariable.hint"))
+	}
+
+	ErrConfigInvalidLayout = func(root, layout string) *GhqxError {
+		return NewError(
+			ErrCodeConfigInvalid,
+			fmt.Sprintf(i18n.T("error.config.invalidLayout.message"), layout, root),
+		).WithHint(i18n.T("error.config.invalidLayout.hint"))
+	}
+
+	ErrConfigInvalidWorkspaceType = func(root, workspaceType string) *GhqxError {
+		return NewError(
+			ErrCodeConfigInvalid,
+			fmt.Sprintf(i18n.T("error.config.invalidType.message"), workspaceType, root),
+		).WithHint(i18n.T("error.config.invalidType.hint"))
+	}
+
+	ErrConfigInvalidColor = func(root, color string) *GhqxError {
+		return NewError(
+			ErrCodeConfigInvalid,
+			fmt.Sprintf(i18n.T("error.config.invalidColor.message"), color, root),
+		).WithHint(i18n.T("error.config.invalidColor.hint"))
+	}
+
+	ErrConfigUnknownKey = func(key string) *GhqxError {
+		return NewError(
+			ErrCodeConfigInvalid,
+			fmt.Sprintf(i18n.T("error.config.unknownKey.message"), key),
+		).WithHint(i18n.T("error.config.unknownKey.hint"))
+	}
+
+	ErrConfigInvalidValue = func(key, value string) *GhqxError {
+		return NewError(
+			ErrCodeConfigInvalid,
+			fmt.Sprintf(i18n.T("error.config.invalidValue.message"), value, key),
+		).WithHint(i18n.T("error.config.invalidValue.hint"))
+	}
+
+	ErrConfigUnsetRootPath = func(key string) *GhqxError {
+		return NewError(
+			ErrCodeConfigInvalid,
+			fmt.Sprintf(i18n.T("error.config.unsetRootPath.message"), key),
+		).WithHint(i18n.T("error.config.unsetRootPath.hint"))
+	}
+
+	ErrConfigInvalidRootName = func(name string) *GhqxError {
+		return NewError(
+			ErrCodeConfigInvalid,
+			fmt.Sprintf(i18n.T("error.config.invalidRootName.message"), name),
+		).WithHint(i18n.T("error.config.invalidRootName.hint"))
+	}
+
+	ErrConfigRootExists = func(name string) *GhqxError {
+		return NewError(
+			ErrCodeConfigInvalid,
+			fmt.Sprintf(i18n.T("error.config.rootExists.message"), name),
+		).WithHint(i18n.T("error.config.rootExists.hint"))
+	}
+
+	ErrConfigRemoveDefaultRoot = func(name string) *GhqxError {
+		return NewError(
+			ErrCodeConfigInvalid,
+			fmt.Sprintf(i18n.T("error.config.removeDefaultRoot.message"), name),
+		).WithHint(i18n.T("error.config.removeDefaultRoot.hint"))
+	}
+
+	ErrConfigUnknownRootOptions = func(name string) *GhqxError {
+		return NewError(
+			ErrCodeConfigInvalid,
+			fmt.Sprintf(i18n.T("error.config.unknownRootOptions.message"), name),
+		).WithHint(i18n.T("error.config.unknownRootOptions.hint"))
+	}
+
+	ErrProfileNotFound = func(name string, available []string) *GhqxError {
+		return NewError(
+			ErrCodeConfigInvalid,
+			fmt.Sprintf(i18n.T("error.profile.notFound.message"), name),
+		).WithHint(fmt.Sprintf(i18n.T("error.profile.notFound.hint"), strings.Join(available, ", ")))
+	}
+
+	ErrProfileReserved = func(name string) *GhqxError {
+		return NewError(
+			ErrCodeConfigInvalid,
+			fmt.Sprintf(i18n.T("error.profile.reserved.message"), name),
+		).WithHint(fmt.Sprintf(i18n.T("error.profile.reserved.hint"), name))
+	}
+
+	ErrConfigNoHistory = func(path string) *GhqxError {
+		return NewError(
+			ErrCodeConfigInvalid,
+			fmt.Sprintf(i18n.T("error.config.noHistory.message"), path),
+		).WithHint(i18n.T("error.config.noHistory.hint"))
+	}
+
+	ErrConfigBackupNotFound = func(backup string, count int) *GhqxError {
+		return NewError(
+			ErrCodeConfigInvalid,
+			fmt.Sprintf(i18n.T("error.config.backupNotFound.message"), backup),
+		).WithHint(fmt.Sprintf(i18n.T("error.config.backupNotFound.hint"), count))
+	}
+
+	ErrConfigValidateFailed = func(path string, count int) *GhqxError {
+		return NewError(
+			ErrCodeConfigInvalid,
+			fmt.Sprintf(i18n.T("error.config.validateFailed.message"), count, path),
+		).WithHint(i18n.T("error.config.validateFailed.hint"))
+	}
+
+	ErrConfigProblems = func(problems []*GhqxError) *GhqxError {
+		message := fmt.Sprintf(i18n.T("error.config.problems.message"), len(problems))
+		for _, problem := range problems {
+			message += "\n  - " + problem.Message
+			if problem.Hint != "" {
+				message += "\n    " + i18n.T("config.validate.hint") + ": " + problem.Hint
+			}
+		}
+		return NewError(
+			ErrCodeConfigInvalid,
+			message,
+		).WithHint(i18n.T("error.config.problems.hint"))
+	}
 )
 
 // Root errors
@@ -51,6 +206,13 @@ var (
 		).WithHint(i18n.T("error.root.notFound.hint"))
 	}
 
+	ErrRootReadOnly = func(name string) *GhqxError {
+		return NewError(
+			ErrCodeInvalidPath,
+			fmt.Sprintf(i18n.T("error.root.readOnly.message"), name),
+		).WithHint(i18n.T("error.root.readOnly.hint"))
+	}
+
 	ErrRootDirNotExist = func(name, path string) *GhqxError {
 		return NewError(
 			ErrCodeRootNotFound,
@@ -58,6 +220,42 @@ var (
 		).WithHint(i18n.T("error.root.dirNotExist.hint")).
 			WithInternal("path: " + path)
 	}
+
+	ErrRootNotDirectory = func(name, path string) *GhqxError {
+		return NewError(
+			ErrCodeInvalidPath,
+			fmt.Sprintf(i18n.T("error.root.notDirectory.message"), name, path),
+		).WithHint(i18n.T("error.root.notDirectory.hint"))
+	}
+
+	ErrRootNotWritable = func(name, path string, cause error) *GhqxError {
+		return NewErrorWithCause(
+			ErrCodeInvalidPath,
+			fmt.Sprintf(i18n.T("error.root.notWritable.message"), name, path),
+			cause,
+		).WithHint(i18n.T("error.root.notWritable.hint"))
+	}
+
+	ErrRootRelativePath = func(name, path string) *GhqxError {
+		return NewError(
+			ErrCodeInvalidPath,
+			fmt.Sprintf(i18n.T("error.root.relativePath.message"), name, path),
+		).WithHint(i18n.T("error.root.relativePath.hint"))
+	}
+
+	ErrRootsOverlap = func(name, other string) *GhqxError {
+		return NewError(
+			ErrCodeInvalidPath,
+			fmt.Sprintf(i18n.T("error.root.overlap.message"), name, other),
+		).WithHint(i18n.T("error.root.overlap.hint"))
+	}
+
+	ErrRootOverlapsGhq = func(name, ghqRoot string) *GhqxError {
+		return NewError(
+			ErrCodeInvalidPath,
+			fmt.Sprintf(i18n.T("error.root.overlapsGhq.message"), name, ghqRoot),
+		).WithHint(i18n.T("error.root.overlapsGhq.hint"))
+	}
 )
 
 // Project errors
@@ -69,17 +267,114 @@ var (
 		).WithHint(i18n.T("error.project.notFound.hint"))
 	}
 
+	ErrProjectAmbiguous = func(name string, roots []string) *GhqxError {
+		return NewError(
+			ErrCodeProjectNotFound,
+			fmt.Sprintf(i18n.T("error.project.ambiguous.message"), name, strings.Join(roots, ", ")),
+		).WithHint(i18n.T("error.project.ambiguous.hint"))
+	}
+
 	ErrProjectNameInvalid = NewError(
 		ErrCodeInvalidPath,
 		i18n.T("error.project.nameInvalid.message"),
 	).WithHint(i18n.T("error.project.nameInvalid.hint"))
 
+	ErrProjectAlreadyExists = func(path string) *GhqxError {
+		return NewError(
+			ErrCodeInvalidPath,
+			i18n.T("error.project.alreadyExists.message"),
+		).WithHint(i18n.T("error.project.alreadyExists.hint")).
+			WithInternal("path: " + path)
+	}
+
+	ErrRepoRefInvalid = func(ref string) *GhqxError {
+		return NewError(
+			ErrCodeInvalidPath,
+			fmt.Sprintf(i18n.T("error.repoRef.invalid.message"), ref),
+		).WithHint(i18n.T("error.repoRef.invalid.hint"))
+	}
+
 	ErrArgumentRequired = NewError(
 		ErrCodeInvalidPath,
 		i18n.T("error.argument.required"),
 	)
 )
 
+// Promote errors
+var (
+	ErrPromoteSameWorkspace = func(workspace string) *GhqxError {
+		return NewError(
+			ErrCodeInvalidPath,
+			fmt.Sprintf(i18n.T("error.promote.sameWorkspace.message"), workspace),
+		).WithHint(i18n.T("error.promote.sameWorkspace.hint"))
+	}
+
+	ErrPromoteNoNextWorkspace = func(workspace string) *GhqxError {
+		return NewError(
+			ErrCodeInvalidPath,
+			fmt.Sprintf(i18n.T("error.promote.noNextWorkspace.message"), workspace),
+		).WithHint(i18n.T("error.promote.noNextWorkspace.hint"))
+	}
+
+	ErrPromoteLayoutMismatch = func(name, workspace, layout string) *GhqxError {
+		return NewError(
+			ErrCodeInvalidPath,
+			fmt.Sprintf(i18n.T("error.promote.layoutMismatch.message"), name, workspace, layout),
+		).WithHint(i18n.T("error.promote.layoutMismatch.hint"))
+	}
+
+	ErrPromoteMoveFailed = func(from, to string, cause error) *GhqxError {
+		return NewErrorWithCause(
+			ErrCodeFSError,
+			i18n.T("error.promote.moveFailed.message"),
+			cause,
+		).WithHint(i18n.T("error.promote.moveFailed.hint")).
+			WithInternal("from: " + from + ", to: " + to)
+	}
+)
+
+// Worktree errors
+var (
+	ErrWorktreeNotGit = func(name string) *GhqxError {
+		return NewError(
+			ErrCodeGitError,
+			fmt.Sprintf(i18n.T("error.worktree.notGit.message"), name),
+		).WithHint(i18n.T("error.worktree.notGit.hint"))
+	}
+
+	ErrWorktreeNotLinked = func(name string) *GhqxError {
+		return NewError(
+			ErrCodeInvalidPath,
+			fmt.Sprintf(i18n.T("error.worktree.notLinked.message"), name),
+		).WithHint(i18n.T("error.worktree.notLinked.hint"))
+	}
+)
+
+// Status errors
+var (
+	ErrStatusInvalidFormat = func(format string) *GhqxError {
+		return NewError(
+			ErrCodeInvalidPath,
+			fmt.Sprintf(i18n.T("error.status.invalidFormat.message"), format),
+		).WithHint(i18n.T("error.status.invalidFormat.hint"))
+	}
+
+	ErrStatusInvalidSort = func(key string) *GhqxError {
+		return NewError(
+			ErrCodeInvalidPath,
+			fmt.Sprintf(i18n.T("error.status.invalidSort.message"), key),
+		).WithHint(i18n.T("error.status.invalidSort.hint"))
+	}
+
+	ErrStatusInvalidTemplate = func(cause error) *GhqxError {
+		return NewErrorWithCause(
+			ErrCodeInvalidPath,
+			i18n.T("error.status.invalidTemplate.message"),
+			cause,
+		).WithHint(i18n.T("error.status.invalidTemplate.hint"))
+	}
+)
+
 // Git errors
 var (
 	ErrGitDirtyRepo = NewError(
@@ -103,6 +398,24 @@ var (
 	}
 )
 
+// VCS errors
+var (
+	ErrVCSTimeout = func(tool, operation string) *GhqxError {
+		return NewError(
+			ErrCodeCommandFailed,
+			fmt.Sprintf(i18n.T("error.vcs.timeout.message"), tool, operation),
+		).WithInternal("timeout exceeded")
+	}
+
+	ErrVCSCommandFailed = func(tool, operation string, cause error) *GhqxError {
+		return NewErrorWithCause(
+			ErrCodeCommandFailed,
+			fmt.Sprintf(i18n.T("error.vcs.commandFailed.message"), tool, operation),
+			cause,
+		)
+	}
+)
+
 
 
 // Filesystem errors
@@ -132,3 +445,21 @@ var (
 	}
 )
 
+// Index errors
+var (
+	ErrIndexCacheDir = func(cause error) *GhqxError {
+		return NewErrorWithCause(
+			ErrCodeFSError,
+			i18n.T("error.index.cacheDir.message"),
+			cause,
+		).WithHint(i18n.T("error.index.cacheDir.hint"))
+	}
+
+	ErrIndexWrite = func(cause error) *GhqxError {
+		return NewErrorWithCause(
+			ErrCodeFSError,
+			i18n.T("error.index.write.message"),
+			cause,
+		).WithHint(i18n.T("error.index.write.hint"))
+	}
+)
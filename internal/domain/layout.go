@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Layout is the number of directory levels between a root and its projects.
+// Plain directories count as projects only at this depth, while repositories
+// are found at any depth up to the scan limit.
+type Layout int
+
+const (
+	// LayoutFlat keeps projects directly in the root: <root>/<repo>
+	LayoutFlat Layout = 1
+	// LayoutOwnerRepo groups projects by owner: <root>/<owner>/<repo>
+	LayoutOwnerRepo Layout = 2
+	// LayoutGhq is the ghq layout and the default: <root>/<host>/<owner>/<repo>
+	LayoutGhq Layout = 3
+)
+
+// MaxLayoutDepth is the deepest custom layout accepted.
+const MaxLayoutDepth = 10
+
+// Layout names accepted in the configuration besides a custom depth ("4").
+const (
+	LayoutNameFlat      = "flat"
+	LayoutNameOwnerRepo = "owner/repo"
+	LayoutNameGhq       = "ghq"
+)
+
+// ParseLayout parses a layout name or a custom depth between 1 and MaxLayoutDepth.
+// An empty string is the default ghq layout.
+func ParseLayout(s string) (Layout, bool) {
+	switch strings.TrimSpace(s) {
+	case "", LayoutNameGhq:
+		return LayoutGhq, true
+	case LayoutNameOwnerRepo:
+		return LayoutOwnerRepo, true
+	case LayoutNameFlat:
+		return LayoutFlat, true
+	}
+
+	depth, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || depth < 1 || depth > MaxLayoutDepth {
+		return 0, false
+	}
+	return Layout(depth), true
+}
+
+// String returns the layout's name, or its depth for custom layouts.
+func (l Layout) String() string {
+	switch l {
+	case LayoutFlat:
+		return LayoutNameFlat
+	case LayoutOwnerRepo:
+		return LayoutNameOwnerRepo
+	case LayoutGhq:
+		return LayoutNameGhq
+	}
+	return strconv.Itoa(int(l))
+}
+
+// RepoPath returns the slash-separated path of a repository below a root with this layout.
+// The ghq layout keeps the full host/owner/repo path (including subgroups),
+// owner/repo drops the host, flat keeps only the name, and a custom depth keeps
+// that many trailing segments of the full path.
+func (l Layout) RepoPath(ref RepoRef) string {
+	switch l {
+	case LayoutGhq:
+		return ref.String()
+	case LayoutOwnerRepo:
+		return ref.Owner + "/" + ref.Name
+	case LayoutFlat:
+		return ref.Name
+	}
+
+	segments := strings.Split(ref.String(), "/")
+	if int(l) < len(segments) {
+		segments = segments[len(segments)-int(l):]
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,54 @@
+package domain
+
+import "testing"
+
+func TestParseLayout(t *testing.T) {
+	cases := map[string]Layout{
+		"":           LayoutGhq,
+		"ghq":        LayoutGhq,
+		"owner/repo": LayoutOwnerRepo,
+		"flat":       LayoutFlat,
+		"4":          Layout(4),
+		" 1 ":        LayoutFlat,
+	}
+	for in, want := range cases {
+		if got, ok := ParseLayout(in); !ok || got != want {
+			t.Errorf("ParseLayout(%q) = %v, %v; want %v", in, got, ok, want)
+		}
+	}
+
+	for _, in := range []string{"0", "-1", "11", "host/owner/repo", "deep"} {
+		if _, ok := ParseLayout(in); ok {
+			t.Errorf("ParseLayout(%q) should fail", in)
+		}
+	}
+}
+
+func TestLayoutString(t *testing.T) {
+	for _, l := range []Layout{LayoutFlat, LayoutOwnerRepo, LayoutGhq, Layout(5)} {
+		if parsed, ok := ParseLayout(l.String()); !ok || parsed != l {
+			t.Errorf("layout %d does not round-trip through %q", l, l.String())
+		}
+	}
+}
+
+func TestLayoutRepoPath(t *testing.T) {
+	ref := RepoRef{Host: "gitlab.com", Owner: "group/sub", Name: "repo"}
+	cases := map[Layout]string{
+		LayoutGhq:       "gitlab.com/group/sub/repo",
+		LayoutOwnerRepo: "group/sub/repo",
+		LayoutFlat:      "repo",
+		Layout(6):       "gitlab.com/group/sub/repo",
+	}
+	for l, want := range cases {
+		if got := l.RepoPath(ref); got != want {
+			t.Errorf("%v.RepoPath = %q, want %q", l, got, want)
+		}
+	}
+
+	// A custom depth keeps that many trailing segments
+	deep := RepoRef{Host: "gitlab.com", Owner: "a/b/c", Name: "repo"}
+	if got := Layout(4).RepoPath(deep); got != "a/b/c/repo" {
+		t.Errorf("custom RepoPath = %q", got)
+	}
+}
@@ -1,11 +1,15 @@
 package domain
 
-import "strings"
+import (
+	"strings"
+	"time"
+)
 
 // RootName represents a workspace root identifier (dev, release, sandbox, etc.).
 type RootName string
 
 // WorkspaceType categorizes the workspace based on its purpose and usage patterns.
+// Besides the built-in types below, roots may declare their own (e.g. "archive").
 type WorkspaceType string
 
 const (
@@ -19,6 +23,38 @@ const (
 	WorkspaceTypeUnknown WorkspaceType = "unknown"
 )
 
+// IsValidWorkspaceType reports whether name can be used as a workspace type:
+// a lowercase letter followed by lowercase letters, digits, '-' or '_'.
+func IsValidWorkspaceType(name string) bool {
+	if name == "" || name[0] < 'a' || name[0] > 'z' {
+		return false
+	}
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// RepositoryType returns the ProjectType of a repository in a workspace of type t.
+// Built-in types keep their historical project types, user-defined types use
+// their own name, and repositories in unknown workspaces are plain directories.
+func (t WorkspaceType) RepositoryType() ProjectType {
+	switch t {
+	case WorkspaceTypeSandbox:
+		return ProjectTypeSandboxGit
+	case WorkspaceTypeDev:
+		return ProjectTypeDev
+	case WorkspaceTypeRelease:
+		return ProjectTypeRelease
+	case WorkspaceTypeUnknown, "":
+		return ProjectTypeDir
+	default:
+		return ProjectType(t)
+	}
+}
+
 // ProjectType categorizes the project based on its location and git status.
 type ProjectType string
 
@@ -39,6 +75,31 @@ const (
 	ProjectTypeDir ProjectType = "dir"
 )
 
+// VCSKind identifies the version control system managing a project.
+type VCSKind string
+
+const (
+	// VCSNone marks a plain directory without version control
+	VCSNone VCSKind = ""
+	// VCSGit represents a git working tree (including linked worktrees)
+	VCSGit VCSKind = "git"
+	// VCSBareGit represents a bare git repository without a working tree
+	VCSBareGit VCSKind = "git-bare"
+	// VCSMercurial represents a Mercurial (hg) working copy
+	VCSMercurial VCSKind = "hg"
+	// VCSSubversion represents a Subversion (svn) working copy
+	VCSSubversion VCSKind = "svn"
+	// VCSFossil represents a Fossil checkout
+	VCSFossil VCSKind = "fossil"
+	// VCSJujutsu represents a Jujutsu (jj) workspace
+	VCSJujutsu VCSKind = "jj"
+)
+
+// HasWorkingCopy reports whether the repository has files checked out that can be dirty.
+func (k VCSKind) HasWorkingCopy() bool {
+	return k != VCSNone && k != VCSBareGit
+}
+
 // Root represents a workspace directory configuration.
 type Root struct {
 	// Name is the identifier for this root (e.g., "dev", "sandbox")
@@ -47,6 +108,10 @@ type Root struct {
 	Path string
 	// WorkspaceType indicates the purpose of this workspace
 	WorkspaceType WorkspaceType
+	// Description is a free-form note about the workspace (may be empty)
+	Description string
+	// Color is the terminal color used for the workspace (ANSI number or hex, may be empty)
+	Color string
 }
 
 // Project represents a repository or directory within a workspace.
@@ -63,18 +128,79 @@ type Project struct {
 	WorkspaceType WorkspaceType
 	// Type categorizes the project based on location and git status
 	Type ProjectType
-	// HasGit indicates whether this is a git repository
+	// VCS is the version control system managing the project (VCSNone for plain directories)
+	VCS VCSKind
+	// HasGit indicates whether this is a git working tree, so git commands can run in it
 	HasGit bool
+	// WorktreeOf is the path of the main repository when this is a linked worktree
+	WorktreeOf string
+	// HasSubmodules indicates whether the repository declares submodules (.gitmodules)
+	HasSubmodules bool
 	// Dirty indicates whether the repository has uncommitted changes
 	Dirty bool
-	// Branch is the current git branch name (lazy-loaded in TUI mode)
+	// Branch is the current branch name, or its equivalent in other VCSs (lazy-loaded in TUI mode)
 	Branch string
+	// Git holds detailed repository status (only set when requested)
+	Git GitStatus
+	// ModTime is the latest modification time of the project (only set when requested)
+	ModTime time.Time
+}
+
+// HasWorkingCopy reports whether the project is a repository with checked-out
+// files, so it can be dirty or clean.
+func (p Project) HasWorkingCopy() bool {
+	return p.HasGit || p.VCS.HasWorkingCopy()
+}
+
+// GitStatus holds detailed working tree and branch information for a repository.
+type GitStatus struct {
+	// Loaded indicates whether the fields below were populated
+	Loaded bool
+	// Upstream is the tracked remote branch (e.g., "origin/main"), empty if none
+	Upstream string
+	// Ahead is the number of local commits not yet pushed to upstream
+	Ahead int
+	// Behind is the number of upstream commits not yet merged locally
+	Behind int
+	// Staged is the number of files with changes in the index
+	Staged int
+	// Unstaged is the number of tracked files with changes in the working tree
+	Unstaged int
+	// Untracked is the number of files not tracked by git
+	Untracked int
+	// Conflicted is the number of files with unresolved merge conflicts
+	Conflicted int
+	// Stashes is the number of stash entries
+	Stashes int
+	// LastCommit is the committer date of HEAD (zero for repositories without commits)
+	LastCommit time.Time
+}
+
+// HasUpstream reports whether the current branch tracks a remote branch.
+func (g GitStatus) HasUpstream() bool {
+	return g.Upstream != ""
+}
+
+// HasChanges reports whether the working tree or index differs from HEAD.
+// Untracked files count as changes, matching "git status --porcelain".
+func (g GitStatus) HasChanges() bool {
+	return g.Staged+g.Unstaged+g.Untracked+g.Conflicted > 0
+}
+
+// UntrackedOnly reports whether untracked files are the only changes.
+func (g GitStatus) UntrackedOnly() bool {
+	return g.Untracked > 0 && g.Staged+g.Unstaged+g.Conflicted == 0
 }
 
 // FormatDisplayName shortens a fully qualified project name for display purposes.
-// Removes domain/org prefix and keeps only the last two path components.
+// In the ghq layout (and deeper custom layouts) it removes the domain/org prefix
+// and keeps only the last two path components; shallower layouts have no host
+// to remove, so their names are shown as is.
 // Example: "github.com/user/repo" -> "user/repo"
-func FormatDisplayName(name string) string {
+func FormatDisplayName(name string, layout Layout) string {
+	if layout < LayoutGhq {
+		return name
+	}
 	parts := strings.Split(name, "/")
 	if len(parts) >= 3 {
 		// Return last two components (organization/repo)
@@ -85,6 +211,7 @@ func FormatDisplayName(name string) string {
 }
 
 // DetermineWorkspaceType maps a root name to its corresponding WorkspaceType.
+// It is the fallback for roots that do not declare a type in the configuration.
 // Returns WorkspaceTypeUnknown for unrecognized root names.
 func DetermineWorkspaceType(rootName RootName) WorkspaceType {
 	switch rootName {
@@ -98,3 +225,17 @@ func DetermineWorkspaceType(rootName RootName) WorkspaceType {
 		return WorkspaceTypeUnknown
 	}
 }
+
+// NextWorkspaceType returns the workspace type that follows t in the
+// sandbox -> dev -> release lifecycle.
+// Returns false when t is the last stage or is not part of the lifecycle.
+func NextWorkspaceType(t WorkspaceType) (WorkspaceType, bool) {
+	switch t {
+	case WorkspaceTypeSandbox:
+		return WorkspaceTypeDev, true
+	case WorkspaceTypeDev:
+		return WorkspaceTypeRelease, true
+	default:
+		return "", false
+	}
+}
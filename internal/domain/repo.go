@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"net/url"
+	"strings"
+)
+
+// DefaultRepoHost is the host assumed for short "owner/repo" references.
+const DefaultRepoHost = "github.com"
+
+// LocalRepoHost is the host used for file:// references, which have none.
+const LocalRepoHost = "localhost"
+
+// RepoRef is the canonical identity of a remote repository.
+// Different spellings of the same repository (https URL, scp-style ssh,
+// "owner/repo" short form, with or without ".git") parse to the same RepoRef.
+type RepoRef struct {
+	// Host is the lower-cased repository host (e.g., "github.com")
+	Host string
+	// Owner is the user, organization or group path (e.g., "user" or "group/subgroup")
+	Owner string
+	// Name is the repository name without a ".git" suffix
+	Name string
+}
+
+// String returns the ghq-style relative path "host/owner/name".
+func (r RepoRef) String() string {
+	return r.Host + "/" + r.Owner + "/" + r.Name
+}
+
+// CloneURL returns an https URL for cloning the repository.
+// Use the original reference instead when it already is a URL.
+func (r RepoRef) CloneURL() string {
+	return "https://" + r.String()
+}
+
+// Equal reports whether two references point at the same repository.
+// Comparison is case-insensitive, matching the behavior of common hosting services.
+func (r RepoRef) Equal(other RepoRef) bool {
+	return strings.EqualFold(r.Host, other.Host) &&
+		strings.EqualFold(r.Owner, other.Owner) &&
+		strings.EqualFold(r.Name, other.Name)
+}
+
+// ParseRepoRef parses a repository reference into its canonical form.
+// Supported forms:
+//   - https://github.com/user/repo(.git), http://, git://
+//   - ssh://[user@]host[:port]/user/repo(.git)
+//   - [user@]host:user/repo(.git) (scp-like syntax)
+//   - host/user/repo (first segment contains a dot)
+//   - user/repo (DefaultRepoHost is assumed)
+//   - file:///path/to/user/repo (LocalRepoHost with the last two path segments)
+func ParseRepoRef(ref string) (RepoRef, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return RepoRef{}, ErrRepoRefInvalid(ref)
+	}
+
+	var host, path string
+
+	switch {
+	case strings.HasPrefix(ref, "file://"):
+		u, err := url.Parse(ref)
+		if err != nil {
+			return RepoRef{}, ErrRepoRefInvalid(ref)
+		}
+		segments := splitRepoPath(u.Path)
+		if len(segments) < 2 {
+			return RepoRef{}, ErrRepoRefInvalid(ref)
+		}
+		// Local paths are arbitrarily deep; only owner/name are meaningful
+		return RepoRef{
+			Host:  LocalRepoHost,
+			Owner: segments[len(segments)-2],
+			Name:  segments[len(segments)-1],
+		}, nil
+
+	case strings.Contains(ref, "://"):
+		u, err := url.Parse(ref)
+		if err != nil || u.Hostname() == "" {
+			return RepoRef{}, ErrRepoRefInvalid(ref)
+		}
+		host = u.Hostname()
+		path = u.Path
+
+	case isSCPLike(ref):
+		// [user@]host:path
+		hostPart, pathPart, _ := strings.Cut(ref, ":")
+		if i := strings.LastIndex(hostPart, "@"); i >= 0 {
+			hostPart = hostPart[i+1:]
+		}
+		host = hostPart
+		path = pathPart
+
+	default:
+		path = ref
+	}
+
+	segments := splitRepoPath(path)
+
+	// Short forms: decide whether the first segment is a host
+	if host == "" {
+		if len(segments) >= 3 && strings.Contains(segments[0], ".") {
+			host = segments[0]
+			segments = segments[1:]
+		} else {
+			host = DefaultRepoHost
+		}
+	}
+
+	if host == "" || len(segments) < 2 {
+		return RepoRef{}, ErrRepoRefInvalid(ref)
+	}
+
+	return RepoRef{
+		Host:  strings.ToLower(host),
+		Owner: strings.Join(segments[:len(segments)-1], "/"),
+		Name:  segments[len(segments)-1],
+	}, nil
+}
+
+// isSCPLike reports whether ref uses the scp-like "host:path" syntax.
+// A colon before the first slash indicates this form (e.g., git@github.com:user/repo).
+func isSCPLike(ref string) bool {
+	colon := strings.Index(ref, ":")
+	if colon <= 0 {
+		return false
+	}
+	slash := strings.Index(ref, "/")
+	return slash == -1 || colon < slash
+}
+
+// splitRepoPath splits a repository path into non-empty segments,
+// stripping a trailing ".git" from the last one.
+func splitRepoPath(path string) []string {
+	path = strings.TrimSuffix(strings.Trim(path, "/"), "/")
+	path = strings.TrimSuffix(path, ".git")
+
+	var segments []string
+	for _, s := range strings.Split(path, "/") {
+		if s != "" {
+			segments = append(segments, s)
+		}
+	}
+	return segments
+}
@@ -0,0 +1,90 @@
+package domain
+
+import "testing"
+
+func TestParseRepoRef(t *testing.T) {
+	want := RepoRef{Host: "github.com", Owner: "user", Name: "repo"}
+
+	testCases := []string{
+		"user/repo",
+		"user/repo.git",
+		"github.com/user/repo",
+		"GitHub.com/user/repo/",
+		"https://github.com/user/repo",
+		"https://github.com/user/repo.git",
+		"http://github.com/user/repo/",
+		"git://github.com/user/repo.git",
+		"ssh://git@github.com/user/repo.git",
+		"ssh://git@github.com:22/user/repo",
+		"git@github.com:user/repo.git",
+		"github.com:user/repo",
+	}
+
+	for _, in := range testCases {
+		got, err := ParseRepoRef(in)
+		if err != nil {
+			t.Errorf("ParseRepoRef(%q) failed: %v", in, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ParseRepoRef(%q) = %+v, want %+v", in, got, want)
+		}
+	}
+}
+
+func TestParseRepoRefNestedOwner(t *testing.T) {
+	got, err := ParseRepoRef("https://gitlab.com/group/sub/project.git")
+	if err != nil {
+		t.Fatalf("ParseRepoRef failed: %v", err)
+	}
+	want := RepoRef{Host: "gitlab.com", Owner: "group/sub", Name: "project"}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if got.String() != "gitlab.com/group/sub/project" {
+		t.Errorf("String() = %q", got.String())
+	}
+}
+
+func TestParseRepoRefInvalid(t *testing.T) {
+	for _, in := range []string{"", "  ", "repo", "https://", "git@github.com:repo", "https://github.com/user"} {
+		if _, err := ParseRepoRef(in); err == nil {
+			t.Errorf("ParseRepoRef(%q) expected error", in)
+		}
+	}
+}
+
+func TestRepoRefEqual(t *testing.T) {
+	a := RepoRef{Host: "github.com", Owner: "User", Name: "Repo"}
+	b := RepoRef{Host: "github.com", Owner: "user", Name: "repo"}
+	c := RepoRef{Host: "github.com", Owner: "other", Name: "repo"}
+
+	if !a.Equal(b) {
+		t.Errorf("expected case-insensitive equality")
+	}
+	if a.Equal(c) {
+		t.Errorf("expected different owners to differ")
+	}
+}
+
+func TestParseRepoRefFileURL(t *testing.T) {
+	got, err := ParseRepoRef("file:///tmp/mirrors/user/repo.git")
+	if err != nil {
+		t.Fatalf("ParseRepoRef failed: %v", err)
+	}
+	want := RepoRef{Host: LocalRepoHost, Owner: "user", Name: "repo"}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	if _, err := ParseRepoRef("file:///repo"); err == nil {
+		t.Errorf("expected error for file URL without owner")
+	}
+}
+
+func TestRepoRefCloneURL(t *testing.T) {
+	ref := RepoRef{Host: "github.com", Owner: "user", Name: "repo"}
+	if got := ref.CloneURL(); got != "https://github.com/user/repo" {
+		t.Errorf("CloneURL() = %q", got)
+	}
+}
@@ -0,0 +1,226 @@
+package foreach
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+
+	"github.com/mi8bi/ghqx/internal/config"
+	"github.com/mi8bi/ghqx/internal/domain"
+	"github.com/mi8bi/ghqx/internal/status"
+)
+
+// Service runs an arbitrary command in every matching project directory.
+type Service struct {
+	// cfg holds the application configuration with root paths
+	cfg *config.Config
+	// status discovers the projects to run in
+	status *status.Service
+}
+
+// NewService creates a new foreach service.
+func NewService(cfg *config.Config, statusService *status.Service) *Service {
+	return &Service{
+		cfg:    cfg,
+		status: statusService,
+	}
+}
+
+// Options configures a command run across projects.
+type Options struct {
+	// Filter selects the projects to run in
+	Filter status.Filter
+	// Command is the program and its arguments
+	Command []string
+	// Parallel is the maximum number of commands running at once (1 = sequential)
+	Parallel int
+	// KeepGoing continues starting commands after one has failed
+	KeepGoing bool
+	// Stdout receives the prefixed standard output of every command
+	Stdout io.Writer
+	// Stderr receives the prefixed standard error of every command
+	Stderr io.Writer
+}
+
+// Result is the outcome of running the command in a single project.
+type Result struct {
+	// Project is the directory the command ran in
+	Project domain.Project
+	// ExitCode is the command's exit code (-1 if it could not be started)
+	ExitCode int
+	// Err holds the failure cause when the command did not succeed
+	Err error
+	// Skipped is true when the command was never started because an earlier one failed
+	Skipped bool
+}
+
+// Failed reports whether the command ran and did not succeed.
+func (r Result) Failed() bool {
+	return !r.Skipped && r.Err != nil
+}
+
+// Run executes the command in every project matching the filter.
+// Each output line is prefixed with the project's display name so that
+// interleaved output from parallel runs stays readable.
+// Without KeepGoing, no new command is started once one has failed.
+// Results are sorted by workspace root and project name.
+func (s *Service) Run(opts Options) ([]Result, error) {
+	if len(opts.Command) == 0 {
+		return nil, domain.ErrArgumentRequired
+	}
+
+	// Run in a stable order so sequential output is predictable
+	projects, err := s.status.GetAll(status.Options{Filter: opts.Filter, Sort: status.SortWorkspace})
+	if err != nil {
+		return nil, err
+	}
+
+	parallel := opts.Parallel
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	stdout := opts.Stdout
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+	stderr := opts.Stderr
+	if stderr == nil {
+		stderr = os.Stderr
+	}
+
+	results := make([]Result, len(projects))
+	sem := make(chan struct{}, parallel) // Limits concurrent commands
+	var wg sync.WaitGroup
+	var outMu sync.Mutex // Keeps prefixed lines from different projects whole
+
+	var failedMu sync.Mutex
+	failed := false
+
+	for i, p := range projects {
+		// Acquire before spawning so commands start in order and a failure
+		// is seen before the next command starts
+		sem <- struct{}{}
+
+		failedMu.Lock()
+		stop := failed && !opts.KeepGoing
+		failedMu.Unlock()
+		if stop {
+			<-sem
+			results[i] = Result{Project: p, ExitCode: -1, Skipped: true}
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, p domain.Project) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			results[i] = runInProject(p, opts.Command,
+				newPrefixWriter(stdout, &outMu, p.DisplayName),
+				newPrefixWriter(stderr, &outMu, p.DisplayName),
+			)
+
+			if results[i].Failed() {
+				failedMu.Lock()
+				failed = true
+				failedMu.Unlock()
+			}
+		}(i, p)
+	}
+
+	wg.Wait()
+	return results, nil
+}
+
+// runInProject runs the command with the project directory as working directory.
+func runInProject(p domain.Project, command []string, stdout, stderr *prefixWriter) Result {
+	result := Result{Project: p}
+
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Dir = p.Path
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.Env = append(os.Environ(),
+		"GHQX_PROJECT_NAME="+p.Name,
+		"GHQX_PROJECT_PATH="+p.Path,
+		"GHQX_WORKSPACE="+string(p.Root),
+	)
+
+	err := cmd.Run()
+	stdout.Flush()
+	stderr.Flush()
+
+	if err != nil {
+		result.Err = err
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			result.ExitCode = exitErr.ExitCode()
+		} else {
+			result.ExitCode = -1
+		}
+	}
+	return result
+}
+
+// prefixWriter writes every complete line to the underlying writer with a prefix.
+// Partial lines are buffered until a newline arrives or Flush is called.
+type prefixWriter struct {
+	w      io.Writer
+	mu     *sync.Mutex
+	prefix []byte
+	buf    bytes.Buffer
+}
+
+// newPrefixWriter creates a writer that prefixes lines with "[name] ".
+// mu is shared between all writers targeting the same output.
+func newPrefixWriter(w io.Writer, mu *sync.Mutex, name string) *prefixWriter {
+	return &prefixWriter{
+		w:      w,
+		mu:     mu,
+		prefix: []byte("[" + name + "] "),
+	}
+}
+
+// Write implements io.Writer.
+func (pw *prefixWriter) Write(p []byte) (int, error) {
+	pw.buf.Write(p)
+
+	for {
+		line, err := pw.buf.ReadBytes('\n')
+		if err != nil {
+			// Keep the incomplete line for the next write
+			pw.buf.Reset()
+			pw.buf.Write(line)
+			break
+		}
+		if werr := pw.writeLine(line); werr != nil {
+			return len(p), werr
+		}
+	}
+	return len(p), nil
+}
+
+// Flush writes any buffered partial line, terminated with a newline.
+func (pw *prefixWriter) Flush() error {
+	if pw.buf.Len() == 0 {
+		return nil
+	}
+	line := append(pw.buf.Bytes(), '\n')
+	pw.buf.Reset()
+	return pw.writeLine(line)
+}
+
+// writeLine writes a single prefixed line while holding the shared lock.
+func (pw *prefixWriter) writeLine(line []byte) error {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+	if _, err := pw.w.Write(pw.prefix); err != nil {
+		return err
+	}
+	_, err := pw.w.Write(line)
+	return err
+}
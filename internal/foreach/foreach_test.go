@@ -0,0 +1,127 @@
+package foreach
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mi8bi/ghqx/internal/config"
+	"github.com/mi8bi/ghqx/internal/status"
+)
+
+// setup creates three plain project directories in a dev root.
+func setup(t *testing.T) *config.Config {
+	t.Helper()
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available on this system")
+	}
+
+	dev := filepath.Join(t.TempDir(), "dev")
+	for _, name := range []string{"a", "b", "c"} {
+		if err := os.MkdirAll(filepath.Join(dev, "example.com", "user", name), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+	return &config.Config{Roots: map[string]string{"dev": dev}}
+}
+
+func TestRunPrefixesOutput(t *testing.T) {
+	cfg := setup(t)
+	s := NewService(cfg, status.NewService(cfg))
+
+	var stdout bytes.Buffer
+	results, err := s.Run(Options{
+		Command: []string{"sh", "-c", "printf 'one\\ntwo'; basename \"$PWD\" >&2"},
+		Stdout:  &stdout,
+		Stderr:  &bytes.Buffer{},
+	})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+
+	out := stdout.String()
+	for _, want := range []string{"[user/a] one\n", "[user/a] two\n", "[user/c] two\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRunStopsAfterFailureUnlessKeepGoing(t *testing.T) {
+	cfg := setup(t)
+	s := NewService(cfg, status.NewService(cfg))
+	failInB := []string{"sh", "-c", "test \"$(basename \"$PWD\")\" != b || exit 3"}
+
+	results, err := s.Run(Options{Command: failInB, Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if results[0].Failed() || results[0].Skipped {
+		t.Errorf("expected a to succeed: %+v", results[0])
+	}
+	if !results[1].Failed() || results[1].ExitCode != 3 {
+		t.Errorf("expected b to fail with exit code 3: %+v", results[1])
+	}
+	if !results[2].Skipped {
+		t.Errorf("expected c to be skipped: %+v", results[2])
+	}
+
+	results, err = s.Run(Options{Command: failInB, KeepGoing: true, Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if results[2].Skipped || results[2].Failed() {
+		t.Errorf("expected c to run with KeepGoing: %+v", results[2])
+	}
+}
+
+func TestRunRejectsUnknownWorkspace(t *testing.T) {
+	cfg := setup(t)
+	s := NewService(cfg, status.NewService(cfg))
+
+	_, err := s.Run(Options{Command: []string{"true"}, Filter: status.Filter{Workspace: "nope"}})
+	if err == nil {
+		t.Fatal("expected error for unknown workspace")
+	}
+	if _, err := s.Run(Options{}); err == nil {
+		t.Fatal("expected error for empty command")
+	}
+}
+
+func TestRunReportsCommandNotFound(t *testing.T) {
+	cfg := setup(t)
+	s := NewService(cfg, status.NewService(cfg))
+
+	results, err := s.Run(Options{Command: []string{"ghqx-no-such-command"}, KeepGoing: true, Stderr: &bytes.Buffer{}})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	for _, r := range results {
+		if !r.Failed() || r.ExitCode != -1 {
+			t.Errorf("expected start failure for %s: %+v", r.Project.Name, r)
+		}
+	}
+}
+
+func TestPrefixWriter(t *testing.T) {
+	var out bytes.Buffer
+	var mu sync.Mutex
+	pw := newPrefixWriter(&out, &mu, "x")
+
+	pw.Write([]byte("he"))
+	pw.Write([]byte("llo\nwor"))
+	pw.Write([]byte("ld"))
+	pw.Flush()
+	pw.Flush()
+
+	if got, want := out.String(), "[x] hello\n[x] world\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
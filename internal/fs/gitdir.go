@@ -0,0 +1,83 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// gitdirPrefix starts the single line of a .git file pointing at the real git directory.
+const gitdirPrefix = "gitdir:"
+
+// GitDir returns the git directory of the working tree at path.
+// The .git entry is either the git directory itself or a file containing
+// "gitdir: <path>", as written for linked worktrees, submodules and
+// repositories created with --separate-git-dir.
+func GitDir(path string) (string, bool) {
+	dotGit := filepath.Join(path, ".git")
+	info, err := os.Stat(dotGit)
+	if err != nil {
+		return "", false
+	}
+	if info.IsDir() {
+		return dotGit, true
+	}
+	if !info.Mode().IsRegular() {
+		return "", false
+	}
+
+	data, err := os.ReadFile(dotGit)
+	if err != nil {
+		return "", false
+	}
+	line, _, _ := strings.Cut(string(data), "\n")
+	target, ok := strings.CutPrefix(strings.TrimSpace(line), gitdirPrefix)
+	if !ok {
+		return "", false
+	}
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return "", false
+	}
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(path, target)
+	}
+	target = filepath.Clean(target)
+
+	// A dangling gitdir file is not a repository
+	if info, err := os.Stat(target); err != nil || !info.IsDir() {
+		return "", false
+	}
+	return target, true
+}
+
+// WorktreeOf returns the main working tree of a linked worktree whose git
+// directory is gitDir, or "" for a regular repository. Linked worktrees have
+// their git directory under <main>/.git/worktrees/<name>, with a commondir
+// file pointing back at the shared git directory.
+func WorktreeOf(gitDir string) string {
+	data, err := os.ReadFile(filepath.Join(gitDir, "commondir"))
+	if err != nil {
+		return ""
+	}
+	commonDir := strings.TrimSpace(string(data))
+	if commonDir == "" {
+		return ""
+	}
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(gitDir, commonDir)
+	}
+	commonDir = filepath.Clean(commonDir)
+
+	// The main worktree of a bare repository is the repository itself
+	if filepath.Base(commonDir) != ".git" {
+		return commonDir
+	}
+	return filepath.Dir(commonDir)
+}
+
+// HasSubmodules reports whether the working tree at path declares submodules.
+func HasSubmodules(path string) bool {
+	info, err := os.Stat(filepath.Join(path, ".gitmodules"))
+	return err == nil && info.Mode().IsRegular()
+}
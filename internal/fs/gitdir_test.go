@@ -0,0 +1,118 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// makeWorktree lays out a main repository at main and a linked worktree at wt,
+// as `git worktree add` does.
+func makeWorktree(t *testing.T, main, wt string) {
+	t.Helper()
+	wtGitDir := filepath.Join(main, ".git", "worktrees", filepath.Base(wt))
+	if err := os.MkdirAll(wtGitDir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(wtGitDir, "commondir"), []byte("../..\n"), 0644); err != nil {
+		t.Fatalf("write commondir: %v", err)
+	}
+	if err := os.MkdirAll(wt, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(wt, ".git"), []byte("gitdir: "+wtGitDir+"\n"), 0644); err != nil {
+		t.Fatalf("write .git: %v", err)
+	}
+}
+
+func TestGitDir(t *testing.T) {
+	tmp := t.TempDir()
+
+	repo := filepath.Join(tmp, "repo")
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if got, ok := GitDir(repo); !ok || got != filepath.Join(repo, ".git") {
+		t.Errorf("GitDir(repo) = %q, %v", got, ok)
+	}
+
+	// A relative gitdir, as written by --separate-git-dir and submodules
+	separate := filepath.Join(tmp, "separate")
+	if err := os.MkdirAll(filepath.Join(tmp, "store", "separate.git"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.MkdirAll(separate, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(separate, ".git"), []byte("gitdir: ../store/separate.git\n"), 0644); err != nil {
+		t.Fatalf("write .git: %v", err)
+	}
+	if got, ok := GitDir(separate); !ok || got != filepath.Join(tmp, "store", "separate.git") {
+		t.Errorf("GitDir(separate) = %q, %v", got, ok)
+	}
+
+	for name, content := range map[string]string{
+		"garbage":  "test",
+		"empty":    "gitdir:",
+		"dangling": "gitdir: " + filepath.Join(tmp, "missing"),
+	} {
+		dir := filepath.Join(tmp, name)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, ".git"), []byte(content), 0644); err != nil {
+			t.Fatalf("write .git: %v", err)
+		}
+		if _, ok := GitDir(dir); ok {
+			t.Errorf("GitDir(%s) should fail", name)
+		}
+	}
+
+	if _, ok := GitDir(filepath.Join(tmp, "nothing")); ok {
+		t.Error("GitDir should fail without .git")
+	}
+}
+
+func TestWorktreeOf(t *testing.T) {
+	tmp := t.TempDir()
+	main := filepath.Join(tmp, "main")
+	wt := filepath.Join(tmp, "main-feature")
+	makeWorktree(t, main, wt)
+
+	gitDir, ok := GitDir(wt)
+	if !ok {
+		t.Fatal("worktree should have a git directory")
+	}
+	if got := WorktreeOf(gitDir); got != main {
+		t.Errorf("WorktreeOf = %q, want %q", got, main)
+	}
+	if got := WorktreeOf(filepath.Join(main, ".git")); got != "" {
+		t.Errorf("main repository should not be a worktree, got %q", got)
+	}
+
+	// Worktrees of a bare repository point at the repository itself
+	bare := filepath.Join(tmp, "bare.git")
+	bareWtDir := filepath.Join(bare, "worktrees", "wt")
+	if err := os.MkdirAll(bareWtDir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(bareWtDir, "commondir"), []byte("../.."), 0644); err != nil {
+		t.Fatalf("write commondir: %v", err)
+	}
+	if got := WorktreeOf(bareWtDir); got != bare {
+		t.Errorf("WorktreeOf(bare) = %q, want %q", got, bare)
+	}
+}
+
+func TestHasSubmodules(t *testing.T) {
+	tmp := t.TempDir()
+	if HasSubmodules(tmp) {
+		t.Error("HasSubmodules should be false without .gitmodules")
+	}
+	if err := os.WriteFile(filepath.Join(tmp, ".gitmodules"), []byte("[submodule \"lib\"]\n"), 0644); err != nil {
+		t.Fatalf("write .gitmodules: %v", err)
+	}
+	if !HasSubmodules(tmp) {
+		t.Error("HasSubmodules should detect .gitmodules")
+	}
+}
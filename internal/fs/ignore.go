@@ -0,0 +1,164 @@
+package fs
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// IgnoreFileName is the name of the optional per-root exclude file.
+// It uses the same pattern syntax as the exclude settings in the config.
+const IgnoreFileName = ".ghqxignore"
+
+// Exclusion records a directory skipped by an exclude pattern.
+type Exclusion struct {
+	// Path is the absolute path of the skipped directory
+	Path string `json:"path"`
+	// Pattern is the pattern that matched, as written by the user
+	Pattern string `json:"pattern"`
+}
+
+// excludePattern is a single parsed gitignore-style pattern.
+type excludePattern struct {
+	// source is the pattern as written, for reporting
+	source string
+	// negate re-includes directories matched by earlier patterns ("!pattern")
+	negate bool
+	// segments are the slash-separated parts matched against the relative path
+	segments []string
+}
+
+// Excludes matches directory paths relative to a root against gitignore-style patterns:
+//   - blank lines and lines starting with # are ignored
+//   - a pattern without a slash matches a directory name at any depth ("node_modules")
+//   - a pattern with a slash is anchored to the root ("/scratch", "github.com/tmp")
+//   - * and ? match within one path segment, ** matches any number of segments
+//   - a trailing slash is allowed ("cache/"); only directories are matched anyway
+//   - ! re-includes directories excluded by an earlier pattern; the last match wins
+type Excludes struct {
+	patterns []excludePattern
+}
+
+// NewExcludes parses gitignore-style patterns.
+func NewExcludes(patterns []string) *Excludes {
+	e := &Excludes{}
+	for _, line := range patterns {
+		if p, ok := parseExcludePattern(line); ok {
+			e.patterns = append(e.patterns, p)
+		}
+	}
+	return e
+}
+
+// ValidateExcludePattern reports whether pattern has valid glob syntax.
+func ValidateExcludePattern(pattern string) bool {
+	p, ok := parseExcludePattern(pattern)
+	if !ok {
+		return true // Blank lines and comments are allowed
+	}
+	for _, seg := range p.segments {
+		if _, err := path.Match(seg, ""); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// parseExcludePattern parses one pattern line.
+// Returns false for blank lines and comments.
+func parseExcludePattern(line string) (excludePattern, bool) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return excludePattern{}, false
+	}
+
+	p := excludePattern{source: line}
+	if strings.HasPrefix(line, "!") {
+		p.negate = true
+		line = line[1:]
+	}
+
+	line = strings.TrimSuffix(line, "/")
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+	if line == "" {
+		return excludePattern{}, false
+	}
+
+	p.segments = strings.Split(line, "/")
+	if !anchored {
+		// A bare name matches at any depth
+		p.segments = append([]string{"**"}, p.segments...)
+	}
+	return p, true
+}
+
+// Empty reports whether there are no patterns.
+func (e *Excludes) Empty() bool {
+	return e == nil || len(e.patterns) == 0
+}
+
+// Match reports whether the directory at rel (slash-separated, relative to the root)
+// is excluded, and returns the pattern that decided it.
+func (e *Excludes) Match(rel string) (bool, string) {
+	if e.Empty() {
+		return false, ""
+	}
+
+	segments := strings.Split(rel, "/")
+	excluded, decidedBy := false, ""
+	for _, p := range e.patterns {
+		if matchSegments(p.segments, segments) {
+			excluded, decidedBy = !p.negate, p.source
+		}
+	}
+	if !excluded {
+		return false, ""
+	}
+	return true, decidedBy
+}
+
+// matchSegments matches path segments against pattern segments, where "**"
+// matches zero or more whole segments.
+func matchSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(segments); i++ {
+				if matchSegments(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
+
+// ReadIgnoreFile returns the patterns in the .ghqxignore file of a root.
+// A missing file yields no patterns.
+func ReadIgnoreFile(rootPath string) ([]string, error) {
+	f, err := os.Open(filepath.Join(rootPath, IgnoreFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var patterns []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		patterns = append(patterns, sc.Text())
+	}
+	return patterns, sc.Err()
+}
@@ -0,0 +1,115 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExcludesMatch(t *testing.T) {
+	e := NewExcludes([]string{
+		"# comment",
+		"",
+		"tmp",
+		"/scratch/",
+		"github.com/*/archive-*",
+		"**/cache/**",
+		"!github.com/me/tmp",
+	})
+
+	testCases := []struct {
+		path    string
+		want    bool
+		pattern string
+	}{
+		{"tmp", true, "tmp"},
+		{"github.com/user/tmp", true, "tmp"},
+		{"github.com/me/tmp", false, ""},
+		{"scratch", true, "/scratch/"},
+		{"github.com/scratch", false, ""},
+		{"github.com/user/archive-2020", true, "github.com/*/archive-*"},
+		{"github.com/user/sub/archive-2020", false, ""},
+		{"host/cache/x", true, "**/cache/**"},
+		{"github.com/user/repo", false, ""},
+	}
+
+	for _, tc := range testCases {
+		got, pattern := e.Match(tc.path)
+		if got != tc.want || pattern != tc.pattern {
+			t.Errorf("Match(%q) = %v, %q; want %v, %q", tc.path, got, pattern, tc.want, tc.pattern)
+		}
+	}
+
+	if !NewExcludes(nil).Empty() || !NewExcludes([]string{"# only a comment"}).Empty() {
+		t.Error("Empty should report true without patterns")
+	}
+}
+
+func TestValidateExcludePattern(t *testing.T) {
+	for _, ok := range []string{"tmp", "**/cache", "a/[bc]/d", "# [comment"} {
+		if !ValidateExcludePattern(ok) {
+			t.Errorf("ValidateExcludePattern(%q) = false", ok)
+		}
+	}
+	if ValidateExcludePattern("a/[b") {
+		t.Error("expected unbalanced bracket to be invalid")
+	}
+}
+
+func TestScanHonorsExcludesAndIgnoreFile(t *testing.T) {
+	tmp := t.TempDir()
+	for _, dir := range []string{
+		"github.com/user/repo/.git",
+		"github.com/user/scratch-1",
+		"mnt/volume/data",
+		"github.com/other/tool",
+	} {
+		if err := os.MkdirAll(filepath.Join(tmp, filepath.FromSlash(dir)), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(tmp, IgnoreFileName), []byte("# mounted volumes\n/mnt\n"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	s := NewScanner()
+	s.SetExcludes([]string{"scratch-*"}, map[string][]string{"dev": {"github.com/other"}, "release": {"github.com/user"}})
+
+	result, err := s.Scan("dev", tmp)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if len(result.Projects) != 1 || result.Projects[0].Name != "github.com/user/repo" {
+		t.Errorf("unexpected projects: %+v", result.Projects)
+	}
+
+	want := map[string]string{
+		filepath.Join(tmp, "github.com", "user", "scratch-1"): "scratch-*",
+		filepath.Join(tmp, "github.com", "other"):             "github.com/other",
+		filepath.Join(tmp, "mnt"):                             "/mnt",
+	}
+	if len(result.Excluded) != len(want) {
+		t.Fatalf("unexpected exclusions: %+v", result.Excluded)
+	}
+	for _, e := range result.Excluded {
+		if want[e.Path] != e.Pattern {
+			t.Errorf("unexpected exclusion %s (%s)", e.Path, e.Pattern)
+		}
+	}
+
+	// Editing the ignore file changes the signature, even without a directory change
+	before := s.Signature("dev", tmp)
+	if err := os.WriteFile(filepath.Join(tmp, IgnoreFileName), []byte("/mnt\ntmp\n"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if s.Signature("dev", tmp) == before {
+		t.Error("signature should change with the ignore file")
+	}
+}
+
+func TestReadIgnoreFileMissing(t *testing.T) {
+	patterns, err := ReadIgnoreFile(t.TempDir())
+	if err != nil || patterns != nil {
+		t.Errorf("ReadIgnoreFile on missing file = %v, %v", patterns, err)
+	}
+}
@@ -1,26 +1,152 @@
 package fs
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/mi8bi/ghqx/internal/domain"
 )
 
+// DefaultMaxDepth is the deepest directory level below a root that is checked for projects.
+// ghq lays repositories out as host/owner/repo (depth 3); the extra levels
+// cover nested groups such as gitlab.com/group/subgroup/repo.
+// Roots whose layout is deeper are always searched down to their project depth.
+const DefaultMaxDepth = 5
+
+// skippedDirs are dependency and build output directories that never contain
+// repositories worth listing. They are only skipped inside a project directory
+// (host/owner/repo in the ghq layout), so an owner that happens to be called
+// "vendor" is still scanned.
+var skippedDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"target":       true,
+}
+
 // Scanner handles filesystem operations for project discovery.
 // It traverses directory trees to find git repositories and structured directories.
-type Scanner struct{}
+type Scanner struct {
+	// maxDepth is the deepest directory level checked for projects
+	maxDepth int
+	// parallel limits how many host subtrees are walked at once
+	parallel int
+	// excludes are exclude patterns applied to every root
+	excludes []string
+	// rootExcludes are exclude patterns applied to a single root
+	rootExcludes map[string][]string
+	// layouts are the layouts of roots not using the ghq layout
+	layouts map[string]domain.Layout
+	// workspaceTypes are the workspace types of roots, keyed by root name
+	workspaceTypes map[string]domain.WorkspaceType
+}
 
 // NewScanner creates a new filesystem scanner instance.
 func NewScanner() *Scanner {
-	return &Scanner{}
+	return &Scanner{
+		maxDepth: DefaultMaxDepth,
+		parallel: runtime.GOMAXPROCS(0),
+	}
+}
+
+// SetMaxDepth sets the deepest directory level checked for projects.
+// Values below 1 restore DefaultMaxDepth.
+func (s *Scanner) SetMaxDepth(depth int) {
+	if depth < 1 {
+		depth = DefaultMaxDepth
+	}
+	s.maxDepth = depth
+}
+
+// MaxDepth returns the deepest directory level checked for projects.
+func (s *Scanner) MaxDepth() int {
+	return s.maxDepth
+}
+
+// SetExcludes sets the exclude patterns applied to every root and those applied
+// to single roots (keyed by root name). Patterns in a root's .ghqxignore file
+// are added on every scan. See Excludes for the pattern syntax.
+func (s *Scanner) SetExcludes(global []string, perRoot map[string][]string) {
+	s.excludes = global
+	s.rootExcludes = perRoot
+}
+
+// SetLayouts sets the layout of each root, keyed by root name.
+// Roots without an entry use the ghq layout.
+func (s *Scanner) SetLayouts(layouts map[string]domain.Layout) {
+	s.layouts = layouts
+}
+
+// layout returns the layout of a root.
+func (s *Scanner) layout(rootName domain.RootName) domain.Layout {
+	if layout, ok := s.layouts[string(rootName)]; ok {
+		return layout
+	}
+	return domain.LayoutGhq
+}
+
+// SetWorkspaceTypes sets the workspace type of each root, keyed by root name.
+// Roots without an entry get the type matching their name (see domain.DetermineWorkspaceType).
+func (s *Scanner) SetWorkspaceTypes(types map[string]domain.WorkspaceType) {
+	s.workspaceTypes = types
+}
+
+// workspaceType returns the workspace type of a root.
+func (s *Scanner) workspaceType(rootName domain.RootName) domain.WorkspaceType {
+	if t, ok := s.workspaceTypes[string(rootName)]; ok {
+		return t
+	}
+	return domain.DetermineWorkspaceType(rootName)
+}
+
+// excludePatterns returns every exclude pattern that applies to a root:
+// global patterns, then the root's own, then its .ghqxignore file.
+func (s *Scanner) excludePatterns(rootName domain.RootName, rootPath string) []string {
+	patterns := append([]string{}, s.excludes...)
+	patterns = append(patterns, s.rootExcludes[string(rootName)]...)
+	// An unreadable ignore file is treated as empty, like an unreadable directory
+	filePatterns, _ := ReadIgnoreFile(rootPath)
+	return append(patterns, filePatterns...)
+}
+
+// Signature describes the settings that affect the scan results of a root,
+// so cached results can be discarded when the settings change.
+func (s *Scanner) Signature(rootName domain.RootName, rootPath string) string {
+	sum := sha256.Sum256([]byte(strings.Join(s.excludePatterns(rootName, rootPath), "\n")))
+	return fmt.Sprintf("depth=%d;layout=%d;type=%s;excludes=%s",
+		s.maxDepth, s.layout(rootName), s.workspaceType(rootName), hex.EncodeToString(sum[:8]))
+}
+
+// ScanResult is the detailed outcome of scanning one root.
+type ScanResult struct {
+	// Projects are the discovered projects, sorted by name
+	Projects []domain.Project
+	// Dirs lists every visited directory. The projects stay valid as long as
+	// none of these directories change, which is what the project index relies on.
+	Dirs []string
+	// Excluded lists the directories skipped by exclude patterns
+	Excluded []Exclusion
 }
 
 // ScanRoot scans a root directory and returns all discovered projects.
 // Validates the root path exists and recursively searches for projects.
 func (s *Scanner) ScanRoot(rootName domain.RootName, rootPath string) ([]domain.Project, error) {
+	result, err := s.Scan(rootName, rootPath)
+	if err != nil {
+		return nil, err
+	}
+	return result.Projects, nil
+}
+
+// Scan scans a root directory like ScanRoot and also reports the visited
+// and excluded directories.
+func (s *Scanner) Scan(rootName domain.RootName, rootPath string) (*ScanResult, error) {
 	if _, err := os.Stat(rootPath); os.IsNotExist(err) {
 		return nil, domain.ErrRootDirNotExist(string(rootName), rootPath)
 	}
@@ -28,128 +154,192 @@ func (s *Scanner) ScanRoot(rootName domain.RootName, rootPath string) ([]domain.
 	return s.scanGhqRoot(rootName, rootPath)
 }
 
-// scanGhqRoot recursively scans a directory for projects (git repos or structured directories).
-// Returns a filtered list of actual projects, not intermediate directories.
-func (s *Scanner) scanGhqRoot(rootName domain.RootName, rootPath string) ([]domain.Project, error) {
-	var potentialProjects []domain.Project
-
-	// Walk directory tree to find projects
-	err := filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return nil // Skip directories we can't access (permission denied, etc.)
-		}
-
-		if !info.IsDir() {
-			return nil // Only interested in directories
-		}
+// rootWalk holds the state shared by all walks of one root.
+type rootWalk struct {
+	rootName domain.RootName
+	rootPath string
+	excludes *Excludes
+	// layout is the depth at which plain directories count as projects
+	layout domain.Layout
+	// maxDepth is the deepest level checked, never shallower than the layout
+	maxDepth int
+	// workspaceType is the workspace type given to every project of the root
+	workspaceType domain.WorkspaceType
+}
 
-		// Don't treat the root itself as a project
-		if path == rootPath {
-			return nil
-		}
+// walkResult is the outcome of walking one host subtree.
+type walkResult struct {
+	projects []domain.Project
+	dirs     []string
+	excluded []Exclusion
+}
 
-		hasGit := s.hasGitDir(path)
+// scanGhqRoot scans a root for projects (repositories, or plain directories at the
+// depth of the root's layout, host/owner/repo by default).
+// Each top-level (host) directory is walked in its own goroutine, and the walk
+// stops descending as soon as a repository is found or a directory is excluded.
+func (s *Scanner) scanGhqRoot(rootName domain.RootName, rootPath string) (*ScanResult, error) {
+	entries, err := os.ReadDir(rootPath)
+	if err != nil {
+		return nil, domain.ErrFSScanRoot(err)
+	}
 
-		// Compute project name relative to root
-		relPath, _ := filepath.Rel(rootPath, path)
-		projectName := filepath.ToSlash(relPath)
-		if projectName == "." || projectName == "" {
-			projectName = filepath.Base(path)
-		}
+	w := &rootWalk{
+		rootName:      rootName,
+		rootPath:      rootPath,
+		excludes:      NewExcludes(s.excludePatterns(rootName, rootPath)),
+		layout:        s.layout(rootName),
+		workspaceType: s.workspaceType(rootName),
+	}
+	w.maxDepth = max(s.maxDepth, int(w.layout))
+	hosts := subdirNames(entries)
+	results := make([]walkResult, len(hosts))
 
-		// Determine project type based on root name and git status
-		projectType := domain.ProjectTypeDir // Default for non-git directories
-		if hasGit {
-			switch rootName {
-			case "sandbox":
-				projectType = domain.ProjectTypeSandboxGit
-			case "release":
-				projectType = domain.ProjectTypeRelease
-			case "dev":
-				projectType = domain.ProjectTypeDev
-			default:
-				projectType = domain.ProjectTypeDir // Fallback
+	// Walk host subtrees concurrently; results are indexed so merging needs no lock
+	sem := make(chan struct{}, max(s.parallel, 1))
+	var wg sync.WaitGroup
+	for i, host := range hosts {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, path string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if !w.exclude(path, &results[i]) {
+				results[i].projects = s.walk(w, path, 1, &results[i])
 			}
-		}
+		}(i, filepath.Join(rootPath, host))
+	}
+	wg.Wait()
 
-		potentialProjects = append(potentialProjects, domain.Project{
-			Name:          projectName,
-			DisplayName:   domain.FormatDisplayName(projectName),
-			Root:          rootName,
-			Path:          path,
-			WorkspaceType: domain.DetermineWorkspaceType(rootName),
-			Type:          projectType,
-			HasGit:        hasGit,
-		})
-
-		// If it's a Git repository, skip descending into it to avoid nested checks
-		if hasGit {
-			return filepath.SkipDir
-		}
-		return nil
+	result := &ScanResult{Dirs: []string{rootPath}}
+	for _, r := range results {
+		result.Projects = append(result.Projects, r.projects...)
+		result.Dirs = append(result.Dirs, r.dirs...)
+		result.Excluded = append(result.Excluded, r.excluded...)
+	}
+
+	// Sort final results by name for consistent output
+	sort.Slice(result.Projects, func(i, j int) bool {
+		return result.Projects[i].Name < result.Projects[j].Name
 	})
 
-	if err != nil {
-		return nil, domain.ErrFSScanRoot(err)
+	return result, nil
+}
+
+// exclude reports whether the directory at path matches an exclude pattern,
+// recording it in r when it does.
+func (w *rootWalk) exclude(path string, r *walkResult) bool {
+	if w.excludes.Empty() {
+		return false
+	}
+	rel, _ := filepath.Rel(w.rootPath, path)
+	excluded, pattern := w.excludes.Match(filepath.ToSlash(rel))
+	if excluded {
+		r.excluded = append(r.excluded, Exclusion{Path: path, Pattern: pattern})
 	}
+	return excluded
+}
 
-	// Post-process: filter out intermediate directories that aren't actual projects
-	var actualProjects []domain.Project
+// walk returns the projects in the directory at path, which is depth levels below the root.
+// A repository ends the walk. A plain directory at the layout's depth (host/owner/repo
+// by default) is a project unless a repository is found below it.
+// Visited and excluded directories are recorded in r.
+func (s *Scanner) walk(w *rootWalk, path string, depth int, r *walkResult) []domain.Project {
+	r.dirs = append(r.dirs, path)
 
-	// Sort by path length descending to identify leaf projects easily
-	sort.Slice(potentialProjects, func(i, j int) bool {
-		return len(potentialProjects[i].Path) > len(potentialProjects[j].Path)
-	})
+	// The deepest level is only checked, never read
+	if depth >= w.maxDepth {
+		if vcs := DetectVCS(path); vcs != domain.VCSNone {
+			return []domain.Project{w.newProject(path, vcs)}
+		}
+		if depth == int(w.layout) {
+			return []domain.Project{w.newProject(path, domain.VCSNone)}
+		}
+		return nil
+	}
 
-	// Use a map to track paths that are already part of a valid project
-	isSubPathOfProject := make(map[string]bool)
+	// Unreadable directories are treated as empty (permission denied, etc.)
+	entries, _ := os.ReadDir(path)
+	if vcs := detectVCSEntries(path, entries); vcs != domain.VCSNone {
+		// Don't descend into repositories to avoid nested checks
+		return []domain.Project{w.newProject(path, vcs)}
+	}
 
-	for _, p := range potentialProjects {
-		// Skip if this path is a subpath of an already discovered project
-		if isSubPathOfProject[p.Path] {
+	var projects []domain.Project
+	for _, name := range subdirNames(entries) {
+		if depth >= int(w.layout) && skippedDirs[name] {
 			continue
 		}
-
-		if p.HasGit {
-			actualProjects = append(actualProjects, p)
-			// Mark all parent directories as part of this project
-			markAncestors(rootPath, p.Path, isSubPathOfProject)
-		} else {
-			// For non-git directories, only include if they form a complete host/user/repo path
-			// e.g., github.com/user/repo has 3 components
-			relPathSegments := strings.Split(p.Name, "/")
-			if len(relPathSegments) == 3 {
-				actualProjects = append(actualProjects, p)
-				markAncestors(rootPath, p.Path, isSubPathOfProject)
-			}
+		child := filepath.Join(path, name)
+		if w.exclude(child, r) {
+			continue
 		}
+		projects = append(projects, s.walk(w, child, depth+1, r)...)
 	}
 
-	// Sort final results by name for consistent output
-	sort.Slice(actualProjects, func(i, j int) bool {
-		return actualProjects[i].Name < actualProjects[j].Name
-	})
+	// A complete project directory without repositories below it is a project
+	if len(projects) == 0 && depth == int(w.layout) {
+		return []domain.Project{w.newProject(path, domain.VCSNone)}
+	}
+	return projects
+}
 
-	return actualProjects, nil
+// subdirNames returns the names of the directories among entries, except .git.
+// Symbolic links are not followed.
+func subdirNames(entries []os.DirEntry) []string {
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() && e.Name() != ".git" {
+			names = append(names, e.Name())
+		}
+	}
+	return names
 }
 
-// markAncestors marks all parent directories of a project as being part of a project hierarchy.
-func markAncestors(rootPath, projectPath string, isSubPathOfProject map[string]bool) {
-	currentPath := projectPath
-	for currentPath != rootPath && currentPath != filepath.Dir(rootPath) {
-		isSubPathOfProject[currentPath] = true
-		currentPath = filepath.Dir(currentPath)
+// newProject creates the project found at path, managed by vcs.
+// Git working trees are linked to their main repository when they are
+// linked worktrees, and marked when they declare submodules.
+func (w *rootWalk) newProject(path string, vcs domain.VCSKind) domain.Project {
+	rootName, rootPath := w.rootName, w.rootPath
+
+	// Compute project name relative to root
+	relPath, _ := filepath.Rel(rootPath, path)
+	projectName := filepath.ToSlash(relPath)
+	if projectName == "." || projectName == "" {
+		projectName = filepath.Base(path)
+	}
+
+	// Determine project type based on the root's workspace type and version control
+	projectType := domain.ProjectTypeDir // Default for plain directories
+	if vcs != domain.VCSNone {
+		projectType = w.workspaceType.RepositoryType()
+	}
+
+	p := domain.Project{
+		Name:          projectName,
+		DisplayName:   domain.FormatDisplayName(projectName, w.layout),
+		Root:          rootName,
+		Path:          path,
+		WorkspaceType: w.workspaceType,
+		Type:          projectType,
+		VCS:           vcs,
+		HasGit:        vcs == domain.VCSGit,
+	}
+	if gitDir, ok := GitDir(path); p.HasGit && ok {
+		p.WorktreeOf = WorktreeOf(gitDir)
+		p.HasSubmodules = HasSubmodules(path)
 	}
+	return p
 }
 
-// hasGitDir checks if a directory contains a .git subdirectory.
+// hasGitDir checks if a directory is a git working tree, with either a .git
+// directory or a valid gitdir file.
 func (s *Scanner) hasGitDir(path string) bool {
-	gitPath := filepath.Join(path, ".git")
-	info, err := os.Stat(gitPath)
-	return err == nil && info.IsDir()
+	_, ok := GitDir(path)
+	return ok
 }
 
-// HasGitDir checks if a directory contains a .git subdirectory (public version).
+// HasGitDir checks if a directory is a git working tree (public version).
 func (s Scanner) HasGitDir(path string) bool {
 	return s.hasGitDir(path)
 }
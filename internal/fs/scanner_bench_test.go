@@ -0,0 +1,120 @@
+package fs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// makeBenchTree creates a synthetic root of about 10,000 directories:
+// 4 hosts x 25 owners x 20 repositories, half of them git repositories
+// (with .git/objects and .git/refs) and half plain directories holding
+// node_modules packages, like checked-out JavaScript projects.
+func makeBenchTree(b *testing.B) string {
+	b.Helper()
+	root := b.TempDir()
+	for h := 0; h < 4; h++ {
+		for o := 0; o < 25; o++ {
+			for r := 0; r < 20; r++ {
+				repo := filepath.Join(root, fmt.Sprintf("host%d.com", h), fmt.Sprintf("owner%d", o), fmt.Sprintf("repo%d", r))
+				var dirs []string
+				if r%2 == 0 {
+					dirs = []string{".git/objects", ".git/refs", "src"}
+				} else {
+					dirs = []string{"node_modules/a/lib", "node_modules/b/lib"}
+				}
+				for _, d := range dirs {
+					if err := os.MkdirAll(filepath.Join(repo, filepath.FromSlash(d)), 0755); err != nil {
+						b.Fatalf("mkdir: %v", err)
+					}
+				}
+			}
+		}
+	}
+	return root
+}
+
+// BenchmarkScanRoot measures the ReadDir based scanner with parallel host walks.
+func BenchmarkScanRoot(b *testing.B) {
+	root := makeBenchTree(b)
+	s := NewScanner()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		projects, err := s.ScanRoot("dev", root)
+		if err != nil || len(projects) != 2000 {
+			b.Fatalf("ScanRoot: %d projects, %v", len(projects), err)
+		}
+	}
+}
+
+// BenchmarkScanRootSequential measures the scanner without parallel host walks.
+func BenchmarkScanRootSequential(b *testing.B) {
+	root := makeBenchTree(b)
+	s := NewScanner()
+	s.parallel = 1
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.ScanRoot("dev", root); err != nil {
+			b.Fatalf("ScanRoot: %v", err)
+		}
+	}
+}
+
+// BenchmarkScanRootLegacyWalk measures the previous filepath.Walk based scan
+// on the same tree, as a baseline for the benchmarks above.
+func BenchmarkScanRootLegacyWalk(b *testing.B) {
+	root := makeBenchTree(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if projects := legacyWalkScan(root); len(projects) != 2000 {
+			b.Fatalf("legacy scan: %d projects", len(projects))
+		}
+	}
+}
+
+// legacyWalkScan is the scan algorithm the scanner used before ReadDir:
+// an lstat per entry, descent into every non-git directory, then a
+// path-length sort and an ancestor map to drop intermediate directories.
+func legacyWalkScan(rootPath string) []string {
+	type candidate struct {
+		name   string
+		path   string
+		hasGit bool
+	}
+	var candidates []candidate
+	_ = filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.IsDir() || path == rootPath {
+			return nil
+		}
+		gitInfo, statErr := os.Stat(filepath.Join(path, ".git"))
+		hasGit := statErr == nil && gitInfo.IsDir()
+		rel, _ := filepath.Rel(rootPath, path)
+		candidates = append(candidates, candidate{filepath.ToSlash(rel), path, hasGit})
+		if hasGit {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return len(candidates[i].path) > len(candidates[j].path)
+	})
+	covered := make(map[string]bool)
+	var projects []string
+	for _, c := range candidates {
+		if covered[c.path] {
+			continue
+		}
+		if c.hasGit || len(strings.Split(c.name, "/")) == 3 {
+			projects = append(projects, c.name)
+			for p := c.path; p != rootPath && p != filepath.Dir(rootPath); p = filepath.Dir(p) {
+				covered[p] = true
+			}
+		}
+	}
+	sort.Strings(projects)
+	return projects
+}
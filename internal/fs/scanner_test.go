@@ -3,6 +3,7 @@ package fs
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/mi8bi/ghqx/internal/domain"
@@ -234,7 +235,7 @@ func TestHasGitDirWithFile(t *testing.T) {
 	}
 	defer os.RemoveAll(tmp)
 
-	// Create .git as a file (not directory)
+	// Create .git as a file that is not a valid gitdir file
 	gitFile := filepath.Join(tmp, ".git")
 	if err := os.WriteFile(gitFile, []byte("test"), 0644); err != nil {
 		t.Fatalf("write .git file: %v", err)
@@ -242,7 +243,15 @@ func TestHasGitDirWithFile(t *testing.T) {
 
 	s := NewScanner()
 	if s.HasGitDir(tmp) {
-		t.Error("HasGitDir should return false when .git is a file")
+		t.Error("HasGitDir should return false when .git is not a gitdir file")
+	}
+
+	// A gitdir file pointing at an existing directory is a repository
+	if err := os.WriteFile(gitFile, []byte("gitdir: "+tmp), 0644); err != nil {
+		t.Fatalf("write .git file: %v", err)
+	}
+	if !s.HasGitDir(tmp) {
+		t.Error("HasGitDir should accept a gitdir file")
 	}
 }
 
@@ -364,3 +373,206 @@ func TestScanRootWithNonGitDirectory(t *testing.T) {
 		t.Errorf("expected ProjectTypeDir, got %v", projects[0].Type)
 	}
 }
+
+func TestScanReportsVisitedDirectories(t *testing.T) {
+	tmp := t.TempDir()
+	repo := filepath.Join(tmp, "github.com", "user", "repo")
+	if err := os.MkdirAll(filepath.Join(repo, ".git", "objects"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	result, err := NewScanner().Scan("dev", tmp)
+	if err != nil || len(result.Projects) != 1 {
+		t.Fatalf("Scan: %v, %v", result, err)
+	}
+
+	visited := make(map[string]bool)
+	for _, d := range result.Dirs {
+		visited[d] = true
+	}
+	for _, want := range []string{tmp, filepath.Join(tmp, "github.com"), filepath.Dir(repo), repo} {
+		if !visited[want] {
+			t.Errorf("missing visited directory %s", want)
+		}
+	}
+	if visited[filepath.Join(repo, ".git")] {
+		t.Error("directories inside a repository should not be visited")
+	}
+}
+
+func TestScanRootDepthAndPruning(t *testing.T) {
+	tmp := t.TempDir()
+	for _, dir := range []string{
+		"gitlab.com/group/sub/repo/.git",            // Nested group (depth 4)
+		"github.com/user/app/node_modules/dep/.git", // Dependency inside a project
+		"github.com/vendor/tool/.git",               // Owner named like a skipped directory
+		"example.com/a/b/c/d/e/.git",                // Deeper than the default max depth
+		"github.com/user/plain/sub",                 // Plain project with a subdirectory
+	} {
+		if err := os.MkdirAll(filepath.Join(tmp, filepath.FromSlash(dir)), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+
+	s := NewScanner()
+	projects, err := s.ScanRoot("dev", tmp)
+	if err != nil {
+		t.Fatalf("ScanRoot failed: %v", err)
+	}
+
+	var names []string
+	for _, p := range projects {
+		names = append(names, p.Name)
+	}
+	want := []string{
+		"example.com/a/b",
+		"github.com/user/app",
+		"github.com/user/plain",
+		"github.com/vendor/tool",
+		"gitlab.com/group/sub/repo",
+	}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Errorf("projects = %v, want %v", names, want)
+	}
+
+	// A shallower limit hides the nested group
+	s.SetMaxDepth(3)
+	projects, _ = s.ScanRoot("dev", tmp)
+	for _, p := range projects {
+		if p.Name == "gitlab.com/group/sub/repo" {
+			t.Error("repository below max depth should not be found")
+		}
+	}
+	if s.Signature("dev", tmp) == NewScanner().Signature("dev", tmp) {
+		t.Error("signature should change with max depth")
+	}
+
+	s.SetMaxDepth(0)
+	if s.MaxDepth() != DefaultMaxDepth {
+		t.Errorf("SetMaxDepth(0) should restore the default, got %d", s.MaxDepth())
+	}
+}
+
+func TestScanRootDetectsWorktreesAndSubmodules(t *testing.T) {
+	tmp := t.TempDir()
+	main := filepath.Join(tmp, "github.com", "user", "repo")
+	wt := filepath.Join(tmp, "github.com", "user", "repo-feature")
+	makeWorktree(t, main, wt)
+	if err := os.WriteFile(filepath.Join(main, ".gitmodules"), []byte("[submodule \"lib\"]\n"), 0644); err != nil {
+		t.Fatalf("write .gitmodules: %v", err)
+	}
+
+	for _, depth := range []int{DefaultMaxDepth, int(domain.LayoutGhq)} {
+		s := NewScanner()
+		s.SetMaxDepth(depth)
+		projects, err := s.ScanRoot("dev", tmp)
+		if err != nil {
+			t.Fatalf("ScanRoot failed: %v", err)
+		}
+		if len(projects) != 2 {
+			t.Fatalf("depth %d: expected 2 projects, got %d", depth, len(projects))
+		}
+
+		repo, feature := projects[0], projects[1]
+		if !repo.HasGit || repo.WorktreeOf != "" || !repo.HasSubmodules {
+			t.Errorf("depth %d: main repository = %+v", depth, repo)
+		}
+		if !feature.HasGit || feature.WorktreeOf != main || feature.HasSubmodules {
+			t.Errorf("depth %d: worktree = %+v", depth, feature)
+		}
+		if feature.Type != domain.ProjectTypeDev {
+			t.Errorf("depth %d: worktree type = %v", depth, feature.Type)
+		}
+	}
+}
+
+func TestScanRootWithLayouts(t *testing.T) {
+	tmp := t.TempDir()
+	for _, dir := range []string{
+		"flat/tool/.git",         // Repository directly in the root
+		"flat/notes/sub",         // Plain project with a subdirectory
+		"owner/user/app/.git",    // owner/repo repository
+		"owner/user/plain",       // owner/repo plain project
+		"deep/a/b/c/d",           // Plain project at a custom depth of 4
+		"deep/x/y/z/w/deeper/v/", // Deeper than the layout and the max depth
+	} {
+		if err := os.MkdirAll(filepath.Join(tmp, filepath.FromSlash(dir)), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+
+	s := NewScanner()
+	s.SetMaxDepth(3)
+	s.SetLayouts(map[string]domain.Layout{
+		"flat":  domain.LayoutFlat,
+		"owner": domain.LayoutOwnerRepo,
+		"deep":  domain.Layout(4),
+	})
+
+	testCases := map[string][]string{
+		"flat":  {"notes", "tool"},
+		"owner": {"user/app", "user/plain"},
+		"deep":  {"a/b/c/d", "x/y/z/w"},
+	}
+	for root, want := range testCases {
+		projects, err := s.ScanRoot(domain.RootName(root), filepath.Join(tmp, root))
+		if err != nil {
+			t.Fatalf("ScanRoot(%s) failed: %v", root, err)
+		}
+
+		var names []string
+		for _, p := range projects {
+			names = append(names, p.Name)
+			// Layouts shallower than ghq have no host to strip from the display name
+			if root != "deep" && p.DisplayName != p.Name {
+				t.Errorf("DisplayName = %q, want the full name %q", p.DisplayName, p.Name)
+			}
+		}
+		if strings.Join(names, ",") != strings.Join(want, ",") {
+			t.Errorf("%s: projects = %v, want %v", root, names, want)
+		}
+	}
+
+	if s.Signature("flat", tmp) == s.Signature("dev", tmp) {
+		t.Error("signature should change with the layout")
+	}
+}
+
+func TestScanRootWithConfiguredWorkspaceTypes(t *testing.T) {
+	tmp := t.TempDir()
+	for _, dir := range []string{"work/github.com/user/repo/.git", "old/github.com/user/repo/.git", "misc/github.com/user/repo/.git"} {
+		if err := os.MkdirAll(filepath.Join(tmp, filepath.FromSlash(dir)), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+
+	s := NewScanner()
+	before := s.Signature("work", tmp)
+	s.SetWorkspaceTypes(map[string]domain.WorkspaceType{"work": domain.WorkspaceTypeDev, "old": "archive"})
+	if s.Signature("work", tmp) == before {
+		t.Error("signature should change with the workspace type")
+	}
+
+	testCases := []struct {
+		rootName      domain.RootName
+		workspaceType domain.WorkspaceType
+		projectType   domain.ProjectType
+	}{
+		{"work", domain.WorkspaceTypeDev, domain.ProjectTypeDev},
+		{"old", "archive", "archive"},
+		{"misc", domain.WorkspaceTypeUnknown, domain.ProjectTypeDir}, // No type configured
+	}
+	for _, tc := range testCases {
+		projects, err := s.ScanRoot(tc.rootName, filepath.Join(tmp, string(tc.rootName)))
+		if err != nil {
+			t.Fatalf("ScanRoot failed: %v", err)
+		}
+		if len(projects) != 1 {
+			t.Fatalf("expected 1 project in %s, got %d", tc.rootName, len(projects))
+		}
+		if projects[0].WorkspaceType != tc.workspaceType || projects[0].Type != tc.projectType {
+			t.Errorf("%s: got workspace %q type %q, want %q %q",
+				tc.rootName, projects[0].WorkspaceType, projects[0].Type, tc.workspaceType, tc.projectType)
+		}
+	}
+}
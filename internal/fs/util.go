@@ -63,7 +63,13 @@ func CopyFile(src, dst string) error {
 	}
 	defer in.Close()
 
-	out, err := os.Create(dst)
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	// Preserve the source permissions so executables such as git hooks keep working
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
 	if err != nil {
 		return err
 	}
@@ -75,3 +81,47 @@ func CopyFile(src, dst string) error {
 	}
 	return out.Close()
 }
+
+// MoveDir moves a directory from src to dst.
+// It tries a cheap rename first and falls back to CopyDir followed by removal
+// of the source when the rename is not possible (e.g. across filesystems).
+// dst must not exist yet; its parent directories are created as needed.
+func MoveDir(src, dst string) error {
+	src = filepath.Clean(src)
+	dst = filepath.Clean(dst)
+
+	if _, err := os.Lstat(dst); err == nil {
+		return fmt.Errorf("destination %s already exists", dst)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return domain.ErrFSCreateDir(err)
+	}
+
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+
+	if err := CopyDir(src, dst); err != nil {
+		// Do not leave a half-copied tree behind
+		os.RemoveAll(dst)
+		return err
+	}
+
+	return os.RemoveAll(src)
+}
+
+// RemoveEmptyParents removes now-empty host/owner directories left behind
+// when a project is moved or deleted.
+// It stops at the root directory or at the first non-empty directory.
+func RemoveEmptyParents(dir, rootPath string) {
+	rootPath = filepath.Clean(rootPath)
+	for dir = filepath.Clean(dir); dir != rootPath && len(dir) > len(rootPath); dir = filepath.Dir(dir) {
+		// os.Remove fails on non-empty directories, which is exactly when we stop
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+	}
+}
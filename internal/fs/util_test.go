@@ -277,3 +277,68 @@ func TestCopyDirWithEmptyDirectory(t *testing.T) {
 		t.Error("dst should be a directory")
 	}
 }
+
+func TestMoveDir(t *testing.T) {
+	tmp := t.TempDir()
+
+	src := filepath.Join(tmp, "src")
+	if err := os.MkdirAll(filepath.Join(src, "sub"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "sub", "a.txt"), []byte("hello"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	dst := filepath.Join(tmp, "nested", "dst")
+	if err := MoveDir(src, dst); err != nil {
+		t.Fatalf("MoveDir failed: %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dst, "sub", "a.txt"))
+	if err != nil || string(got) != "hello" {
+		t.Fatalf("moved content mismatch: %q, %v", got, err)
+	}
+	if _, err := os.Stat(src); !os.IsNotExist(err) {
+		t.Fatalf("expected source to be gone")
+	}
+}
+
+func TestMoveDirRefusesExistingDst(t *testing.T) {
+	tmp := t.TempDir()
+
+	src := filepath.Join(tmp, "src")
+	dst := filepath.Join(tmp, "dst")
+	for _, p := range []string{src, dst} {
+		if err := os.MkdirAll(p, 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+
+	if err := MoveDir(src, dst); err == nil {
+		t.Fatal("expected error when destination exists")
+	}
+	if _, err := os.Stat(src); err != nil {
+		t.Fatalf("source must be untouched: %v", err)
+	}
+}
+
+func TestCopyFilePreservesMode(t *testing.T) {
+	tmp := t.TempDir()
+
+	src := filepath.Join(tmp, "hook")
+	if err := os.WriteFile(src, []byte("#!/bin/sh"), 0755); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	dst := filepath.Join(tmp, "hook_copy")
+	if err := CopyFile(src, dst); err != nil {
+		t.Fatalf("CopyFile failed: %v", err)
+	}
+
+	info, err := os.Stat(dst)
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	if info.Mode().Perm()&0100 == 0 {
+		t.Errorf("expected executable bit to be preserved, got %v", info.Mode())
+	}
+}
@@ -0,0 +1,78 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/mi8bi/ghqx/internal/domain"
+)
+
+// vcsDetector recognizes one kind of repository by an entry in its top directory.
+type vcsDetector struct {
+	kind domain.VCSKind
+	// marker is the entry whose presence makes the directory a candidate
+	marker string
+	// confirm checks the candidate at path
+	confirm func(path string) bool
+}
+
+// vcsDetectors are tried in order; the first confirmed detector wins.
+var vcsDetectors = []vcsDetector{
+	// Colocated Jujutsu workspaces also have a .git; jj is the tool in charge there
+	{domain.VCSJujutsu, ".jj", isDirIn(".jj")},
+	{domain.VCSGit, ".git", func(path string) bool {
+		_, ok := GitDir(path)
+		return ok
+	}},
+	{domain.VCSMercurial, ".hg", isDirIn(".hg")},
+	{domain.VCSSubversion, ".svn", isDirIn(".svn")},
+	{domain.VCSFossil, ".fslckout", isFileIn(".fslckout")},
+	{domain.VCSFossil, "_FOSSIL_", isFileIn("_FOSSIL_")},
+	{domain.VCSBareGit, "HEAD", isBareGit},
+}
+
+// DetectVCS returns the kind of repository at path, or domain.VCSNone for a plain directory.
+func DetectVCS(path string) domain.VCSKind {
+	for _, d := range vcsDetectors {
+		if d.confirm(path) {
+			return d.kind
+		}
+	}
+	return domain.VCSNone
+}
+
+// detectVCSEntries is DetectVCS for a directory whose entries were already read,
+// so only markers actually present are checked further.
+func detectVCSEntries(path string, entries []os.DirEntry) domain.VCSKind {
+	names := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		names[e.Name()] = true
+	}
+	for _, d := range vcsDetectors {
+		if names[d.marker] && d.confirm(path) {
+			return d.kind
+		}
+	}
+	return domain.VCSNone
+}
+
+// isDirIn returns a check for a subdirectory named name.
+func isDirIn(name string) func(path string) bool {
+	return func(path string) bool {
+		info, err := os.Stat(filepath.Join(path, name))
+		return err == nil && info.IsDir()
+	}
+}
+
+// isFileIn returns a check for a regular file named name.
+func isFileIn(name string) func(path string) bool {
+	return func(path string) bool {
+		info, err := os.Stat(filepath.Join(path, name))
+		return err == nil && info.Mode().IsRegular()
+	}
+}
+
+// isBareGit reports whether path is a bare git repository (HEAD, objects and refs at the top).
+func isBareGit(path string) bool {
+	return isFileIn("HEAD")(path) && isDirIn("objects")(path) && isDirIn("refs")(path)
+}
@@ -0,0 +1,111 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mi8bi/ghqx/internal/domain"
+)
+
+// makeTree creates entries under root: entries ending in "/" are directories, others empty files.
+func makeTree(t *testing.T, root string, entries ...string) {
+	t.Helper()
+	for _, e := range entries {
+		path := filepath.Join(root, filepath.FromSlash(e))
+		if e[len(e)-1] == '/' {
+			if err := os.MkdirAll(path, 0755); err != nil {
+				t.Fatalf("mkdir: %v", err)
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+}
+
+func TestDetectVCS(t *testing.T) {
+	testCases := []struct {
+		name    string
+		entries []string
+		want    domain.VCSKind
+	}{
+		{"plain", []string{"README.md"}, domain.VCSNone},
+		{"git", []string{".git/"}, domain.VCSGit},
+		{"hg", []string{".hg/"}, domain.VCSMercurial},
+		{"svn", []string{".svn/"}, domain.VCSSubversion},
+		{"fossil", []string{".fslckout"}, domain.VCSFossil},
+		{"fossil legacy", []string{"_FOSSIL_"}, domain.VCSFossil},
+		{"jj", []string{".jj/"}, domain.VCSJujutsu},
+		{"jj colocated", []string{".jj/", ".git/"}, domain.VCSJujutsu},
+		{"bare git", []string{"HEAD", "objects/", "refs/"}, domain.VCSBareGit},
+		{"HEAD file only", []string{"HEAD", "src/"}, domain.VCSNone},
+		{"hg file", []string{".hg"}, domain.VCSNone},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			makeTree(t, dir, tc.entries...)
+
+			if got := DetectVCS(dir); got != tc.want {
+				t.Errorf("DetectVCS = %q, want %q", got, tc.want)
+			}
+			entries, _ := os.ReadDir(dir)
+			if got := detectVCSEntries(dir, entries); got != tc.want {
+				t.Errorf("detectVCSEntries = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestScanRootDetectsOtherVCS(t *testing.T) {
+	tmp := t.TempDir()
+	makeTree(t, tmp,
+		"hg.example.com/user/hgrepo/.hg/",
+		"svn.example.com/user/svnrepo/.svn/",
+		"github.com/user/bare.git/HEAD",
+		"github.com/user/bare.git/objects/",
+		"github.com/user/bare.git/refs/",
+		"github.com/user/plain/",
+	)
+
+	for _, depth := range []int{DefaultMaxDepth, int(domain.LayoutGhq)} {
+		s := NewScanner()
+		s.SetMaxDepth(depth)
+		projects, err := s.ScanRoot("dev", tmp)
+		if err != nil {
+			t.Fatalf("ScanRoot failed: %v", err)
+		}
+
+		got := make(map[string]domain.Project)
+		for _, p := range projects {
+			got[p.Name] = p
+		}
+		for name, want := range map[string]domain.VCSKind{
+			"hg.example.com/user/hgrepo":   domain.VCSMercurial,
+			"svn.example.com/user/svnrepo": domain.VCSSubversion,
+			"github.com/user/bare.git":     domain.VCSBareGit,
+			"github.com/user/plain":        domain.VCSNone,
+		} {
+			p, ok := got[name]
+			if !ok {
+				t.Errorf("depth %d: %s not found", depth, name)
+				continue
+			}
+			if p.VCS != want || p.HasGit {
+				t.Errorf("depth %d: %s has VCS %q (HasGit %v), want %q", depth, name, p.VCS, p.HasGit, want)
+			}
+			if want != domain.VCSNone && p.Type != domain.ProjectTypeDev {
+				t.Errorf("depth %d: %s has type %v", depth, name, p.Type)
+			}
+		}
+		if len(projects) != 4 {
+			t.Errorf("depth %d: expected 4 projects, got %d", depth, len(projects))
+		}
+	}
+}
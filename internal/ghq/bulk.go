@@ -0,0 +1,54 @@
+package ghq
+
+import (
+	"bytes"
+	"sync"
+)
+
+// GetResult は一括取得における1リポジトリ分の結果
+type GetResult struct {
+	Options GetOptions // 取得に使用したオプション
+	Err     error      // 取得に失敗した場合のエラー
+	Output  string     // 取得中に出力された内容 (標準出力と標準エラー出力)
+}
+
+// GetMany は複数のリポジトリを並列に取得する
+// parallel は同時実行数の上限 (1 未満の場合は 1)
+// 各コマンドの出力は混ざらないよう GetResult.Output に保持される
+// onDone は各リポジトリの取得完了ごとに呼ばれる (nil 可、同時には呼ばれない)
+// 結果は opts と同じ順序で返される
+func (c *Client) GetMany(opts []GetOptions, parallel int, onDone func(GetResult)) []GetResult {
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	results := make([]GetResult, len(opts))
+	sem := make(chan struct{}, parallel) // 同時実行数を制限する
+	var wg sync.WaitGroup
+	var doneMu sync.Mutex // onDone の呼び出しを直列化する
+
+	for i, o := range opts {
+		wg.Add(1)
+		go func(i int, o GetOptions) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			var output bytes.Buffer
+			o.Stdout = &output
+			o.Stderr = &output
+			err := c.Get(o)
+
+			results[i] = GetResult{Options: o, Err: err, Output: output.String()}
+
+			if onDone != nil {
+				doneMu.Lock()
+				onDone(results[i])
+				doneMu.Unlock()
+			}
+		}(i, o)
+	}
+
+	wg.Wait()
+	return results
+}
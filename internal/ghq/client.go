@@ -2,8 +2,10 @@ package ghq
 
 import (
 	"context"
+	"io"
 	"os"
 	"os/exec"
+	"strings"
 	"time"
 
 	"github.com/mi8bi/ghqx/internal/config"
@@ -26,39 +28,87 @@ func NewClient(cfg *config.Config) *Client {
 
 // GetOptions は ghq get コマンドのオプション
 type GetOptions struct {
-	Repository string // リポジトリURL または短縮形
-	Workspace  string // 取得先のワークスペース (sandbox/dev/release) - Renamed from Zone
+	Repository string    // リポジトリURL または短縮形
+	Workspace  string    // 取得先のワークスペース (sandbox/dev/release) - Renamed from Zone
+	Stdout     io.Writer // コマンドの標準出力の出力先 (nil の場合は os.Stdout)
+	Stderr     io.Writer // コマンドの標準エラー出力の出力先 (nil の場合は os.Stderr)
 }
 
-// Get は ghq get コマンドを実行してリポジトリを取得する
-func (c *Client) Get(opts GetOptions) error {
-	// ghq コマンドが利用可能か確認
-	if !c.hasGhq() {
-		return domain.NewError(
-			domain.ErrCodeGitError,
-			"ghq command not found",
-		).WithHint("Install ghq: https://github.com/x-motemen/ghq")
+// outputs は出力先を返す (未指定の場合は親プロセスの標準出力・標準エラー出力)
+func (o GetOptions) outputs() (io.Writer, io.Writer) {
+	stdout, stderr := o.Stdout, o.Stderr
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+	if stderr == nil {
+		stderr = os.Stderr
 	}
+	return stdout, stderr
+}
 
+// Get はリポジトリを取得する
+// root の clone_backend (未指定の場合は clone.backend) 設定に従い
+// ghq get またはネイティブの git clone を使用する
+func (c *Client) Get(opts GetOptions) error {
 	// ワークスペースに対応する root を取得 - Renamed from zone
 	rootPath, exists := c.cfg.GetRoot(opts.Workspace) // Updated opts.Zone to opts.Workspace
 	if !exists {
 		return domain.ErrRootNotFound(opts.Workspace) // Updated opts.Zone to opts.Workspace
 	}
 
+	// 読み取り専用の root には取得しない
+	if c.cfg.IsReadOnly(opts.Workspace) {
+		return domain.ErrRootReadOnly(opts.Workspace)
+	}
+
+	// ghq は <host>/<owner>/<repo> のレイアウトしか扱えないため
+	// それ以外のレイアウトの root には常に git clone を使用する
+	if c.cfg.RootLayout(opts.Workspace) != domain.LayoutGhq || c.resolveBackend(opts.Workspace) == config.CloneBackendGit {
+		return c.gitClone(opts, rootPath)
+	}
+	return c.ghqGet(opts, rootPath)
+}
+
+// resolveBackend は root に対して実際に使用するクローンバックエンドを決定する
+// auto の場合は ghq が利用可能なら ghq、そうでなければ git を使用する
+func (c *Client) resolveBackend(workspace string) string {
+	switch c.cfg.RootCloneBackend(workspace) {
+	case config.CloneBackendGhq:
+		return config.CloneBackendGhq
+	case config.CloneBackendGit:
+		return config.CloneBackendGit
+	default:
+		if c.hasGhq() {
+			return config.CloneBackendGhq
+		}
+		return config.CloneBackendGit
+	}
+}
+
+// ghqGet は ghq get コマンドを実行してリポジトリを取得する
+func (c *Client) ghqGet(opts GetOptions, rootPath string) error {
+	// ghq コマンドが利用可能か確認
+	if !c.hasGhq() {
+		return domain.NewError(
+			domain.ErrCodeGitError,
+			"ghq command not found",
+		).WithHint("Install ghq: https://github.com/x-motemen/ghq or set clone.backend = \"git\"")
+	}
+
 	// コンテキストとタイムアウト設定
 	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
 	defer cancel()
 
 	// ghq get コマンドを構築
-	cmd := exec.CommandContext(ctx, "ghq", "get", opts.Repository)
+	// root の clone_flags はリポジトリ指定の前に渡す
+	args := append([]string{"get"}, c.cfg.RootCloneFlags(opts.Workspace)...)
+	cmd := exec.CommandContext(ctx, "ghq", append(args, opts.Repository)...)
 	
 	// GHQ_ROOT 環境変数を設定してクローン先を指定
 	cmd.Env = append(os.Environ(), "GHQ_ROOT="+rootPath)
 	
-	// 標準出力・標準エラー出力を親プロセスに接続
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	// 標準出力・標準エラー出力を接続
+	cmd.Stdout, cmd.Stderr = opts.outputs()
 
 	// コマンド実行
 	if err := cmd.Run(); err != nil {
@@ -78,6 +128,29 @@ func (c *Client) Get(opts GetOptions) error {
 	return nil
 }
 
+// rootsTimeout は ghq root の実行時間の上限
+const rootsTimeout = 5 * time.Second
+
+// Roots は ghq 自身が管理するルートディレクトリ (ghq root --all) を返す
+// ghq がインストールされていない場合や実行に失敗した場合は nil を返す
+func Roots() []string {
+	ctx, cancel := context.WithTimeout(context.Background(), rootsTimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "ghq", "root", "--all").Output()
+	if err != nil {
+		return nil
+	}
+
+	var roots []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			roots = append(roots, line)
+		}
+	}
+	return roots
+}
+
 // hasGhq は ghq コマンドが利用可能かチェックする
 func (c *Client) hasGhq() bool {
 	cmd := exec.Command("ghq", "--version")
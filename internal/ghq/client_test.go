@@ -282,3 +282,21 @@ func TestGetRootPath(t *testing.T) {
 		}
 	}
 }
+
+func TestRoots(t *testing.T) {
+	dir := t.TempDir()
+	script := "#!/bin/sh\n[ \"$1 $2\" = \"root --all\" ] || exit 1\necho /home/u/ghq\necho\necho /mnt/ghq\n"
+	if err := os.WriteFile(filepath.Join(dir, "ghq"), []byte(script), 0755); err != nil {
+		t.Fatalf("write ghq: %v", err)
+	}
+
+	t.Setenv("PATH", dir)
+	if got := Roots(); len(got) != 2 || got[0] != "/home/u/ghq" || got[1] != "/mnt/ghq" {
+		t.Errorf("Roots() = %v", got)
+	}
+
+	t.Setenv("PATH", "")
+	if got := Roots(); got != nil {
+		t.Errorf("Roots() without ghq = %v, want nil", got)
+	}
+}
@@ -0,0 +1,83 @@
+package ghq
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/mi8bi/ghqx/internal/domain"
+)
+
+// gitClone は ghq を使わずに git clone でリポジトリを取得する
+// root のレイアウト (既定は ghq と同じ <root>/<host>/<owner>/<repo>) に従って配置する
+func (c *Client) gitClone(opts GetOptions, rootPath string) error {
+	if !c.hasGit() {
+		return domain.NewError(
+			domain.ErrCodeGitError,
+			"git command not found",
+		).WithHint("Install git or ghq: https://github.com/x-motemen/ghq")
+	}
+
+	ref, err := domain.ParseRepoRef(opts.Repository)
+	if err != nil {
+		return err
+	}
+
+	dest := filepath.Join(rootPath, filepath.FromSlash(c.cfg.RootLayout(opts.Workspace).RepoPath(ref)))
+	if _, err := os.Stat(dest); err == nil {
+		return domain.ErrProjectAlreadyExists(dest)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return domain.ErrFSCreateDir(err)
+	}
+
+	// コンテキストとタイムアウト設定
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	// root の clone_flags (例: --depth=1) は "--" の前に渡す
+	args := append([]string{"clone"}, c.cfg.RootCloneFlags(opts.Workspace)...)
+	args = append(args, "--", cloneURL(opts.Repository, ref), dest)
+	cmd := exec.CommandContext(ctx, "git", args...)
+
+	// 標準出力・標準エラー出力を接続
+	cmd.Stdout, cmd.Stderr = opts.outputs()
+
+	if err := cmd.Run(); err != nil {
+		// 中途半端なクローンを残さない
+		os.RemoveAll(dest)
+
+		if ctx.Err() == context.DeadlineExceeded {
+			return domain.NewError(
+				domain.ErrCodeGitError,
+				"git clone operation timed out",
+			).WithHint("Repository may be too large or network is slow")
+		}
+		return domain.NewErrorWithCause(
+			domain.ErrCodeGitError,
+			"git clone failed",
+			err,
+		).WithHint("Check repository URL and network connection").
+			WithInternal("dest: " + dest)
+	}
+
+	return nil
+}
+
+// cloneURL は git clone に渡す URL を決定する
+// URL や scp 形式の指定はそのまま使い、短縮形は https URL に展開する
+func cloneURL(repository string, ref domain.RepoRef) string {
+	if strings.Contains(repository, ":") {
+		return repository
+	}
+	return ref.CloneURL()
+}
+
+// hasGit は git コマンドが利用可能かチェックする
+func (c *Client) hasGit() bool {
+	_, err := exec.LookPath("git")
+	return err == nil
+}
@@ -0,0 +1,248 @@
+package ghq
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mi8bi/ghqx/internal/config"
+	"github.com/mi8bi/ghqx/internal/domain"
+)
+
+// newLocalRemote creates a repository with one commit at <dir>/user/repo
+// and returns its file:// URL.
+func newLocalRemote(t *testing.T, dir string) string {
+	t.Helper()
+
+	if exec.Command("git", "--version").Run() != nil {
+		t.Skip("git not available on this system")
+	}
+
+	remote := filepath.Join(dir, "user", "repo")
+	if err := os.MkdirAll(remote, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	cmds := [][]string{
+		{"git", "init"},
+		{"git", "config", "user.email", "test@example.com"},
+		{"git", "config", "user.name", "test"},
+		{"git", "commit", "--allow-empty", "-m", "init"},
+	}
+	for _, args := range cmds {
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Dir = remote
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("%v failed: %v: %s", args, err, out)
+		}
+	}
+
+	return "file://" + filepath.ToSlash(remote)
+}
+
+func TestGitCloneFromLocalRepository(t *testing.T) {
+	tmp := t.TempDir()
+	url := newLocalRemote(t, filepath.Join(tmp, "remote"))
+
+	sandbox := filepath.Join(tmp, "sandbox")
+	dev := filepath.Join(tmp, "dev")
+	cfg := &config.Config{
+		Roots:   map[string]string{"sandbox": sandbox, "dev": dev},
+		Default: config.DefaultConfig{Root: "sandbox"},
+		Clone:   config.CloneConfig{Backend: config.CloneBackendGit},
+	}
+	c := NewClient(cfg)
+
+	if err := c.Get(GetOptions{Repository: url, Workspace: "dev"}); err != nil {
+		t.Fatalf("Get with git backend failed: %v", err)
+	}
+
+	dest := filepath.Join(dev, domain.LocalRepoHost, "user", "repo")
+	if _, err := os.Stat(filepath.Join(dest, ".git")); err != nil {
+		t.Fatalf("expected clone at %s: %v", dest, err)
+	}
+
+	// Cloning again must not touch the existing checkout
+	if err := c.Get(GetOptions{Repository: url, Workspace: "dev"}); err == nil {
+		t.Fatal("expected error when destination already exists")
+	}
+}
+
+func TestGitCloneFailureCleansUp(t *testing.T) {
+	if exec.Command("git", "--version").Run() != nil {
+		t.Skip("git not available on this system")
+	}
+
+	tmp := t.TempDir()
+	cfg := &config.Config{
+		Roots: map[string]string{"sandbox": tmp},
+		Clone: config.CloneConfig{Backend: config.CloneBackendGit},
+	}
+	c := NewClient(cfg)
+
+	url := "file://" + filepath.ToSlash(filepath.Join(tmp, "missing", "user", "repo"))
+	if err := c.Get(GetOptions{Repository: url, Workspace: "sandbox"}); err == nil {
+		t.Fatal("expected error cloning a missing repository")
+	}
+
+	if _, err := os.Stat(filepath.Join(tmp, domain.LocalRepoHost, "user", "repo")); !os.IsNotExist(err) {
+		t.Errorf("expected no partial clone to remain")
+	}
+}
+
+func TestGitCloneWithInvalidRepository(t *testing.T) {
+	if exec.Command("git", "--version").Run() != nil {
+		t.Skip("git not available on this system")
+	}
+
+	cfg := &config.Config{
+		Roots: map[string]string{"sandbox": t.TempDir()},
+		Clone: config.CloneConfig{Backend: config.CloneBackendGit},
+	}
+
+	if err := NewClient(cfg).Get(GetOptions{Repository: "repo", Workspace: "sandbox"}); err == nil {
+		t.Fatal("expected error for an unparsable repository")
+	}
+}
+
+func TestResolveBackend(t *testing.T) {
+	cfg := &config.Config{Roots: map[string]string{"sandbox": "/tmp"}}
+	c := NewClient(cfg)
+
+	cfg.Clone.Backend = config.CloneBackendGit
+	if got := c.resolveBackend("sandbox"); got != config.CloneBackendGit {
+		t.Errorf("expected git backend, got %q", got)
+	}
+
+	cfg.Clone.Backend = config.CloneBackendGhq
+	if got := c.resolveBackend("sandbox"); got != config.CloneBackendGhq {
+		t.Errorf("expected ghq backend, got %q", got)
+	}
+
+	// auto falls back to git when ghq is missing
+	origPath := os.Getenv("PATH")
+	os.Setenv("PATH", "")
+	defer os.Setenv("PATH", origPath)
+
+	cfg.Clone.Backend = ""
+	if got := c.resolveBackend("sandbox"); got != config.CloneBackendGit {
+		t.Errorf("expected auto to fall back to git, got %q", got)
+	}
+}
+
+func TestCloneURL(t *testing.T) {
+	ref := domain.RepoRef{Host: "github.com", Owner: "user", Name: "repo"}
+
+	testCases := map[string]string{
+		"user/repo":                      "https://github.com/user/repo",
+		"github.com/user/repo":           "https://github.com/user/repo",
+		"git@github.com:user/repo.git":   "git@github.com:user/repo.git",
+		"https://github.com/user/repo":   "https://github.com/user/repo",
+		"ssh://git@github.com/user/repo": "ssh://git@github.com/user/repo",
+	}
+
+	for in, want := range testCases {
+		if got := cloneURL(in, ref); got != want {
+			t.Errorf("cloneURL(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestGetManyCollectsResultsInOrder(t *testing.T) {
+	tmp := t.TempDir()
+	url := newLocalRemote(t, filepath.Join(tmp, "remote"))
+
+	root := filepath.Join(tmp, "sandbox")
+	cfg := &config.Config{
+		Roots: map[string]string{"sandbox": root},
+		Clone: config.CloneConfig{Backend: config.CloneBackendGit},
+	}
+	c := NewClient(cfg)
+
+	opts := []GetOptions{
+		{Repository: url, Workspace: "sandbox"},
+		{Repository: url, Workspace: "missing"},
+	}
+
+	calls := 0
+	results := c.GetMany(opts, 2, func(GetResult) { calls++ })
+
+	if calls != len(opts) {
+		t.Errorf("expected onDone to be called %d times, got %d", len(opts), calls)
+	}
+	if len(results) != len(opts) {
+		t.Fatalf("expected %d results, got %d", len(opts), len(results))
+	}
+	if results[0].Err != nil {
+		t.Errorf("expected first clone to succeed: %v (%s)", results[0].Err, results[0].Output)
+	}
+	if results[1].Err == nil {
+		t.Errorf("expected clone into a missing workspace to fail")
+	}
+	if results[0].Options.Repository != url || results[1].Options.Workspace != "missing" {
+		t.Errorf("results are not in input order")
+	}
+}
+
+func TestGitCloneUsesRootLayout(t *testing.T) {
+	tmp := t.TempDir()
+	url := newLocalRemote(t, filepath.Join(tmp, "remote"))
+
+	flat := filepath.Join(tmp, "flat")
+	owner := filepath.Join(tmp, "owner")
+	cfg := &config.Config{
+		Roots: map[string]string{"flat": flat, "owner": owner},
+		RootOptions: map[string]config.RootOptions{
+			"flat":  {Layout: "flat"},
+			"owner": {Layout: "owner/repo"},
+		},
+		// ghq cannot clone into these layouts, so git is used regardless
+		Clone: config.CloneConfig{Backend: config.CloneBackendGhq},
+	}
+	c := NewClient(cfg)
+
+	for root, dest := range map[string]string{
+		"flat":  filepath.Join(flat, "repo"),
+		"owner": filepath.Join(owner, "user", "repo"),
+	} {
+		if err := c.Get(GetOptions{Repository: url, Workspace: root}); err != nil {
+			t.Fatalf("Get into %s failed: %v", root, err)
+		}
+		if _, err := os.Stat(filepath.Join(dest, ".git")); err != nil {
+			t.Errorf("expected clone at %s: %v", dest, err)
+		}
+	}
+}
+
+func TestGetRespectsRootSettings(t *testing.T) {
+	tmp := t.TempDir()
+	url := newLocalRemote(t, filepath.Join(tmp, "remote"))
+
+	cfg := &config.Config{
+		Roots: map[string]string{"dev": filepath.Join(tmp, "dev"), "archive": filepath.Join(tmp, "archive")},
+		RootOptions: map[string]config.RootOptions{
+			"dev":     {CloneBackend: config.CloneBackendGit, CloneFlags: []string{"--origin", "upstream"}},
+			"archive": {ReadOnly: true},
+		},
+		Clone: config.CloneConfig{Backend: config.CloneBackendGhq},
+	}
+	c := NewClient(cfg)
+
+	var ghqxErr *domain.GhqxError
+	if err := c.Get(GetOptions{Repository: url, Workspace: "archive"}); !errors.As(err, &ghqxErr) || ghqxErr.Code != domain.ErrCodeInvalidPath {
+		t.Errorf("expected read-only error, got %v", err)
+	}
+
+	if err := c.Get(GetOptions{Repository: url, Workspace: "dev"}); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	cmd := exec.Command("git", "remote")
+	cmd.Dir = filepath.Join(tmp, "dev", domain.LocalRepoHost, "user", "repo")
+	out, err := cmd.Output()
+	if err != nil || strings.TrimSpace(string(out)) != "upstream" {
+		t.Errorf("expected clone flags to name the remote upstream, got %q (%v)", out, err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"os/exec"
+	"strconv"
 	"strings"
 	"time"
 
@@ -71,3 +72,169 @@ func (c *Client) GetBranch(repoPath string) (string, error) {
 
 	return strings.TrimSpace(string(output)), nil
 }
+
+// run executes a git command in repoPath with the client's timeout.
+// op names the operation in returned errors (e.g., "fetch").
+func (c *Client) run(repoPath, op string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = repoPath
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", domain.ErrGitTimeout(op)
+		}
+		gitErr := domain.ErrGitCommandFailed(op, err)
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			gitErr = gitErr.WithInternal(msg)
+		}
+		return "", gitErr
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// RevParse resolves a revision (e.g., "HEAD" or "@{u}") to a commit hash.
+func (c *Client) RevParse(repoPath, rev string) (string, error) {
+	return c.run(repoPath, "rev-parse", "rev-parse", "--verify", "--quiet", rev+"^{commit}")
+}
+
+// RemoteURL returns the URL of the "origin" remote.
+func (c *Client) RemoteURL(repoPath string) (string, error) {
+	return c.run(repoPath, "remote", "remote", "get-url", "origin")
+}
+
+// HasUpstream reports whether the current branch tracks a remote branch.
+func (c *Client) HasUpstream(repoPath string) bool {
+	_, err := c.RevParse(repoPath, "@{u}")
+	return err == nil
+}
+
+// Fetch downloads objects and refs from all remotes, pruning deleted branches.
+func (c *Client) Fetch(repoPath string) error {
+	_, err := c.run(repoPath, "fetch", "fetch", "--all", "--prune", "--quiet")
+	return err
+}
+
+// MergeFastForward fast-forwards the current branch to its upstream.
+// It fails instead of creating a merge commit when the branches have diverged.
+func (c *Client) MergeFastForward(repoPath string) error {
+	_, err := c.run(repoPath, "merge", "merge", "--ff-only", "--quiet", "@{u}")
+	return err
+}
+
+// WorktreeAdd creates a linked worktree of the repository at repoPath in path.
+// An existing local branch, or a remote branch of the same name, is checked out;
+// otherwise a new branch is created from the current HEAD.
+func (c *Client) WorktreeAdd(repoPath, path, branch string) error {
+	args := []string{"worktree", "add", "--quiet", path, branch}
+	if !c.hasBranch(repoPath, branch) {
+		args = []string{"worktree", "add", "--quiet", "-b", branch, path}
+	}
+	_, err := c.run(repoPath, "worktree add", args...)
+	return err
+}
+
+// hasBranch reports whether branch exists locally or on any remote.
+func (c *Client) hasBranch(repoPath, branch string) bool {
+	if _, err := c.RevParse(repoPath, "refs/heads/"+branch); err == nil {
+		return true
+	}
+	out, err := c.run(repoPath, "for-each-ref", "for-each-ref", "--format=%(refname)", "refs/remotes/*/"+branch)
+	return err == nil && out != ""
+}
+
+// WorktreeRemove removes the linked worktree at path from the repository at repoPath.
+// Git refuses worktrees with changes unless force is set.
+func (c *Client) WorktreeRemove(repoPath, path string, force bool) error {
+	args := []string{"worktree", "remove", path}
+	if force {
+		args = []string{"worktree", "remove", "--force", path}
+	}
+	_, err := c.run(repoPath, "worktree remove", args...)
+	return err
+}
+
+// Status returns detailed status for a repository along with its current branch.
+// Everything except the last commit date comes from a single
+// "git status --porcelain=v2 --branch --show-stash" call.
+func (c *Client) Status(repoPath string) (domain.GitStatus, string, error) {
+	out, err := c.run(repoPath, "status", "status", "--porcelain=v2", "--branch", "--show-stash")
+	if err != nil {
+		return domain.GitStatus{}, "", err
+	}
+
+	st, branch := parseStatusV2(out)
+
+	// Repositories without commits have no date to show
+	if unix, err := c.run(repoPath, "log", "log", "-1", "--format=%ct"); err == nil && unix != "" {
+		if sec, err := strconv.ParseInt(unix, 10, 64); err == nil {
+			st.LastCommit = time.Unix(sec, 0)
+		}
+	}
+
+	return st, branch, nil
+}
+
+// parseStatusV2 parses "git status --porcelain=v2 --branch --show-stash" output.
+// It returns the status and the branch name ("HEAD" when detached, like rev-parse).
+func parseStatusV2(out string) (domain.GitStatus, string) {
+	st := domain.GitStatus{Loaded: true}
+	branch := ""
+
+	for _, line := range strings.Split(out, "\n") {
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "# ") {
+			fields := strings.Fields(line[2:])
+			if len(fields) < 2 {
+				continue
+			}
+			switch fields[0] {
+			case "branch.head":
+				branch = fields[1]
+				if branch == "(detached)" {
+					branch = "HEAD"
+				}
+			case "branch.upstream":
+				st.Upstream = fields[1]
+			case "branch.ab":
+				if len(fields) >= 3 {
+					st.Ahead, _ = strconv.Atoi(strings.TrimPrefix(fields[1], "+"))
+					st.Behind, _ = strconv.Atoi(strings.TrimPrefix(fields[2], "-"))
+				}
+			case "stash":
+				st.Stashes, _ = strconv.Atoi(fields[1])
+			}
+			continue
+		}
+
+		switch line[0] {
+		case '1', '2':
+			// "1 XY ..." or "2 XY ...": X is the index, Y the working tree
+			if len(line) < 4 {
+				continue
+			}
+			if line[2] != '.' {
+				st.Staged++
+			}
+			if line[3] != '.' {
+				st.Unstaged++
+			}
+		case 'u':
+			st.Conflicted++
+		case '?':
+			st.Untracked++
+		}
+	}
+
+	return st, branch
+}
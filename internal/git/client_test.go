@@ -4,8 +4,11 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/mi8bi/ghqx/internal/domain"
 )
 
 func TestHasGitUnavailableWhenPathEmpty(t *testing.T) {
@@ -111,3 +114,152 @@ func TestInitCommitFlowWhenGitAvailable(t *testing.T) {
 		t.Fatalf("expected non-empty branch name")
 	}
 }
+
+func TestFetchAndMergeFastForward(t *testing.T) {
+	if exec.Command("git", "--version").Run() != nil {
+		t.Skip("git not available on this system")
+	}
+
+	tmp := t.TempDir()
+	run := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.email=t@example.com", "-c", "user.name=t", "-c", "commit.gpgsign=false"}, args...)...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, out)
+		}
+	}
+
+	remote := filepath.Join(tmp, "remote")
+	if err := os.MkdirAll(remote, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	run(remote, "init")
+	run(remote, "commit", "--allow-empty", "-m", "init")
+
+	clone := filepath.Join(tmp, "clone")
+	run(tmp, "clone", "--quiet", remote, clone)
+	run(remote, "commit", "--allow-empty", "-m", "second")
+
+	c := NewClientWithTimeout(10 * time.Second)
+
+	if !c.HasUpstream(clone) {
+		t.Fatal("expected clone to have an upstream")
+	}
+	if c.HasUpstream(remote) {
+		t.Error("expected remote to have no upstream")
+	}
+
+	before, err := c.RevParse(clone, "HEAD")
+	if err != nil {
+		t.Fatalf("RevParse failed: %v", err)
+	}
+	if err := c.Fetch(clone); err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if err := c.MergeFastForward(clone); err != nil {
+		t.Fatalf("MergeFastForward failed: %v", err)
+	}
+	after, err := c.RevParse(clone, "HEAD")
+	if err != nil {
+		t.Fatalf("RevParse failed: %v", err)
+	}
+	if before == after {
+		t.Error("expected HEAD to move after fast-forward")
+	}
+
+	if _, err := c.RevParse(clone, "does-not-exist"); err == nil {
+		t.Error("expected RevParse to fail for unknown revision")
+	}
+}
+
+func TestParseStatusV2(t *testing.T) {
+	out := strings.Join([]string{
+		"# branch.oid 1234567890abcdef",
+		"# branch.head main",
+		"# branch.upstream origin/main",
+		"# branch.ab +2 -1",
+		"# stash 3",
+		"1 M. N... 100644 100644 100644 aaa bbb staged.go",
+		"1 .M N... 100644 100644 100644 aaa bbb unstaged.go",
+		"1 MM N... 100644 100644 100644 aaa bbb both.go",
+		"2 R. N... 100644 100644 100644 aaa bbb R100 new.go\told.go",
+		"u UU N... 100644 100644 100644 100644 aaa bbb ccc conflict.go",
+		"? untracked.txt",
+		"? other.txt",
+		"! ignored.log",
+	}, "\n")
+
+	st, branch := parseStatusV2(out)
+
+	if branch != "main" {
+		t.Errorf("branch = %q, want main", branch)
+	}
+	want := domain.GitStatus{
+		Loaded:     true,
+		Upstream:   "origin/main",
+		Ahead:      2,
+		Behind:     1,
+		Staged:     3,
+		Unstaged:   2,
+		Untracked:  2,
+		Conflicted: 1,
+		Stashes:    3,
+	}
+	if st != want {
+		t.Errorf("status = %+v, want %+v", st, want)
+	}
+}
+
+func TestParseStatusV2DetachedWithoutUpstream(t *testing.T) {
+	st, branch := parseStatusV2("# branch.oid (initial)\n# branch.head (detached)\n")
+	if branch != "HEAD" {
+		t.Errorf("branch = %q, want HEAD", branch)
+	}
+	if st.HasUpstream() || st.HasChanges() || st.Stashes != 0 {
+		t.Errorf("expected empty status, got %+v", st)
+	}
+}
+
+func TestStatusWhenGitAvailable(t *testing.T) {
+	if exec.Command("git", "--version").Run() != nil {
+		t.Skip("git not available on this system")
+	}
+
+	tmp := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.email=t@example.com", "-c", "user.name=t", "-c", "commit.gpgsign=false"}, args...)...)
+		cmd.Dir = tmp
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, out)
+		}
+	}
+	run("init")
+	run("commit", "--allow-empty", "-m", "init")
+	if err := os.WriteFile(filepath.Join(tmp, "stash.txt"), []byte("x"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	run("stash", "push", "--include-untracked")
+	if err := os.WriteFile(filepath.Join(tmp, "new.txt"), []byte("x"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	c := NewClientWithTimeout(5 * time.Second)
+	st, branch, err := c.Status(tmp)
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if branch == "" {
+		t.Error("expected a branch name")
+	}
+	if !st.UntrackedOnly() || st.Untracked != 1 {
+		t.Errorf("expected one untracked file, got %+v", st)
+	}
+	if st.Stashes != 1 {
+		t.Errorf("expected one stash, got %d", st.Stashes)
+	}
+	if st.LastCommit.IsZero() || time.Since(st.LastCommit) > time.Hour {
+		t.Errorf("unexpected last commit time %v", st.LastCommit)
+	}
+}
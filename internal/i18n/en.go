@@ -12,12 +12,14 @@ It diagnoses the following items:
 - git command`,
 		"doctor.check.config.name":      "config",
 		"doctor.check.config.ok":        "Config file loaded successfully",
+		"doctor.check.config.profile":   "(profile: %s)",
 		"doctor.check.config.fail":      "Config file not found or invalid",
 		"doctor.check.ghq.name":         "ghq",
 		"doctor.check.ghq.ok":           "ghq found at: %s",
 		"doctor.check.ghq.fail.found":   "ghq not found",
 		"doctor.check.ghq.fail.exec":    "Failed to execute ghq --version",
 		"doctor.check.ghq.hint.install": "Install ghq: https://github.com/x-motemen/ghq",
+		"doctor.check.ghq.notRequired":  "ghq not found; repositories are cloned with git",
 		"doctor.check.git.name":         "git",
 		"doctor.check.git.ok":           "git found at: %s",
 		"doctor.check.git.fail.found":   "git not found",
@@ -34,27 +36,113 @@ This command cannot directly change your shell's current directory. To do that,
 		"version.command.long":  "version displays the version information of ghqx.",
 
 		// Errors (Messages and Hints)
-		"error.config.notFoundAny.message":        "No configuration file found",
-		"error.config.notFoundAny.hint":           "Run 'ghqx config init' to create a config file",
-		"error.config.notFoundAt.message":         "Config file not found at specified path",
-		"error.config.notFoundAt.hint":            "Check the path provided with --config flag",
-		"error.config.invalidToml.message":        "Failed to parse config file",
-		"error.config.invalidToml.hint":           "Check the TOML syntax in your config file",
-		"error.config.noRoots.message":            "No roots defined in configuration",
-		"error.config.noRoots.hint":               "Add at least one root in the [roots] section",
-		"error.config.invalidDefaultRoot.message": "Default root does not exist in roots",
-		"error.config.invalidDefaultRoot.hint":    "Set default.root to one of the defined roots",
+		"error.config.notFoundAny.message":         "No configuration file found",
+		"error.config.notFoundAny.hint":            "Run 'ghqx config init' to create a config file",
+		"error.config.notFoundAt.message":          "Config file not found at specified path",
+		"error.config.notFoundAt.hint":             "Check the path provided with --config flag",
+		"error.config.invalidToml.message":         "Failed to parse config file",
+		"error.config.invalidToml.hint":            "Check the TOML syntax in your config file",
+		"error.config.unsupportedVersion.message":  "Unsupported config version: %d",
+		"error.config.unsupportedVersion.hint":     "This ghqx reads config versions up to %d; upgrade ghqx",
+		"error.config.noRoots.message":             "No roots defined in configuration",
+		"error.config.noRoots.hint":                "Add at least one root in the [roots] section",
+		"error.config.invalidDefaultRoot.message":  "Default root does not exist in roots",
+		"error.config.invalidDefaultRoot.hint":     "Set default.root to one of the defined roots",
+		"error.config.invalidCloneBackend.message": "Unknown clone backend: %s",
+		"error.config.invalidCloneBackend.hint":    "Set clone.backend to auto, ghq or git",
+		"error.config.invalidMaxDepth.message":     "Invalid scan depth: %d",
+		"error.config.invalidMaxDepth.hint":        "Set scan.max_depth to a positive number, or remove it to use the default (5)",
+		"error.config.invalidExclude.message":      "Invalid exclude pattern: %s",
+		"error.config.invalidExclude.hint":         "Check the brackets in the pattern (gitignore-style globs such as 'tmp/' or '**/cache')",
+		"error.config.unsetVariable.message":       "Environment variable $%s in the path of root %s is not set",
+		"error.config.unsetVariable.hint":          "Set the variable, or write the path without it",
+		"error.config.invalidLayout.message":       "Invalid layout %q for root %s",
+		"error.config.invalidLayout.hint":          "Use ghq, owner/repo, flat or a depth from 1 to 10",
+		"error.config.invalidType.message":         "Invalid workspace type %q for root %s",
+		"error.config.invalidType.hint":            "Use lowercase letters, digits, '-' and '_', starting with a letter (e.g. dev, archive)",
+		"error.config.invalidColor.message":        "Invalid color %q for root %s",
+		"error.config.invalidColor.hint":           "Use an ANSI color number (0-255) or a hex color such as #ff8700",
+		"error.config.unknownRootOptions.message":  "root_options refers to an unknown root: %s",
+		"error.config.unknownRootOptions.hint":     "Add the root to [roots] or remove its [root_options] table",
+		"error.config.problems.message":            "Config has %d problem(s):",
+		"error.config.problems.hint":               "Run 'ghqx config validate' for a hint on each problem",
+		"error.config.validateFailed.message":      "Found %d problem(s) in %s",
+		"error.config.validateFailed.hint":         "Fix the problems listed above and run 'ghqx config validate' again",
+
+		"error.config.unknownKey.message":        "Unknown config key: %s",
+		"error.config.unknownKey.hint":           "Run 'ghqx config get' to list the keys that are set (e.g. default.root, roots.<name>.layout)",
+		"error.config.invalidValue.message":      "Invalid value %q for %s",
+		"error.config.invalidValue.hint":         "Use true/false for flags, a number for depths and comma-separated items for lists",
+		"error.config.unsetRootPath.message":     "Cannot unset %s",
+		"error.config.unsetRootPath.hint":        "Use 'ghqx config remove-root' to remove the root",
+		"error.config.invalidRootName.message":   "Invalid root name: %q",
+		"error.config.invalidRootName.hint":      "Root names cannot be empty or contain dots, slashes or other path characters",
+		"error.config.rootExists.message":        "Root already exists: %s",
+		"error.config.rootExists.hint":           "Use 'ghqx config set roots.<name>.path' to change its path",
+		"error.config.removeDefaultRoot.message": "Cannot remove the default root: %s",
+		"error.config.removeDefaultRoot.hint":    "Set default.root to another root first",
+
+		"error.profile.notFound.message": "Profile not found: %s",
+		"error.profile.notFound.hint":    "Available profiles: %s",
+		"error.profile.reserved.message": "[profiles.%s] is not allowed",
+		"error.profile.reserved.hint":    "The top-level roots are the %s profile; rename the table",
+
+		"error.config.noHistory.message":      "No backups of %s yet",
+		"error.config.noHistory.hint":         "A backup is taken every time ghqx changes the config file",
+		"error.config.backupNotFound.message": "No backup number %s",
+		"error.config.backupNotFound.hint":    "Choose a number between 1 and %d from 'ghqx config history'",
 
 		"error.root.notFound.message":    "Root not found: %s",
 		"error.root.notFound.hint":       "Check your config.toml for available roots",
+		"error.root.readOnly.message":    "Root is read-only: %s",
+		"error.root.readOnly.hint":       "Choose another root or remove read_only from its settings",
 		"error.root.dirNotExist.message": "Root directory does not exist: %s",
 		"error.root.dirNotExist.hint":    "Create the directory or update config.toml",
 
+		"error.root.notDirectory.message": "Root %s is not a directory: %s",
+		"error.root.notDirectory.hint":    "Point the root at a directory, or move the file out of the way",
+		"error.root.notWritable.message":  "Root %s is not writable: %s",
+		"error.root.notWritable.hint":     "Fix the directory permissions, or set read_only = true if the root is only browsed",
+		"error.root.relativePath.message": "Root %s has a relative path: %s",
+		"error.root.relativePath.hint":    "Use an absolute path; relative paths change with the working directory",
+		"error.root.overlap.message":      "Roots %s and %s overlap",
+		"error.root.overlap.hint":         "Give each root its own directory; nested roots list the same repositories twice",
+		"error.root.overlapsGhq.message":  "Root %s overlaps the ghq root %s",
+		"error.root.overlapsGhq.hint":     "Move the root out of the ghq root, or clone with ghqx only so both tools agree on the layout",
+
 		"error.project.notFound.message":    "Project not found: %s",
 		"error.project.notFound.hint":       "Use 'ghqx status' to see all available projects",
+		"error.project.ambiguous.message":   "Project %s exists in several workspaces: %s",
+		"error.project.ambiguous.hint":      "Choose one with --from <workspace>",
 		"error.project.nameInvalid.message": "Invalid project name",
 		"error.project.nameInvalid.hint":    "Project name contains forbidden characters",
 
+		"error.project.alreadyExists.message":   "A project already exists at the target path",
+		"error.project.alreadyExists.hint":      "Remove or rename the existing project first",
+		"error.promote.sameWorkspace.message":   "Project is already in workspace: %s",
+		"error.promote.sameWorkspace.hint":      "Specify a different target workspace",
+		"error.promote.noNextWorkspace.message": "No next workspace after: %s",
+		"error.promote.noNextWorkspace.hint":    "Specify the target workspace explicitly",
+		"error.promote.layoutMismatch.message":  "Cannot place %s in workspace %s with layout %s",
+		"error.promote.layoutMismatch.hint":     "Only repositories with an origin remote can move into a deeper layout; move the project manually",
+		"error.promote.moveFailed.message":      "Failed to move project",
+		"error.promote.moveFailed.hint":         "Check directory permissions and free disk space",
+
+		"error.worktree.notGit.message":    "Not a git repository: %s",
+		"error.worktree.notGit.hint":       "Worktrees can only be created from git repositories",
+		"error.worktree.notLinked.message": "Not a linked worktree: %s",
+		"error.worktree.notLinked.hint":    "Run 'ghqx worktree list' to see the worktrees",
+
+		"error.repoRef.invalid.message": "Invalid repository reference: %s",
+		"error.repoRef.invalid.hint":    "Use a URL, host/owner/repo or owner/repo",
+
+		"error.status.invalidFormat.message":   "Unknown output format: %s",
+		"error.status.invalidFormat.hint":      "Use one of: table, json, ndjson, csv, tsv",
+		"error.status.invalidSort.message":     "Unknown sort key: %s",
+		"error.status.invalidSort.hint":        "Use one of: name, workspace, modified, last-commit",
+		"error.status.invalidTemplate.message": "Invalid output template",
+		"error.status.invalidTemplate.hint":    "See https://pkg.go.dev/text/template for the template syntax",
+
 		"error.argument.required": "Argument required",
 
 		"error.git.dirtyRepo.message":     "Repository has uncommitted changes",
@@ -62,10 +150,18 @@ This command cannot directly change your shell's current directory. To do that,
 		"error.git.timeout.message":       "Git operation timed out: %s",
 		"error.git.commandFailed.message": "Git operation failed: %s",
 
+		"error.vcs.timeout.message":       "%s operation timed out: %s",
+		"error.vcs.commandFailed.message": "%s operation failed: %s",
+
 		"error.fs.readDir.message":   "Failed to read directory",
 		"error.fs.createDir.message": "Failed to create directory",
 		"error.fs.scanRoot.message":  "Failed to scan root directory",
 
+		"error.index.cacheDir.message": "Cannot determine the cache directory for the project index",
+		"error.index.cacheDir.hint":    "Set XDG_CACHE_HOME (or HOME), or use --no-cache",
+		"error.index.write.message":    "Failed to write the project index",
+		"error.index.write.hint":       "Check permissions of the cache directory, or use --no-cache",
+
 		// UI Formatter
 		"ui.error.prefix":          "Error",
 		"ui.error.hintPrefix":      "Hint",
@@ -73,14 +169,27 @@ This command cannot directly change your shell's current directory. To do that,
 		"ui.error.internalPrefix":  "Internal",
 		"ui.error.causePrefix":     "Cause",
 		"ui.success.prefix":        "✓",
+		"ui.failure.prefix":        "✗",
 		"ui.warning.prefix":        "⚠",
 		"ui.info.prefix":           "•",
 
 		// Status display strings
-		"status.git.managed":   "Managed",
-		"status.git.unmanaged": "Unmanaged",
-		"status.repo.clean":    "clean",
-		"status.repo.dirty":    "dirty",
+		"status.git.managed":       "Managed",
+		"status.git.unmanaged":     "Unmanaged",
+		"status.repo.clean":        "clean",
+		"status.repo.dirty":        "dirty",
+		"status.sync.noUpstream":   "no upstream",
+		"status.time.justNow":      "just now",
+		"status.time.minutesAgo":   "%dm ago",
+		"status.time.hoursAgo":     "%dh ago",
+		"status.time.daysAgo":      "%dd ago",
+		"status.check.uncommitted": "uncommitted changes",
+		"status.check.unpushed":    "unpushed commits",
+		"status.check.stashed":     "stashes",
+		"status.check.unreadable":  "status unreadable",
+		"status.check.ok":          "All %d repositories are safe: no uncommitted changes, unpushed commits or stashes",
+		"status.excluded.header":   "Excluded by patterns (%d):",
+		"status.check.failed":      "%d of %d repositories have unsaved work",
 
 		// Status table headers
 		"status.header.name":       "Repo",
@@ -89,6 +198,12 @@ This command cannot directly change your shell's current directory. To do that,
 		"status.header.status":     "Status",
 		"status.header.root":       "Root",
 		"status.header.path":       "Path",
+		"status.header.branch":     "Branch",
+		"status.header.sync":       "Sync",
+		"status.header.changes":    "Changes",
+		"status.header.stash":      "Stash",
+		"status.header.lastCommit": "LastCommit",
+		"status.header.problems":   "Problems",
 
 		// Status messages
 		"status.message.projectsLoaded": "%d projects loaded",
@@ -102,15 +217,24 @@ This command cannot directly change your shell's current directory. To do that,
 		"status.title.detail":  "ghqx status - Project Detail",
 
 		// TUI Detail View
-		"status.detail.basicInfo":  "■ Basic Info",
-		"status.detail.name":       "Name",
-		"status.detail.path":       "Path",
-		"status.detail.workspace":  "Workspace", // Renamed from status.detail.zone
-		"status.detail.root":       "Root",
-		"status.detail.gitInfo":    "■ Git Info",
-		"status.detail.gitManaged": "Git Managed",
-		"status.detail.status":     "Status",
-		"status.detail.branch":     "Branch",
+		"status.detail.basicInfo":    "■ Basic Info",
+		"status.detail.name":         "Name",
+		"status.detail.path":         "Path",
+		"status.detail.workspace":    "Workspace", // Renamed from status.detail.zone
+		"status.detail.root":         "Root",
+		"status.detail.description":  "Description",
+		"status.detail.gitInfo":      "■ Git Info",
+		"status.detail.gitManaged":   "Git Managed",
+		"status.detail.worktreeOf":   "Worktree of",
+		"status.detail.submodules":   "Submodules",
+		"status.detail.status":       "Status",
+		"status.detail.branch":       "Branch",
+		"status.detail.upstream":     "Upstream",
+		"status.detail.sync":         "Sync",
+		"status.detail.changes":      "Changes",
+		"status.detail.changeCounts": "staged %d, unstaged %d, untracked %d, conflicted %d",
+		"status.detail.stash":        "Stash",
+		"status.detail.lastCommit":   "Last Commit",
 
 		// TUI Help
 		"status.help.error": "q: Quit | r: Retry",
@@ -134,6 +258,7 @@ This command cannot directly change your shell's current directory. To do that,
 		"config.init.fileCreated":        "Config file created",
 		"config.init.summaryHeader":      "Configuration Summary:",
 		"config.show.title":              "ghqx Configuration",
+		"config.show.profile":            "Profile: %s",
 		"config.prompt.intro1":           "Interactively create ghqx configuration",
 		"config.prompt.intro2":           "Press Enter to use default values",
 		"config.prompt.section.roots":    "■ Workspace Roots",
@@ -146,24 +271,50 @@ This command cannot directly change your shell's current directory. To do that,
 		"config.summary.section.default": "[Default]",
 
 		// Get Command
-		"get.command.short":    "Clone a repository into a workspace",
-		"get.command.long":     "Clones the specified repository into a workspace using ghq. The default workspace can be set with `ghqx mode`.",
-		"get.repositoryExists": "Repository already exists in %s workspace", // Updated from zone
-		"get.continueFetch":    "Continuing fetch...",
-		"get.cloning":          "Cloning %s to %s workspace...",          // Updated from zone
-		"get.cloneSuccess":     "Successfully cloned %s to %s workspace", // Updated from zone
-		"get.flag.workspace":   "target workspace (sandbox/dev/release)", // Updated from get.flag.zone
+		"get.command.short":               "Clone a repository into a workspace",
+		"get.command.long":                "Clones the specified repository into a workspace using ghq, or plain git when ghq is not installed (see clone.backend). The default workspace can be set with `ghqx mode`.\n\nBulk mode:\n  ghqx get -f repos.txt   Clone every repository listed in the file\n  ghqx get -              Read the list from stdin\n\nEach line is \"<repository> [workspace]\"; blank lines and # comments are ignored.\nRepositories are cloned in parallel (--parallel) and a summary is printed at the end.",
+		"get.repositoryExists":            "Repository already exists in %s workspace: %s", // Updated from zone
+		"get.continueFetch":               "Continuing fetch...",
+		"get.cloning":                     "Cloning %s to %s workspace...",          // Updated from zone
+		"get.cloneSuccess":                "Successfully cloned %s to %s workspace", // Updated from zone
+		"get.flag.workspace":              "target workspace (sandbox/dev/release)", // Updated from get.flag.zone
+		"get.flag.file":                   "read repositories from a file, one per line (\"-\" for stdin)",
+		"get.flag.parallel":               "number of repositories to clone in parallel",
+		"get.bulk.openFailed":             "Failed to open repository list: %s",
+		"get.bulk.readFailed":             "Failed to read repository list",
+		"get.bulk.invalidLine":            "Invalid repository list entry on line %d: %s",
+		"get.bulk.invalidLineHint":        "Each line must be \"<repository> [workspace]\"",
+		"get.bulk.fileWithRepository":     "Cannot combine --file with the repository %s",
+		"get.bulk.fileWithRepositoryHint": "Add the repository to the list, or clone it in a separate 'ghqx get'",
+		"get.bulk.skipExisting":           "Skipping %s: already exists in %s workspace: %s",
+		"get.bulk.skipDuplicate":          "Skipping %s: listed more than once",
+		"get.bulk.cloneFailed":            "Failed to clone %s to %s workspace",
+		"get.bulk.summary":                "Cloned: %d, Skipped: %d, Failed: %d",
+		"get.bulk.failed":                 "%d repositories failed to clone",
 
 		// Root Command
 		"root.command.short": "ghqx - ghq-compatible workspace manager",
 		"root.command.long":  "ghqx extends ghq by managing multiple workspaces (dev/release/sandbox).",
 		"root.flag.config":   "config file path",
+		"root.flag.noCache":  "scan the filesystem instead of reading the project index",
+		"root.flag.profile":  "profile to use (default: $GHQX_PROFILE, then the profile set with 'ghqx profile use')",
+		"root.flag.strict":   "fail on every problem 'ghqx config validate' reports (missing or overlapping roots, ...)",
 
 		// Status Command
-		"status.command.short": "Show the state of all projects across all roots",
-		"status.command.long":  "Status quickly visualizes workspace state.\n\nProjects are classified by workspace:\n  sandbox\n  dev\n  release\n\nAdditional information:\n  - Git managed or not\n  - Dirty/clean status", // Updated from zone
-		"status.flag.verbose":  "show detailed information including paths",
-		"status.flag.tui":      "launch interactive TUI mode",
+		"status.command.short":  "Show the state of all projects across all roots",
+		"status.command.long":   "Status quickly visualizes workspace state.\n\nProjects are classified by workspace:\n  sandbox\n  dev\n  release\n\nAdditional information:\n  - Git managed or not\n  - Dirty/clean status\n\nMachine-readable output:\n  --format json|ndjson|csv|tsv  stable, non-localized keys\n  --template '{{.Path}}'       Go template per project\n\nCI check (--check):\n  exit code 2 = uncommitted changes, 4 = unpushed commits, 8 = stashes\n  codes are added together when several apply (e.g. 6)", // Updated from zone
+		"status.flag.verbose":   "show detailed information including paths and directories skipped by exclude patterns",
+		"status.flag.tui":       "launch interactive TUI mode",
+		"status.flag.format":    "output format: table, json, ndjson, csv or tsv",
+		"status.flag.template":  "print each project with a Go template (e.g. '{{.Path}}')",
+		"status.flag.workspace": "only show projects in this workspace",
+		"status.flag.dirty":     "only show git repositories with uncommitted changes",
+		"status.flag.clean":     "only show git repositories without uncommitted changes",
+		"status.flag.git":       "only show git repositories",
+		"status.flag.noGit":     "only show directories that are not git repositories",
+		"status.flag.query":     "only show projects whose name contains every word of the query",
+		"status.flag.sort":      "sort by: name, workspace, modified or last-commit",
+		"status.flag.check":     "only list repositories with uncommitted changes, unpushed commits or stashes and exit non-zero if any (for CI)",
 
 		// Config Command
 		"config.command.short":           "Manage ghqx configuration",
@@ -173,9 +324,73 @@ This command cannot directly change your shell's current directory. To do that,
 		"config.show.command.short":      "Show current configuration",
 		"config.show.command.long":       "Display the current ghqx configuration in human-readable format.\n\nShows:\n  - All configured roots\n  - Default settings",
 		"config.edit.command.short":      "Edit configuration interactively (TUI)",
-		"config.edit.command.long":       "Launch an interactive TUI editor for ghqx configuration.\n\nFeatures:\n  - Visual field editor with descriptions\n  - Real-time validation\n\nKeybindings:\n  ↑↓ or j/k  - Navigate fields\n  Enter       - Edit selected field\n  Esc         - Cancel edit\n  Ctrl+S      - Save configuration\n  q           - Quit (warns if unsaved)\n  Ctrl+Q      - Force quit without saving",
+		"config.edit.command.long":       "Launch an interactive TUI editor for ghqx configuration.\n\nFeatures:\n  - One field per configured root, plus the default root\n  - Add, rename and remove roots\n  - Live path validation (exists, is a directory, does not overlap another root)\n  - Offers to create missing root directories\n\nKeybindings:\n  ↑↓ or j/k  - Navigate fields\n  Enter       - Edit selected field\n  a           - Add a root\n  r           - Rename the selected root\n  d           - Remove the selected root (its directory is kept)\n  Esc         - Cancel edit\n  Ctrl+S      - Save configuration\n  q           - Quit (warns if unsaved)\n  Ctrl+Q      - Force quit without saving",
 		"config.error.fileAlreadyExists": "Config file already exists: %s",
 
+		"config.migrate.command.short": "Rewrite the config file in the current format",
+		"config.migrate.command.long":  "Rewrite the config file in the current format (version 2), where each root is a [roots.<name>] table holding its path and settings.\n\nVersion 1 files ([roots] paths plus [root_options.<name>]) keep working without migration.\nThe original file is kept next to the config file as <file>.v1.bak.",
+		"config.migrate.success":       "Migrated %s from version %d to %d",
+		"config.migrate.backup":        "Backup: %s",
+		"config.migrate.upToDate":      "%s is already version %d",
+
+		"config.validate.command.short": "Check the config file and its root directories",
+		"config.validate.command.long":  "Check the config file and report every problem at once, with a hint for each.\n\nBesides invalid settings, this finds roots that:\n  - do not exist, or are files instead of directories\n  - are not writable (read_only roots excepted)\n  - use relative paths\n  - overlap or nest inside each other\n  - overlap the ghq root ('ghq root --all')\n\nRun any command with --strict to apply the same checks when loading the config.",
+		"config.validate.ok":            "%s is valid",
+		"config.validate.hint":          "hint",
+		"config.history.command.short":  "List the backups of the config file",
+		"config.history.command.long":   "Lists the copies ghqx keeps of the config file, newest first.\n\nEvery time ghqx changes the config file (config set, config edit, mode, profile use, ...),\nthe previous version is copied to config.toml.history next to it. The last %d copies are kept.\nRestore one with 'ghqx config rollback [number]'.",
+		"config.history.empty":          "No backups of %s yet",
+		"config.history.header":         "Backups of %s (newest first):",
+		"config.rollback.command.short": "Restore the config file from a backup",
+		"config.rollback.command.long":  "Restores the config file from a backup listed by 'ghqx config history' (default: 1, the newest).\n\nThe replaced file is backed up too, so 'ghqx config rollback' run twice undoes the rollback.\n\nExamples:\n  ghqx config rollback\n  ghqx config rollback 3",
+		"config.rollback.success":       "Restored %s from the backup of %s",
+
+		"config.get.command.short":        "Print a config value",
+		"config.get.command.long":         "Print the value of a config key, or every key that is set when no key is given.\n\nKeys follow the config file: default.root, clone.backend, scan.max_depth, scan.exclude,\nroots.<name>.path (or roots.<name>) and roots.<name>.<setting> such as layout, type or read_only.\nLists are printed one item per line; use --json for machine-readable output.",
+		"config.get.flag.json":            "print the value as JSON",
+		"config.set.command.short":        "Set a config value",
+		"config.set.command.long":         "Set a config key (see 'ghqx config get') and save the config file.\n\nFlags take true/false, depths a number and lists comma-separated items (e.g. 'tmp/,**/.cache').\nSetting roots.<name> for a new name adds that root, like 'ghqx config add-root'.\nThe file is only written when the resulting config is valid.",
+		"config.set.success":              "Set %s = %s",
+		"config.unset.command.short":      "Reset a config value to its default",
+		"config.unset.command.long":       "Remove a config key from the config file so its default applies again.\nA root's path cannot be unset; use 'ghqx config remove-root'.",
+		"config.unset.success":            "Unset %s",
+		"config.addRoot.command.short":    "Add a workspace root",
+		"config.addRoot.command.long":     "Add a workspace root to the config file and create its directory.\nUse 'ghqx config set roots.<name>.<setting>' afterwards to configure it.",
+		"config.addRoot.success":          "Added root %s (%s)",
+		"config.removeRoot.command.short": "Remove a workspace root",
+		"config.removeRoot.command.long":  "Remove a workspace root and its settings from the config file.\nThe directory and its repositories are left untouched.",
+		"config.removeRoot.success":       "Removed root %s (directory kept: %s)",
+
+		"config.edit.saving":                        "Saving...",
+		"config.edit.saved":                         "Configuration saved",
+		"config.edit.saveFailed":                    "Save failed: %s",
+		"config.edit.unsaved":                       "Unsaved changes. Press Ctrl+S to save or Ctrl+Q to quit without saving",
+		"config.edit.modified":                      "[modified]",
+		"config.edit.configPath":                    "Config: %s",
+		"config.edit.field.root.description":        "Path of the %s workspace",
+		"config.edit.field.defaultRoot.name":        "Default root",
+		"config.edit.field.defaultRoot.description": "Root used when no workspace is given",
+		"config.edit.path.ok":                       "Directory exists",
+		"config.edit.path.missing":                  "Directory does not exist yet",
+		"config.edit.path.empty":                    "Enter a path",
+		"config.edit.path.notDir":                   "Not a directory",
+		"config.edit.path.nested":                   "Overlaps with root %s",
+		"config.edit.prompt.addName":                "New root name",
+		"config.edit.prompt.addPath":                "Path of %s",
+		"config.edit.prompt.rename":                 "Rename %s to",
+		"config.edit.confirm.remove":                "Remove root %s? Its directory is kept. (y/n)",
+		"config.edit.confirm.create":                "%s does not exist. Create it? (y/n)",
+		"config.edit.rootAdded":                     "Added root %s",
+		"config.edit.rootRemoved":                   "Removed root %s",
+		"config.edit.rootRenamed":                   "Renamed %s to %s",
+		"config.edit.directoryCreated":              "Created %s",
+		"config.edit.help.list":                     "↑↓/jk: Move | Enter: Edit | a: Add root | r: Rename | d: Remove | Ctrl+S: Save | q: Quit",
+		"config.edit.help.forceQuit":                " | Ctrl+Q: Quit without saving",
+		"config.edit.help.bool":                     "Enter/Space: Toggle | Esc/q: Cancel",
+		"config.edit.help.selection":                "←→: Move | Enter: Confirm | Esc/q: Cancel",
+		"config.edit.help.input":                    "Enter: Confirm | Esc: Cancel | Backspace: Delete",
+		"config.edit.help.confirm":                  "y: Yes | n/Esc: No",
+
 		// Clean Command
 		"clean.command.short":          "Reset ghqx configuration and managed information",
 		"clean.command.long":           "Resets ghqx to its initial state. Deletes configuration files and all managed repositories.",
@@ -187,6 +402,8 @@ This command cannot directly change your shell's current directory. To do that,
 		"clean.aborted":                "Clean up aborted.",
 		"clean.deleting.roots":         "Deleting root directories...",
 		"clean.deleting.success":       "Deleted",
+		"clean.deleting.readOnly":      "Skipped (read-only)",
+		"clean.warning.readOnly":       "(read-only, kept)",
 		"clean.deleting.config":        "Deleting configuration file...",
 		"clean.deleting.noConfigFound": "Configuration file not found. Skipping deletion.",
 		"clean.deleting.noConfigPath":  "Configuration file path unknown. Skipping deletion.",
@@ -201,5 +418,81 @@ This command cannot directly change your shell's current directory. To do that,
 		"mode.noChange":       "Default mode is already set to the selected one. No change made.",
 		"mode.success":        "Default mode set to: ",
 		"mode.aborted":        "Mode selection aborted.",
+
+		// Promote Command
+		"promote.command.short": "Move a project to another workspace (sandbox → dev → release)",
+		"promote.command.long":  "Moves a project into another workspace root, placing it where that root's layout expects it.\n\nIf no workspace is given, the project is promoted to the next stage:\n  sandbox → dev → release\n\nRepositories with uncommitted changes are refused unless --force is given.\nAn existing project at the target path is never overwritten.",
+		"promote.flag.from":     "source workspace to look the project up in",
+		"promote.flag.force":    "move even if the repository has uncommitted changes",
+		"promote.success":       "Moved %s from %s to %s",
+
+		// Update Command
+		"update.command.short":        "Fetch and fast-forward every repository across workspaces",
+		"update.command.long":         "Fetches every git repository in all workspaces and fast-forwards clean ones to their upstream branch.\n\nRepositories with uncommitted changes are never touched.\nBranches that have diverged from upstream are reported as failed instead of being merged.\n\nUse --fetch-only to update remote-tracking branches without touching any working tree.",
+		"update.flag.workspace":       "only update repositories in this workspace",
+		"update.flag.fetchOnly":       "fetch only; never merge into working trees",
+		"update.flag.parallel":        "number of repositories to update in parallel",
+		"update.header.result":        "Result",
+		"update.status.updated":       "updated",
+		"update.status.up-to-date":    "up-to-date",
+		"update.status.skipped-dirty": "skipped (dirty)",
+		"update.status.no-upstream":   "skipped (no upstream)",
+		"update.status.failed":        "failed",
+		"update.summary":              "Updated: %d, Up-to-date: %d, Skipped: %d, Failed: %d",
+		"update.failed":               "%d repositories failed to update",
+
+		// Exec Command
+		"exec.command.short":  "Run a command in every project",
+		"exec.command.long":   "Runs a command in the directory of every project that matches the filters.\n\nEach output line is prefixed with the project name. Commands run one at a time unless --parallel is given.\nBy default no new command is started after one fails; use --keep-going to run in every project.\n\nThe variables GHQX_PROJECT_NAME, GHQX_PROJECT_PATH and GHQX_WORKSPACE are set for the command.\n\nExamples:\n  ghqx exec -- git status -s\n  ghqx exec --dirty --workspace dev -- git diff --stat\n  ghqx exec -p 4 -k -- make test",
+		"exec.flag.workspace": "only run in projects in this workspace",
+		"exec.flag.dirty":     "only run in git repositories with uncommitted changes",
+		"exec.flag.clean":     "only run in git repositories without uncommitted changes",
+		"exec.flag.git":       "only run in git repositories",
+		"exec.flag.noGit":     "only run in directories that are not git repositories",
+		"exec.flag.query":     "only run in projects whose name contains every word of the query",
+		"exec.flag.parallel":  "number of projects to run the command in at once",
+		"exec.flag.keepGoing": "keep running in the remaining projects after a failure",
+		"exec.noProjects":     "No projects matched the filters",
+		"exec.exitCode":       "%s: exit code %d",
+		"exec.startFailed":    "%s: %v",
+		"exec.summary":        "Succeeded: %d, Failed: %d, Skipped: %d",
+		"exec.failed":         "command failed in %d of %d projects",
+
+		// Index Command
+		"index.command.short":         "Manage the project index cache",
+		"index.command.long":          "ghqx keeps the projects of each root in an index under the user cache directory\n($XDG_CACHE_HOME/ghqx/index on Linux), so cd and status start without walking the roots.\n\nAn entry is rescanned as soon as a directory it was built from changes\n(clone, move, delete), and refreshed in the background once it is an hour old.\nUse --no-cache on any command to bypass the index.",
+		"index.rebuild.command.short": "Rescan roots and rewrite the project index",
+		"index.rebuild.command.long":  "Rescans every root, or only the given workspace, and overwrites its index entry.\n\nExamples:\n  ghqx index rebuild\n  ghqx index rebuild dev",
+		"index.rebuild.done":          "%s: %d projects indexed",
+		"index.rebuild.location":      "Index directory: %s",
+
+		// Worktree command
+		"worktree.command.short":         "Manage git worktrees of workspace projects",
+		"worktree.command.long":          "Creates, lists and removes linked git worktrees, laid out next to other projects\nas <host>/<owner>/<repo>@<branch>, for a second checkout without recloning.",
+		"worktree.add.command.short":     "Create a worktree of a project on a branch",
+		"worktree.add.command.long":      "Creates a linked worktree of a project checked out on a branch.\nAn existing local or remote branch is checked out; otherwise a new branch is created from HEAD.\nThe worktree is created in the project's workspace unless --workspace is given.\nUse --from when the project exists in several workspaces.\n\nExamples:\n  ghqx worktree add github.com/user/repo fix-login\n  ghqx worktree add github.com/user/repo review/42 --workspace sandbox",
+		"worktree.add.flag.from":         "source workspace to look the project up in",
+		"worktree.add.flag.workspace":    "workspace to create the worktree in (default: the project's workspace)",
+		"worktree.add.success":           "Created worktree on %s in %s",
+		"worktree.list.command.short":    "List linked worktrees",
+		"worktree.list.command.long":     "Lists the linked worktrees in all workspaces, or only those of the given repository.",
+		"worktree.list.empty":            "No worktrees found",
+		"worktree.header.repository":     "Repository",
+		"worktree.remove.command.short":  "Remove a linked worktree",
+		"worktree.remove.command.long":   "Removes a linked worktree and its administrative files in the main repository.\nWorktrees with uncommitted changes are refused unless --force is given.\n\nExample:\n  ghqx worktree remove github.com/user/repo@fix-login",
+		"worktree.remove.flag.workspace": "workspace to look the worktree up in",
+		"worktree.remove.flag.force":     "remove even if the worktree has uncommitted changes",
+		"worktree.remove.success":        "Removed worktree %s",
+
+		// Profile command
+		"profile.command.short":      "List and switch config profiles",
+		"profile.command.long":       "A profile is a named set of roots and a default root kept in [profiles.<name>] tables of the config file,\nnext to the top-level roots, which form the default profile. Other settings are shared by all profiles.\n\nThe active profile is chosen by --profile, then $GHQX_PROFILE, then the profile set with 'ghqx profile use'.\nCommands that change the config (config set, config edit, mode, ...) change the active profile.",
+		"profile.list.command.short": "List profiles",
+		"profile.list.command.long":  "Lists the profiles of the config file. The active profile is marked with *.",
+		"profile.list.saved":         "(saved)",
+		"profile.use.command.short":  "Set the profile used by default",
+		"profile.use.command.long":   "Saves the profile used when neither --profile nor $GHQX_PROFILE is given.\n\nExamples:\n  ghqx profile use work\n  ghqx profile use default",
+		"profile.use.success":        "Using profile %s",
+		"profile.use.overridden":     "%s=%s still selects another profile in this shell",
 	})
 }
@@ -12,12 +12,14 @@ func loadJapaneseMessages() {
 - git コマンド`,
 		"doctor.check.config.name":      "config",
 		"doctor.check.config.ok":        "設定ファイルを読み込みました",
+		"doctor.check.config.profile":   "(プロファイル: %s)",
 		"doctor.check.config.fail":      "設定ファイルが見つからないか、不正です",
 		"doctor.check.ghq.name":         "ghq",
 		"doctor.check.ghq.ok":           "ghq が見つかりました: %s",
 		"doctor.check.ghq.fail.found":   "ghq が見つかりません",
 		"doctor.check.ghq.fail.exec":    "ghq --version の実行に失敗しました",
 		"doctor.check.ghq.hint.install": "ghq をインストールしてください: https://github.com/x-motemen/ghq",
+		"doctor.check.ghq.notRequired":  "ghq が見つかりません。リポジトリは git で取得されます",
 		"doctor.check.git.name":         "git",
 		"doctor.check.git.ok":           "git が見つかりました: %s",
 		"doctor.check.git.fail.found":   "git が見つかりません",
@@ -34,27 +36,113 @@ func loadJapaneseMessages() {
 		"version.command.long":  "version は ghqx のバージョン情報を表示します。",
 
 		// Errors (Messages and Hints)
-		"error.config.notFoundAny.message":        "設定ファイルが見つかりません",
-		"error.config.notFoundAny.hint":           "'ghqx config init' を実行して設定ファイルを作成してください",
-		"error.config.notFoundAt.message":         "指定されたパスに設定ファイルが見つかりません",
-		"error.config.notFoundAt.hint":            "--config フラグで指定したパスを確認してください",
-		"error.config.invalidToml.message":        "設定ファイルの解析に失敗しました",
-		"error.config.invalidToml.hint":           "設定ファイルの TOML 構文を確認してください",
-		"error.config.noRoots.message":            "設定にルートが定義されていません",
-		"error.config.noRoots.hint":               "[roots] セクションに少なくとも1つのルートを追加してください",
-		"error.config.invalidDefaultRoot.message": "デフォルトルートが [roots] に存在しません",
-		"error.config.invalidDefaultRoot.hint":    "default.root を定義済みルートのいずれかに設定してください",
+		"error.config.notFoundAny.message":         "設定ファイルが見つかりません",
+		"error.config.notFoundAny.hint":            "'ghqx config init' を実行して設定ファイルを作成してください",
+		"error.config.notFoundAt.message":          "指定されたパスに設定ファイルが見つかりません",
+		"error.config.notFoundAt.hint":             "--config フラグで指定したパスを確認してください",
+		"error.config.invalidToml.message":         "設定ファイルの解析に失敗しました",
+		"error.config.invalidToml.hint":            "設定ファイルの TOML 構文を確認してください",
+		"error.config.unsupportedVersion.message":  "サポートされていない設定バージョンです: %d",
+		"error.config.unsupportedVersion.hint":     "この ghqx が読める設定はバージョン %d までです。ghqx を更新してください",
+		"error.config.noRoots.message":             "設定にルートが定義されていません",
+		"error.config.noRoots.hint":                "[roots] セクションに少なくとも1つのルートを追加してください",
+		"error.config.invalidDefaultRoot.message":  "デフォルトルートが [roots] に存在しません",
+		"error.config.invalidDefaultRoot.hint":     "default.root を定義済みルートのいずれかに設定してください",
+		"error.config.invalidCloneBackend.message": "不明なクローンバックエンドです: %s",
+		"error.config.invalidCloneBackend.hint":    "clone.backend には auto、ghq、git のいずれかを指定してください",
+		"error.config.invalidMaxDepth.message":     "不正なスキャン深さです: %d",
+		"error.config.invalidMaxDepth.hint":        "scan.max_depth には正の数を指定するか、削除してデフォルト (5) を使用してください",
+		"error.config.invalidExclude.message":      "不正な除外パターンです: %s",
+		"error.config.invalidExclude.hint":         "パターン内の括弧を確認してください ('tmp/' や '**/cache' のような gitignore 形式のグロブ)",
+		"error.config.unsetVariable.message":       "ルート %[2]s のパスの環境変数 $%[1]s が設定されていません",
+		"error.config.unsetVariable.hint":          "変数を設定するか、変数を使わずにパスを記述してください",
+		"error.config.invalidLayout.message":       "ルート %[2]s のレイアウト %[1]q は不正です",
+		"error.config.invalidLayout.hint":          "ghq、owner/repo、flat、または 1 から 10 の深さを指定してください",
+		"error.config.invalidType.message":         "ルート %[2]s のワークスペース種別 %[1]q は不正です",
+		"error.config.invalidType.hint":            "英小文字で始まる英小文字・数字・'-'・'_' を指定してください (例: dev, archive)",
+		"error.config.invalidColor.message":        "ルート %[2]s の色 %[1]q は不正です",
+		"error.config.invalidColor.hint":           "ANSI カラー番号 (0-255) または #ff8700 のような 16 進カラーを指定してください",
+		"error.config.unknownRootOptions.message":  "root_options が存在しないルートを参照しています: %s",
+		"error.config.unknownRootOptions.hint":     "[roots] にルートを追加するか、その [root_options] テーブルを削除してください",
+		"error.config.problems.message":            "設定に %d 件の問題があります:",
+		"error.config.problems.hint":               "各問題のヒントは 'ghqx config validate' で確認できます",
+		"error.config.validateFailed.message":      "%[2]s に %[1]d 件の問題が見つかりました",
+		"error.config.validateFailed.hint":         "上記の問題を修正してから、もう一度 'ghqx config validate' を実行してください",
+
+		"error.config.unknownKey.message":        "不明な設定キーです: %s",
+		"error.config.unknownKey.hint":           "'ghqx config get' で設定済みのキーを確認してください (例: default.root, roots.<name>.layout)",
+		"error.config.invalidValue.message":      "%[2]s の値 %[1]q は不正です",
+		"error.config.invalidValue.hint":         "フラグは true/false、深さは数値、リストはカンマ区切りで指定してください",
+		"error.config.unsetRootPath.message":     "%s は削除できません",
+		"error.config.unsetRootPath.hint":        "ルートを削除するには 'ghqx config remove-root' を使用してください",
+		"error.config.invalidRootName.message":   "ルート名が不正です: %q",
+		"error.config.invalidRootName.hint":      "ルート名は空にできず、ドット・スラッシュなどのパス文字を含められません",
+		"error.config.rootExists.message":        "ルートは既に存在します: %s",
+		"error.config.rootExists.hint":           "パスを変更するには 'ghqx config set roots.<name>.path' を使用してください",
+		"error.config.removeDefaultRoot.message": "デフォルトルートは削除できません: %s",
+		"error.config.removeDefaultRoot.hint":    "先に default.root を別のルートに変更してください",
+
+		"error.profile.notFound.message": "プロファイルが見つかりません: %s",
+		"error.profile.notFound.hint":    "利用可能なプロファイル: %s",
+		"error.profile.reserved.message": "[profiles.%s] は使用できません",
+		"error.profile.reserved.hint":    "トップレベルのルートが %s プロファイルです。テーブル名を変更してください",
+
+		"error.config.noHistory.message":      "%s のバックアップはまだありません",
+		"error.config.noHistory.hint":         "ghqx が設定ファイルを変更するたびにバックアップが作成されます",
+		"error.config.backupNotFound.message": "バックアップ %s はありません",
+		"error.config.backupNotFound.hint":    "'ghqx config history' に表示される 1 から %d までの番号を指定してください",
 
 		"error.root.notFound.message":    "ルートが見つかりません: %s",
 		"error.root.notFound.hint":       "config.toml で利用可能なルートを確認してください",
+		"error.root.readOnly.message":    "ルートは読み取り専用です: %s",
+		"error.root.readOnly.hint":       "別のルートを指定するか、設定の read_only を外してください",
 		"error.root.dirNotExist.message": "ルートディレクトリが存在しません: %s",
 		"error.root.dirNotExist.hint":    "ディレクトリを作成するか、config.toml を更新してください",
 
+		"error.root.notDirectory.message": "ルート %s はディレクトリではありません: %s",
+		"error.root.notDirectory.hint":    "ルートにはディレクトリを指定するか、ファイルを移動してください",
+		"error.root.notWritable.message":  "ルート %s に書き込めません: %s",
+		"error.root.notWritable.hint":     "ディレクトリの権限を修正するか、閲覧専用なら read_only = true を設定してください",
+		"error.root.relativePath.message": "ルート %s のパスが相対パスです: %s",
+		"error.root.relativePath.hint":    "絶対パスを指定してください。相対パスは作業ディレクトリによって変わります",
+		"error.root.overlap.message":      "ルート %s と %s が重なっています",
+		"error.root.overlap.hint":         "ルートごとに別のディレクトリを指定してください。入れ子のルートでは同じリポジトリが二重に表示されます",
+		"error.root.overlapsGhq.message":  "ルート %s が ghq のルート %s と重なっています",
+		"error.root.overlapsGhq.hint":     "ルートを ghq のルートの外に移すか、両方のツールでレイアウトが一致するよう ghqx だけでクローンしてください",
+
 		"error.project.notFound.message":    "プロジェクトが見つかりません: %s",
 		"error.project.notFound.hint":       "'ghqx status' で利用可能なプロジェクトを確認してください",
+		"error.project.ambiguous.message":   "プロジェクト %s は複数のワークスペースにあります: %s",
+		"error.project.ambiguous.hint":      "--from <workspace> でどれか一つを指定してください",
 		"error.project.nameInvalid.message": "不正なプロジェクト名です",
 		"error.project.nameInvalid.hint":    "プロジェクト名に禁止文字が含まれています",
 
+		"error.project.alreadyExists.message":   "移動先に同名のプロジェクトが既に存在します",
+		"error.project.alreadyExists.hint":      "既存のプロジェクトを削除するか名前を変更してください",
+		"error.promote.sameWorkspace.message":   "プロジェクトは既にワークスペースにあります: %s",
+		"error.promote.sameWorkspace.hint":      "別の移動先ワークスペースを指定してください",
+		"error.promote.noNextWorkspace.message": "次のワークスペースがありません: %s",
+		"error.promote.noNextWorkspace.hint":    "移動先ワークスペースを明示的に指定してください",
+		"error.promote.layoutMismatch.message":  "%s をワークスペース %s (レイアウト %s) に配置できません",
+		"error.promote.layoutMismatch.hint":     "より深いレイアウトへ移動できるのは origin リモートを持つリポジトリだけです。手動で移動してください",
+		"error.promote.moveFailed.message":      "プロジェクトの移動に失敗しました",
+		"error.promote.moveFailed.hint":         "ディレクトリの権限と空きディスク容量を確認してください",
+
+		"error.worktree.notGit.message":    "git リポジトリではありません: %s",
+		"error.worktree.notGit.hint":       "ワークツリーは git リポジトリからのみ作成できます",
+		"error.worktree.notLinked.message": "リンクされたワークツリーではありません: %s",
+		"error.worktree.notLinked.hint":    "'ghqx worktree list' でワークツリーを確認してください",
+
+		"error.repoRef.invalid.message": "不正なリポジトリ指定です: %s",
+		"error.repoRef.invalid.hint":    "URL、host/owner/repo または owner/repo の形式で指定してください",
+
+		"error.status.invalidFormat.message":   "不明な出力形式です: %s",
+		"error.status.invalidFormat.hint":      "table, json, ndjson, csv, tsv のいずれかを指定してください",
+		"error.status.invalidSort.message":     "不明なソートキーです: %s",
+		"error.status.invalidSort.hint":        "name, workspace, modified, last-commit のいずれかを指定してください",
+		"error.status.invalidTemplate.message": "出力テンプレートが不正です",
+		"error.status.invalidTemplate.hint":    "テンプレートの構文は https://pkg.go.dev/text/template を参照してください",
+
 		"error.argument.required": "引数が必要です",
 
 		"error.git.dirtyRepo.message":     "リポジトリにコミットされていない変更があります",
@@ -62,10 +150,18 @@ func loadJapaneseMessages() {
 		"error.git.timeout.message":       "Git 操作がタイムアウトしました: %s",
 		"error.git.commandFailed.message": "Git 操作に失敗しました: %s",
 
+		"error.vcs.timeout.message":       "%s の操作がタイムアウトしました: %s",
+		"error.vcs.commandFailed.message": "%s の操作に失敗しました: %s",
+
 		"error.fs.readDir.message":   "ディレクトリの読み込みに失敗しました",
 		"error.fs.createDir.message": "ディレクトリの作成に失敗しました",
 		"error.fs.scanRoot.message":  "ルートディレクトリのスキャンに失敗しました",
 
+		"error.index.cacheDir.message": "プロジェクトインデックスのキャッシュディレクトリを特定できません",
+		"error.index.cacheDir.hint":    "XDG_CACHE_HOME (または HOME) を設定するか、--no-cache を使用してください",
+		"error.index.write.message":    "プロジェクトインデックスの書き込みに失敗しました",
+		"error.index.write.hint":       "キャッシュディレクトリの権限を確認するか、--no-cache を使用してください",
+
 		// UI Formatter
 		"ui.error.prefix":          "エラー",
 		"ui.error.hintPrefix":      "ヒント",
@@ -73,14 +169,27 @@ func loadJapaneseMessages() {
 		"ui.error.internalPrefix":  "内部",
 		"ui.error.causePrefix":     "原因",
 		"ui.success.prefix":        "✓",
+		"ui.failure.prefix":        "✗",
 		"ui.warning.prefix":        "⚠",
 		"ui.info.prefix":           "•",
 
 		// Status display strings
-		"status.git.managed":   "管理",
-		"status.git.unmanaged": "未管理",
-		"status.repo.clean":    "変更なし",
-		"status.repo.dirty":    "変更あり",
+		"status.git.managed":       "管理",
+		"status.git.unmanaged":     "未管理",
+		"status.repo.clean":        "変更なし",
+		"status.repo.dirty":        "変更あり",
+		"status.sync.noUpstream":   "upstream なし",
+		"status.time.justNow":      "たった今",
+		"status.time.minutesAgo":   "%d分前",
+		"status.time.hoursAgo":     "%d時間前",
+		"status.time.daysAgo":      "%d日前",
+		"status.check.uncommitted": "未コミットの変更",
+		"status.check.unpushed":    "未プッシュのコミット",
+		"status.check.stashed":     "stash",
+		"status.check.unreadable":  "状態を取得できません",
+		"status.check.ok":          "%d 個のリポジトリはすべて安全です: 未コミットの変更・未プッシュのコミット・stash はありません",
+		"status.excluded.header":   "パターンにより除外 (%d):",
+		"status.check.failed":      "%d / %d 個のリポジトリに保存されていない作業があります",
 
 		// Status table headers
 		"status.header.name":       "Repo",
@@ -89,6 +198,12 @@ func loadJapaneseMessages() {
 		"status.header.status":     "状態",
 		"status.header.root":       "Root",
 		"status.header.path":       "Path",
+		"status.header.branch":     "ブランチ",
+		"status.header.sync":       "同期",
+		"status.header.changes":    "変更",
+		"status.header.stash":      "スタッシュ",
+		"status.header.lastCommit": "最終コミット",
+		"status.header.problems":   "問題",
 
 		// Status messages
 		"status.message.projectsLoaded": "プロジェクトを %d 個読み込みました",
@@ -102,15 +217,24 @@ func loadJapaneseMessages() {
 		"status.title.detail":  "ghqx status - プロジェクト詳細",
 
 		// TUI Detail View
-		"status.detail.basicInfo":  "■ 基本情報",
-		"status.detail.name":       "名前",
-		"status.detail.path":       "パス",
-		"status.detail.workspace":  "ワークスペース", // Renamed from status.detail.zone
-		"status.detail.root":       "ルート",
-		"status.detail.gitInfo":    "■ Git 情報",
-		"status.detail.gitManaged": "Git管理",
-		"status.detail.status":     "状態",
-		"status.detail.branch":     "ブランチ",
+		"status.detail.basicInfo":    "■ 基本情報",
+		"status.detail.name":         "名前",
+		"status.detail.path":         "パス",
+		"status.detail.workspace":    "ワークスペース", // Renamed from status.detail.zone
+		"status.detail.root":         "ルート",
+		"status.detail.description":  "説明",
+		"status.detail.gitInfo":      "■ Git 情報",
+		"status.detail.gitManaged":   "Git管理",
+		"status.detail.worktreeOf":   "ワークツリー元",
+		"status.detail.submodules":   "サブモジュール",
+		"status.detail.status":       "状態",
+		"status.detail.branch":       "ブランチ",
+		"status.detail.upstream":     "Upstream",
+		"status.detail.sync":         "同期",
+		"status.detail.changes":      "変更",
+		"status.detail.changeCounts": "ステージ済み %d, 未ステージ %d, 未追跡 %d, コンフリクト %d",
+		"status.detail.stash":        "スタッシュ",
+		"status.detail.lastCommit":   "最終コミット",
 
 		// TUI Help
 		"status.help.error": "q: 終了 | r: 再試行",
@@ -134,6 +258,7 @@ func loadJapaneseMessages() {
 		"config.init.fileCreated":        "設定ファイルを作成しました",
 		"config.init.summaryHeader":      "設定内容:",
 		"config.show.title":              "ghqx 設定",
+		"config.show.profile":            "プロファイル: %s",
 		"config.prompt.intro1":           "ghqx 設定を対話的に作成します",
 		"config.prompt.intro2":           "各項目でEnterを押すとデフォルト値を使用します",
 		"config.prompt.section.roots":    "■ ワークスペースルート",
@@ -146,24 +271,50 @@ func loadJapaneseMessages() {
 		"config.summary.section.default": "[Default]",
 
 		// Get Command
-		"get.command.short":    "リポジトリを取得し、ワークスペースにクローンします",
-		"get.command.long":     "指定されたリポジトリをghqを使用してワークスペースにクローンします。デフォルトのワークスペースはghqx modeで設定できます。",
-		"get.repositoryExists": "リポジトリは既に %s ワークスペースに存在します", // Updated from zone
-		"get.continueFetch":    "取得を続行します...",
-		"get.cloning":          "リポジトリ %s を %s ワークスペースにクローンしています...", // Updated from zone
-		"get.cloneSuccess":     "%s を %s ワークスペースにクローンしました",           // Updated from zone
-		"get.flag.workspace":   "ターゲットワークスペース (sandbox/dev/release)", // Updated from get.flag.zone
+		"get.command.short":               "リポジトリを取得し、ワークスペースにクローンします",
+		"get.command.long":                "指定されたリポジトリをghq (ghq が無い場合は git) を使用してワークスペースにクローンします (clone.backend を参照)。デフォルトのワークスペースはghqx modeで設定できます。\n\n一括取得:\n  ghqx get -f repos.txt   ファイルに列挙された全リポジトリをクローン\n  ghqx get -              一覧を標準入力から読み込む\n\n各行は \"<repository> [workspace]\" の形式です。空行と # のコメントは無視されます。\nリポジトリは並列に (--parallel) クローンされ、最後に集計が表示されます。",
+		"get.repositoryExists":            "リポジトリは既に %s ワークスペースに存在します: %s", // Updated from zone
+		"get.continueFetch":               "取得を続行します...",
+		"get.cloning":                     "リポジトリ %s を %s ワークスペースにクローンしています...", // Updated from zone
+		"get.cloneSuccess":                "%s を %s ワークスペースにクローンしました",           // Updated from zone
+		"get.flag.workspace":              "ターゲットワークスペース (sandbox/dev/release)", // Updated from get.flag.zone
+		"get.flag.file":                   "リポジトリ一覧をファイルから読み込む (1行に1つ、\"-\" で標準入力)",
+		"get.flag.parallel":               "並列にクローンするリポジトリ数",
+		"get.bulk.openFailed":             "リポジトリ一覧を開けません: %s",
+		"get.bulk.readFailed":             "リポジトリ一覧の読み込みに失敗しました",
+		"get.bulk.invalidLine":            "リポジトリ一覧の %d 行目が不正です: %s",
+		"get.bulk.invalidLineHint":        "各行は \"<repository> [workspace]\" の形式で記述してください",
+		"get.bulk.fileWithRepository":     "--file とリポジトリ %s は同時に指定できません",
+		"get.bulk.fileWithRepositoryHint": "リポジトリを一覧に追加するか、別の 'ghqx get' で取得してください",
+		"get.bulk.skipExisting":           "%s をスキップ: 既に %s ワークスペースに存在します: %s",
+		"get.bulk.skipDuplicate":          "%s をスキップ: 一覧に複数回記述されています",
+		"get.bulk.cloneFailed":            "%s を %s ワークスペースにクローンできませんでした",
+		"get.bulk.summary":                "クローン: %d, スキップ: %d, 失敗: %d",
+		"get.bulk.failed":                 "%d 件のリポジトリのクローンに失敗しました",
 
 		// Root Command
 		"root.command.short": "ghqx - ghq互換ワークスペースマネージャー",
 		"root.command.long":  "ghqx は、複数のワークスペース (dev/release/sandbox) を管理することで ghq を拡張します。",
 		"root.flag.config":   "設定ファイルのパス",
+		"root.flag.noCache":  "プロジェクトインデックスを使わずにファイルシステムをスキャンする",
+		"root.flag.profile":  "使用するプロファイル (デフォルト: $GHQX_PROFILE、次に 'ghqx profile use' で設定したプロファイル)",
+		"root.flag.strict":   "'ghqx config validate' が報告する問題 (存在しない・重なったルートなど) があればエラーにする",
 
 		// Status Command
-		"status.command.short": "すべてのルートにおける全プロジェクトの状態を表示",
-		"status.command.long":  "status はワークスペースの状態を素早く可視化します。\n\nプロジェクトはワークスペースによって分類されます:\n  sandbox\n  dev\n  release\n\n追加情報:\n  - Git管理されているか\n  - Dirty/clean 状態", // Updated from zone
-		"status.flag.verbose":  "パスを含む詳細情報を表示",
-		"status.flag.tui":      "対話型 TUI モードを起動",
+		"status.command.short":  "すべてのルートにおける全プロジェクトの状態を表示",
+		"status.command.long":   "status はワークスペースの状態を素早く可視化します。\n\nプロジェクトはワークスペースによって分類されます:\n  sandbox\n  dev\n  release\n\n追加情報:\n  - Git管理されているか\n  - Dirty/clean 状態\n\n機械可読な出力:\n  --format json|ndjson|csv|tsv  ロケールに依存しない固定キー\n  --template '{{.Path}}'       プロジェクトごとの Go テンプレート\n\nCI チェック (--check):\n  終了コード 2 = 未コミットの変更, 4 = 未プッシュのコミット, 8 = stash\n  複数該当する場合は合計されます (例: 6)", // Updated from zone
+		"status.flag.verbose":   "パスや除外パターンでスキップされたディレクトリを含む詳細情報を表示",
+		"status.flag.tui":       "対話型 TUI モードを起動",
+		"status.flag.format":    "出力形式: table, json, ndjson, csv, tsv",
+		"status.flag.template":  "各プロジェクトを Go テンプレートで出力 (例: '{{.Path}}')",
+		"status.flag.workspace": "このワークスペースのプロジェクトのみ表示する",
+		"status.flag.dirty":     "未コミットの変更がある git リポジトリのみ表示する",
+		"status.flag.clean":     "未コミットの変更がない git リポジトリのみ表示する",
+		"status.flag.git":       "git リポジトリのみ表示する",
+		"status.flag.noGit":     "git リポジトリではないディレクトリのみ表示する",
+		"status.flag.query":     "名前にクエリのすべての単語を含むプロジェクトのみ表示する",
+		"status.flag.sort":      "並び順: name, workspace, modified, last-commit",
+		"status.flag.check":     "未コミットの変更・未プッシュのコミット・stash があるリポジトリのみ表示し、あれば非ゼロで終了する (CI 用)",
 
 		// Config Command
 		"config.command.short":           "ghqx の設定を管理",
@@ -173,9 +324,73 @@ func loadJapaneseMessages() {
 		"config.show.command.short":      "現在の設定を表示",
 		"config.show.command.long":       "現在の ghqx 設定を人間が読みやすい形式で表示します。\n\n表示内容:\n  - 設定されているすべてのルート\n  - デフォルト設定",
 		"config.edit.command.short":      "設定を対話的に編集 (TUI)",
-		"config.edit.command.long":       "ghqx 設定の対話型 TUI エディターを起動します。\n\n機能:\n  - 説明付きの視覚的なフィールドエディター\n  - リアルタイム検証\n\nキーバインド:\n  ↑↓ or j/k  - フィールドをナビゲート\n  Enter       - 選択したフィールドを編集\n  Esc         - 編集をキャンセル\n  Ctrl+S      - 設定を保存\n  q           - 終了 (未保存の場合は警告)\n  Ctrl+Q      - 保存せずに強制終了",
+		"config.edit.command.long":       "ghqx 設定の対話型 TUI エディターを起動します。\n\n機能:\n  - 設定されたルートごとのフィールドとデフォルトルート\n  - ルートの追加・名前変更・削除\n  - パスのリアルタイム検証 (存在するか、ディレクトリか、他のルートと重ならないか)\n  - 存在しないルートディレクトリの作成\n\nキーバインド:\n  ↑↓ or j/k  - フィールドをナビゲート\n  Enter       - 選択したフィールドを編集\n  a           - ルートを追加\n  r           - 選択したルートの名前を変更\n  d           - 選択したルートを削除 (ディレクトリは残ります)\n  Esc         - 編集をキャンセル\n  Ctrl+S      - 設定を保存\n  q           - 終了 (未保存の場合は警告)\n  Ctrl+Q      - 保存せずに強制終了",
 		"config.error.fileAlreadyExists": "設定ファイルが既に存在します: %s",
 
+		"config.migrate.command.short": "設定ファイルを現在の形式に書き換える",
+		"config.migrate.command.long":  "設定ファイルを現在の形式 (バージョン 2) に書き換えます。各ルートはパスと設定を持つ [roots.<name>] テーブルになります。\n\nバージョン 1 のファイル ([roots] のパスと [root_options.<name>]) は移行しなくてもそのまま使えます。\n元のファイルは設定ファイルと同じ場所に <file>.v1.bak として残ります。",
+		"config.migrate.success":       "%s をバージョン %d から %d に移行しました",
+		"config.migrate.backup":        "バックアップ: %s",
+		"config.migrate.upToDate":      "%s はすでにバージョン %d です",
+
+		"config.validate.command.short": "設定ファイルとルートディレクトリを検査",
+		"config.validate.command.long":  "設定ファイルを検査し、すべての問題をヒント付きで一度に報告します。\n\n不正な設定に加えて、次のようなルートを検出します:\n  - 存在しない、またはディレクトリではなくファイル\n  - 書き込めない (read_only のルートを除く)\n  - 相対パス\n  - 互いに重なっている・入れ子になっている\n  - ghq のルート ('ghq root --all') と重なっている\n\n任意のコマンドに --strict を付けると、設定の読み込み時に同じ検査を行います。",
+		"config.validate.ok":            "%s に問題はありません",
+		"config.validate.hint":          "ヒント",
+		"config.history.command.short":  "設定ファイルのバックアップを一覧表示",
+		"config.history.command.long":   "ghqx が保存している設定ファイルのコピーを新しい順に一覧表示します。\n\nghqx が設定ファイルを変更するたび (config set、config edit、mode、profile use など)、\n変更前のファイルを同じディレクトリの config.toml.history にコピーします。直近 %d 件が保存されます。\n'ghqx config rollback [番号]' で復元できます。",
+		"config.history.empty":          "%s のバックアップはまだありません",
+		"config.history.header":         "%s のバックアップ (新しい順):",
+		"config.rollback.command.short": "設定ファイルをバックアップから復元",
+		"config.rollback.command.long":  "'ghqx config history' に表示されるバックアップから設定ファイルを復元します (デフォルト: 1、最新)。\n\n置き換えられるファイルもバックアップされるため、'ghqx config rollback' をもう一度実行すると元に戻せます。\n\n例:\n  ghqx config rollback\n  ghqx config rollback 3",
+		"config.rollback.success":       "%[2]s のバックアップから %[1]s を復元しました",
+
+		"config.get.command.short":        "設定値を表示",
+		"config.get.command.long":         "設定キーの値を表示します。キーを省略すると設定済みのキーをすべて表示します。\n\nキーは設定ファイルに対応します: default.root, clone.backend, scan.max_depth, scan.exclude,\nroots.<name>.path (または roots.<name>)、roots.<name>.<設定> (layout, type, read_only など)。\nリストは 1 行に 1 項目ずつ表示します。機械処理には --json を使用してください。",
+		"config.get.flag.json":            "値を JSON で出力",
+		"config.set.command.short":        "設定値を変更",
+		"config.set.command.long":         "設定キー ('ghqx config get' を参照) を変更し、設定ファイルを保存します。\n\nフラグは true/false、深さは数値、リストはカンマ区切り (例: 'tmp/,**/.cache') で指定します。\n新しい名前で roots.<name> を設定すると、'ghqx config add-root' と同様にルートを追加します。\n変更後の設定が正しい場合のみファイルを書き込みます。",
+		"config.set.success":              "%s = %s を設定しました",
+		"config.unset.command.short":      "設定値をデフォルトに戻す",
+		"config.unset.command.long":       "設定ファイルからキーを削除し、デフォルト値に戻します。\nルートのパスは削除できません。'ghqx config remove-root' を使用してください。",
+		"config.unset.success":            "%s を削除しました",
+		"config.addRoot.command.short":    "ワークスペースルートを追加",
+		"config.addRoot.command.long":     "設定ファイルにワークスペースルートを追加し、そのディレクトリを作成します。\n設定は 'ghqx config set roots.<name>.<設定>' で変更してください。",
+		"config.addRoot.success":          "ルート %s (%s) を追加しました",
+		"config.removeRoot.command.short": "ワークスペースルートを削除",
+		"config.removeRoot.command.long":  "設定ファイルからワークスペースルートとその設定を削除します。\nディレクトリとリポジトリはそのまま残ります。",
+		"config.removeRoot.success":       "ルート %s を削除しました (ディレクトリは残しています: %s)",
+
+		"config.edit.saving":                        "保存中...",
+		"config.edit.saved":                         "設定を保存しました",
+		"config.edit.saveFailed":                    "保存失敗: %s",
+		"config.edit.unsaved":                       "未保存の変更があります。Ctrl+S で保存するか、Ctrl+Q で破棄して終了",
+		"config.edit.modified":                      "[変更あり]",
+		"config.edit.configPath":                    "設定: %s",
+		"config.edit.field.root.description":        "%s ワークスペースのパス",
+		"config.edit.field.defaultRoot.name":        "デフォルトルート",
+		"config.edit.field.defaultRoot.description": "デフォルトで使用するルート",
+		"config.edit.path.ok":                       "ディレクトリが存在します",
+		"config.edit.path.missing":                  "ディレクトリはまだ存在しません",
+		"config.edit.path.empty":                    "パスを入力してください",
+		"config.edit.path.notDir":                   "ディレクトリではありません",
+		"config.edit.path.nested":                   "ルート %s と重なっています",
+		"config.edit.prompt.addName":                "新しいルート名",
+		"config.edit.prompt.addPath":                "%s のパス",
+		"config.edit.prompt.rename":                 "%s の新しい名前",
+		"config.edit.confirm.remove":                "ルート %s を削除しますか？ディレクトリは残ります。(y/n)",
+		"config.edit.confirm.create":                "%s は存在しません。作成しますか？(y/n)",
+		"config.edit.rootAdded":                     "ルート %s を追加しました",
+		"config.edit.rootRemoved":                   "ルート %s を削除しました",
+		"config.edit.rootRenamed":                   "%s を %s に変更しました",
+		"config.edit.directoryCreated":              "%s を作成しました",
+		"config.edit.help.list":                     "↑↓/jk: 移動 | Enter: 編集 | a: ルート追加 | r: 名前変更 | d: 削除 | Ctrl+S: 保存 | q: 終了",
+		"config.edit.help.forceQuit":                " | Ctrl+Q: 破棄して終了",
+		"config.edit.help.bool":                     "Enter/Space: 切替 | Esc/q: キャンセル",
+		"config.edit.help.selection":                "←→: 移動 | Enter: 確定 | Esc/q: キャンセル",
+		"config.edit.help.input":                    "Enter: 確定 | Esc: キャンセル | Backspace: 削除",
+		"config.edit.help.confirm":                  "y: はい | n/Esc: いいえ",
+
 		// Clean Command
 		"clean.command.short":          "ghqx の設定や管理情報をリセット",
 		"clean.command.long":           "ghqx を初期状態に戻します。設定ファイルと、管理下の全リポジトリを削除します。",
@@ -187,6 +402,8 @@ func loadJapaneseMessages() {
 		"clean.aborted":                "クリーンアップを中止しました。",
 		"clean.deleting.roots":         "ルートディレクトリを削除中...",
 		"clean.deleting.success":       "削除完了",
+		"clean.deleting.readOnly":      "スキップ (読み取り専用)",
+		"clean.warning.readOnly":       "(読み取り専用のため残します)",
 		"clean.deleting.config":        "設定ファイルを削除中...",
 		"clean.deleting.noConfigFound": "設定ファイルが見つかりません。削除をスキップします。",
 		"clean.deleting.noConfigPath":  "設定ファイルのパスが不明です。削除をスキップします。",
@@ -201,5 +418,81 @@ func loadJapaneseMessages() {
 		"mode.noChange":       "デフォルトモードは既に選択されたモードに設定されています。変更はありません。",
 		"mode.success":        "デフォルトモードを次のものに設定しました: ",
 		"mode.aborted":        "モード選択は中止されました。",
+
+		// Promote Command
+		"promote.command.short": "プロジェクトを別のワークスペースへ移動 (sandbox → dev → release)",
+		"promote.command.long":  "プロジェクトを移動先ルートのレイアウトに従って別のワークスペースルートへ移動します。\n\nワークスペースを省略した場合、次のステージへ昇格します:\n  sandbox → dev → release\n\n未コミットの変更があるリポジトリは --force を指定しない限り移動しません。\n移動先に既存のプロジェクトがある場合は上書きしません。",
+		"promote.flag.from":     "プロジェクトを検索する移動元ワークスペース",
+		"promote.flag.force":    "未コミットの変更があっても移動する",
+		"promote.success":       "%s を %s から %s へ移動しました",
+
+		// Update Command
+		"update.command.short":        "全ワークスペースのリポジトリを fetch して fast-forward",
+		"update.command.long":         "全ワークスペースの git リポジトリを fetch し、変更のないリポジトリを upstream ブランチへ fast-forward します。\n\n未コミットの変更があるリポジトリには一切触れません。\nupstream と分岐しているブランチはマージせず失敗として報告します。\n\n--fetch-only を指定すると作業ツリーに触れずリモート追跡ブランチのみ更新します。",
+		"update.flag.workspace":       "このワークスペースのリポジトリのみ更新する",
+		"update.flag.fetchOnly":       "fetch のみ行い、作業ツリーへはマージしない",
+		"update.flag.parallel":        "並列に更新するリポジトリ数",
+		"update.header.result":        "結果",
+		"update.status.updated":       "更新",
+		"update.status.up-to-date":    "最新",
+		"update.status.skipped-dirty": "スキップ (変更あり)",
+		"update.status.no-upstream":   "スキップ (upstream なし)",
+		"update.status.failed":        "失敗",
+		"update.summary":              "更新: %d, 最新: %d, スキップ: %d, 失敗: %d",
+		"update.failed":               "%d 件のリポジトリの更新に失敗しました",
+
+		// Exec Command
+		"exec.command.short":  "全プロジェクトでコマンドを実行",
+		"exec.command.long":   "フィルタに一致する各プロジェクトのディレクトリでコマンドを実行します。\n\n出力の各行にはプロジェクト名が付きます。--parallel を指定しない限り 1 つずつ実行します。\nデフォルトでは失敗した時点で新しいコマンドを開始しません。全プロジェクトで実行するには --keep-going を指定してください。\n\nコマンドには GHQX_PROJECT_NAME、GHQX_PROJECT_PATH、GHQX_WORKSPACE が設定されます。\n\n例:\n  ghqx exec -- git status -s\n  ghqx exec --dirty --workspace dev -- git diff --stat\n  ghqx exec -p 4 -k -- make test",
+		"exec.flag.workspace": "このワークスペースのプロジェクトでのみ実行する",
+		"exec.flag.dirty":     "未コミットの変更がある git リポジトリでのみ実行する",
+		"exec.flag.clean":     "未コミットの変更がない git リポジトリでのみ実行する",
+		"exec.flag.git":       "git リポジトリでのみ実行する",
+		"exec.flag.noGit":     "git リポジトリではないディレクトリでのみ実行する",
+		"exec.flag.query":     "名前にクエリのすべての単語を含むプロジェクトでのみ実行する",
+		"exec.flag.parallel":  "同時にコマンドを実行するプロジェクト数",
+		"exec.flag.keepGoing": "失敗後も残りのプロジェクトで実行を続ける",
+		"exec.noProjects":     "フィルタに一致するプロジェクトがありません",
+		"exec.exitCode":       "%s: 終了コード %d",
+		"exec.startFailed":    "%s: %v",
+		"exec.summary":        "成功: %d, 失敗: %d, スキップ: %d",
+		"exec.failed":         "%d / %d プロジェクトでコマンドが失敗しました",
+
+		// Index Command
+		"index.command.short":         "プロジェクトインデックスのキャッシュを管理",
+		"index.command.long":          "ghqx は各ルートのプロジェクトをユーザーキャッシュディレクトリ内のインデックス\n(Linux では $XDG_CACHE_HOME/ghqx/index) に保存し、cd や status がルートを走査せずに開始できるようにします。\n\nエントリは元になったディレクトリが変更される (clone・移動・削除) とすぐに再スキャンされ、\n1 時間経過するとバックグラウンドで更新されます。\n任意のコマンドで --no-cache を指定するとインデックスを使用しません。",
+		"index.rebuild.command.short": "ルートを再スキャンしてプロジェクトインデックスを再作成",
+		"index.rebuild.command.long":  "すべてのルート、または指定したワークスペースを再スキャンし、インデックスのエントリを上書きします。\n\n例:\n  ghqx index rebuild\n  ghqx index rebuild dev",
+		"index.rebuild.done":          "%s: %d 個のプロジェクトをインデックスしました",
+		"index.rebuild.location":      "インデックスディレクトリ: %s",
+
+		// Worktree command
+		"worktree.command.short":         "ワークスペース内のプロジェクトの git ワークツリーを管理",
+		"worktree.command.long":          "リンクされた git ワークツリーを作成・一覧表示・削除します。\nワークツリーは <host>/<owner>/<repo>@<branch> として他のプロジェクトと同じ場所に配置され、\n再クローンせずに 2 つ目のチェックアウトを用意できます。",
+		"worktree.add.command.short":     "プロジェクトのワークツリーをブランチで作成",
+		"worktree.add.command.long":      "プロジェクトのリンクされたワークツリーを作成し、ブランチをチェックアウトします。\n既存のローカルまたはリモートブランチはそのままチェックアウトし、存在しない場合は HEAD から新しいブランチを作成します。\n--workspace を指定しない場合、プロジェクトと同じワークスペースに作成します。\nプロジェクトが複数のワークスペースにある場合は --from で指定してください。\n\n例:\n  ghqx worktree add github.com/user/repo fix-login\n  ghqx worktree add github.com/user/repo review/42 --workspace sandbox",
+		"worktree.add.flag.from":         "プロジェクトを検索する作成元ワークスペース",
+		"worktree.add.flag.workspace":    "ワークツリーを作成するワークスペース（デフォルト: プロジェクトのワークスペース）",
+		"worktree.add.success":           "%s のワークツリーを %s に作成しました",
+		"worktree.list.command.short":    "リンクされたワークツリーを一覧表示",
+		"worktree.list.command.long":     "すべてのワークスペースのリンクされたワークツリー、または指定したリポジトリのワークツリーのみを一覧表示します。",
+		"worktree.list.empty":            "ワークツリーが見つかりません",
+		"worktree.header.repository":     "リポジトリ",
+		"worktree.remove.command.short":  "リンクされたワークツリーを削除",
+		"worktree.remove.command.long":   "リンクされたワークツリーと、メインリポジトリ内の管理ファイルを削除します。\n未コミットの変更があるワークツリーは --force を指定しない限り削除しません。\n\n例:\n  ghqx worktree remove github.com/user/repo@fix-login",
+		"worktree.remove.flag.workspace": "ワークツリーを検索するワークスペース",
+		"worktree.remove.flag.force":     "未コミットの変更があっても削除する",
+		"worktree.remove.success":        "ワークツリー %s を削除しました",
+
+		// Profile command
+		"profile.command.short":      "設定プロファイルの一覧表示と切り替え",
+		"profile.command.long":       "プロファイルは、設定ファイルの [profiles.<name>] テーブルに保存するルートとデフォルトルートの組です。\nトップレベルのルートは default プロファイルになります。その他の設定はすべてのプロファイルで共通です。\n\n有効なプロファイルは --profile、$GHQX_PROFILE、'ghqx profile use' で設定したプロファイルの順に決まります。\n設定を変更するコマンド (config set、config edit、mode など) は有効なプロファイルを変更します。",
+		"profile.list.command.short": "プロファイルを一覧表示",
+		"profile.list.command.long":  "設定ファイルのプロファイルを一覧表示します。有効なプロファイルには * が付きます。",
+		"profile.list.saved":         "(保存済み)",
+		"profile.use.command.short":  "デフォルトで使用するプロファイルを設定",
+		"profile.use.command.long":   "--profile も $GHQX_PROFILE も指定されていないときに使用するプロファイルを保存します。\n\n例:\n  ghqx profile use work\n  ghqx profile use default",
+		"profile.use.success":        "プロファイル %s を使用します",
+		"profile.use.overridden":     "このシェルでは %s=%s により別のプロファイルが選択されます",
 	})
 }
@@ -0,0 +1,264 @@
+package index
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/mi8bi/ghqx/internal/domain"
+	"github.com/mi8bi/ghqx/internal/fs"
+)
+
+// formatVersion is bumped whenever the entry layout or the scanner's output changes,
+// so entries written by other versions are rescanned instead of misread.
+const formatVersion = 3
+
+// DefaultMaxAge is how old a valid entry may get before it is refreshed in the background.
+// Directory mtimes catch clones, moves and deletions; the age limit catches the rest.
+const DefaultMaxAge = time.Hour
+
+// tempPattern names the temporary files entries are written to before being renamed into place.
+const tempPattern = ".entry-*"
+
+// staleTempAge is how old a temporary file must be before it is treated as
+// left behind by an interrupted write. Writing an entry takes milliseconds,
+// so files this old are never still in use by another process.
+const staleTempAge = 10 * time.Minute
+
+// Index is an on-disk cache of scan results, one file per root.
+// An entry is used as long as none of the directories read by the scan
+// have changed; otherwise the root is rescanned and the entry rewritten.
+// Writing the cache is best-effort: failures only cost a rescan next time.
+type Index struct {
+	// dir is the directory holding the entry files
+	dir string
+	// MaxAge triggers a background refresh of entries older than this (0 disables it)
+	MaxAge time.Duration
+	// scanner performs the actual filesystem scans
+	scanner *fs.Scanner
+	// refreshes tracks background refreshes so Wait can let them finish
+	refreshes sync.WaitGroup
+	// sweep removes stale temporary files once, before the first write
+	sweep sync.Once
+}
+
+// entry is the cached scan result of one root.
+type entry struct {
+	Version   int              `json:"version"`
+	Scanner   string           `json:"scanner"` // Scanner settings the entry was built with
+	Root      string           `json:"root"`
+	Path      string           `json:"path"`
+	ScannedAt time.Time        `json:"scanned_at"`
+	Dirs      map[string]int64 `json:"dirs"` // Directory path -> mtime (UnixNano) at scan time
+	Projects  []domain.Project `json:"projects"`
+	Excluded  []fs.Exclusion   `json:"excluded,omitempty"`
+}
+
+// New creates an index stored in dir that scans roots with scanner.
+func New(dir string, scanner *fs.Scanner) *Index {
+	return &Index{
+		dir:     dir,
+		MaxAge:  DefaultMaxAge,
+		scanner: scanner,
+	}
+}
+
+// DefaultDir returns the index directory under the user's cache directory
+// ($XDG_CACHE_HOME/ghqx/index on Linux).
+func DefaultDir() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", domain.ErrIndexCacheDir(err)
+	}
+	return filepath.Join(cacheDir, "ghqx", "index"), nil
+}
+
+// Dir returns the directory holding the entry files.
+func (idx *Index) Dir() string {
+	return idx.dir
+}
+
+// Scan scans a root through the cache: a valid entry is returned without touching
+// the root's subtree. An outdated entry causes a synchronous rescan, while an entry
+// that is only older than MaxAge is returned as is and refreshed in the background.
+// Results served from the cache carry no Dirs.
+func (idx *Index) Scan(rootName domain.RootName, rootPath string) (*fs.ScanResult, error) {
+	signature := idx.scanner.Signature(rootName, rootPath)
+	if e, ok := idx.load(rootName, rootPath); ok && e.Scanner == signature && e.valid() {
+		if idx.MaxAge > 0 && time.Since(e.ScannedAt) > idx.MaxAge {
+			idx.refreshes.Add(1)
+			go func() {
+				defer idx.refreshes.Done()
+				_, _ = idx.Rebuild(rootName, rootPath)
+			}()
+		}
+		return &fs.ScanResult{Projects: e.Projects, Excluded: e.Excluded}, nil
+	}
+
+	result, e, err := idx.scan(rootName, rootPath)
+	if err != nil {
+		return nil, err
+	}
+	_ = idx.save(e) // Best-effort: the scan result is still correct
+	return result, nil
+}
+
+// Rebuild rescans a root and overwrites its entry.
+func (idx *Index) Rebuild(rootName domain.RootName, rootPath string) ([]domain.Project, error) {
+	result, e, err := idx.scan(rootName, rootPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := idx.save(e); err != nil {
+		return nil, err
+	}
+	return result.Projects, nil
+}
+
+// scan scans a root and builds the entry recording its directories.
+func (idx *Index) scan(rootName domain.RootName, rootPath string) (*fs.ScanResult, entry, error) {
+	signature := idx.scanner.Signature(rootName, rootPath)
+	scanStart := time.Now()
+	result, err := idx.scanner.Scan(rootName, rootPath)
+	if err != nil {
+		return nil, entry{}, err
+	}
+
+	e := entry{
+		Version:   formatVersion,
+		Scanner:   signature,
+		Root:      string(rootName),
+		Path:      rootPath,
+		ScannedAt: scanStart,
+		Dirs:      make(map[string]int64, len(result.Dirs)),
+		Projects:  result.Projects,
+		Excluded:  result.Excluded,
+	}
+	for _, dir := range result.Dirs {
+		e.Dirs[dir] = dirModTime(dir, scanStart)
+	}
+	return result, e, nil
+}
+
+// Wait blocks until all background refreshes have finished.
+func (idx *Index) Wait() {
+	idx.refreshes.Wait()
+}
+
+// WaitTimeout blocks until all background refreshes have finished or timeout
+// has passed, and reports whether they finished. A refresh left running is
+// simply lost when the process exits: save is atomic, so the old entry stays
+// in use and is refreshed again on a later run, whose first write also
+// removes the temporary file the lost refresh may have left behind.
+func (idx *Index) WaitTimeout(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		idx.refreshes.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// dirModTime returns the mtime of dir for change detection.
+// Directories modified during the scan get 0, so the entry is treated as
+// outdated next time instead of hiding a change the scan may have missed.
+func dirModTime(dir string, scanStart time.Time) int64 {
+	info, err := os.Stat(dir)
+	if err != nil || !info.ModTime().Before(scanStart) {
+		return 0
+	}
+	return info.ModTime().UnixNano()
+}
+
+// valid reports whether every directory read by the scan is unchanged.
+func (e *entry) valid() bool {
+	if e.Version != formatVersion || len(e.Dirs) == 0 {
+		return false
+	}
+	for dir, modTime := range e.Dirs {
+		info, err := os.Stat(dir)
+		if err != nil || modTime == 0 || info.ModTime().UnixNano() != modTime {
+			return false
+		}
+	}
+	return true
+}
+
+// entryPath returns the entry file of a root. Both the name and the path
+// are part of the key, so renaming or moving a root never reuses an entry.
+func (idx *Index) entryPath(rootName domain.RootName, rootPath string) string {
+	sum := sha256.Sum256([]byte(string(rootName) + "\x00" + rootPath))
+	return filepath.Join(idx.dir, hex.EncodeToString(sum[:8])+".json")
+}
+
+// load reads the entry of a root. Missing or unreadable entries report false.
+func (idx *Index) load(rootName domain.RootName, rootPath string) (*entry, bool) {
+	data, err := os.ReadFile(idx.entryPath(rootName, rootPath))
+	if err != nil {
+		return nil, false
+	}
+
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, false
+	}
+	if e.Root != string(rootName) || e.Path != rootPath {
+		return nil, false
+	}
+	return &e, true
+}
+
+// save writes an entry atomically, so concurrent readers and interrupted
+// background refreshes never leave a truncated file behind.
+func (idx *Index) save(e entry) error {
+	if err := os.MkdirAll(idx.dir, 0755); err != nil {
+		return domain.ErrIndexWrite(err)
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return domain.ErrIndexWrite(err)
+	}
+
+	idx.sweep.Do(idx.removeStaleTemps)
+
+	tmp, err := os.CreateTemp(idx.dir, tempPattern)
+	if err != nil {
+		return domain.ErrIndexWrite(err)
+	}
+	defer os.Remove(tmp.Name()) // No-op after a successful rename
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return domain.ErrIndexWrite(err)
+	}
+	if err := tmp.Close(); err != nil {
+		return domain.ErrIndexWrite(err)
+	}
+	if err := os.Rename(tmp.Name(), idx.entryPath(domain.RootName(e.Root), e.Path)); err != nil {
+		return domain.ErrIndexWrite(err)
+	}
+	return nil
+}
+
+// removeStaleTemps deletes temporary files older than staleTempAge, which
+// writes cut short by the process exiting (see WaitTimeout) leave behind.
+// Errors are ignored: a leftover file only costs disk space.
+func (idx *Index) removeStaleTemps() {
+	matches, _ := filepath.Glob(filepath.Join(idx.dir, tempPattern))
+	for _, path := range matches {
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > staleTempAge {
+			_ = os.Remove(path)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package index
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mi8bi/ghqx/internal/domain"
+	"github.com/mi8bi/ghqx/internal/fs"
+)
+
+// baseTime is the mtime makeRepos gives every directory it touches.
+var baseTime = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// makeRepos creates host/owner/repo directories under root and resets the
+// mtimes of them and their parents to baseTime. Changes made this way are
+// invisible to mtime detection, while any other change is always detected.
+func makeRepos(t *testing.T, root string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		dir := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		for d := dir; d != filepath.Dir(root); d = filepath.Dir(d) {
+			if err := os.Chtimes(d, baseTime, baseTime); err != nil {
+				t.Fatalf("chtimes: %v", err)
+			}
+		}
+	}
+}
+
+func TestScanUsesCacheUntilDirectoriesChange(t *testing.T) {
+	root := t.TempDir()
+	makeRepos(t, root, "github.com/user/a")
+
+	idx := New(t.TempDir(), fs.NewScanner())
+	idx.MaxAge = 0
+
+	projects, err := scanProjects(idx, "dev", root)
+	if err != nil || len(projects) != 1 {
+		t.Fatalf("first scan: %v, %v", projects, err)
+	}
+
+	// A new repository hidden from mtime detection proves the cache is used
+	makeRepos(t, root, "github.com/user/b")
+	if projects, _ := scanProjects(idx, "dev", root); len(projects) != 1 {
+		t.Fatalf("expected cached result, got %d projects", len(projects))
+	}
+
+	// A real change to a scanned directory invalidates the entry
+	if err := os.MkdirAll(filepath.Join(root, "github.com", "user", "c"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if projects, _ := scanProjects(idx, "dev", root); len(projects) != 3 {
+		t.Fatalf("expected rescan after change, got %d projects", len(projects))
+	}
+}
+
+func TestRebuildAndBackgroundRefresh(t *testing.T) {
+	root := t.TempDir()
+	makeRepos(t, root, "github.com/user/a")
+
+	idx := New(t.TempDir(), fs.NewScanner())
+	if _, err := idx.Rebuild("dev", root); err != nil {
+		t.Fatalf("Rebuild failed: %v", err)
+	}
+
+	// Hide a change from mtime detection, then let the age limit catch it
+	makeRepos(t, root, "github.com/user/b")
+
+	idx.MaxAge = time.Nanosecond
+	if projects, _ := scanProjects(idx, "dev", root); len(projects) != 1 {
+		t.Fatalf("expected stale result while refreshing, got %d projects", len(projects))
+	}
+	idx.Wait()
+
+	idx.MaxAge = 0
+	if projects, _ := scanProjects(idx, "dev", root); len(projects) != 2 {
+		t.Fatalf("expected refreshed result, got %d projects", len(projects))
+	}
+}
+
+func TestWaitTimeout(t *testing.T) {
+	idx := New(t.TempDir(), fs.NewScanner())
+	if !idx.WaitTimeout(time.Second) {
+		t.Fatal("expected WaitTimeout to return at once without refreshes")
+	}
+
+	// A refresh that never finishes must not block past the timeout
+	idx.refreshes.Add(1)
+	defer idx.refreshes.Done()
+	start := time.Now()
+	if idx.WaitTimeout(10 * time.Millisecond) {
+		t.Fatal("expected WaitTimeout to give up on a running refresh")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("WaitTimeout blocked for %v", elapsed)
+	}
+}
+
+func TestSaveRemovesStaleTempFiles(t *testing.T) {
+	root := t.TempDir()
+	makeRepos(t, root, "github.com/user/a")
+
+	dir := t.TempDir()
+	stale := filepath.Join(dir, ".entry-stale")
+	fresh := filepath.Join(dir, ".entry-fresh")
+	for _, path := range []string{stale, fresh} {
+		if err := os.WriteFile(path, []byte("{"), 0644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	// Left behind by a refresh the process did not wait for
+	if err := os.Chtimes(stale, baseTime, baseTime); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+
+	idx := New(dir, fs.NewScanner())
+	if _, err := idx.Rebuild("dev", root); err != nil {
+		t.Fatalf("Rebuild failed: %v", err)
+	}
+
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Error("expected the stale temp file to be removed")
+	}
+	// A recent file may belong to another process still writing it
+	if _, err := os.Stat(fresh); err != nil {
+		t.Errorf("expected the recent temp file to be kept: %v", err)
+	}
+}
+
+func TestScanKeysEntriesByRoot(t *testing.T) {
+	dev := t.TempDir()
+	release := t.TempDir()
+	makeRepos(t, dev, "github.com/user/a")
+	makeRepos(t, release, "github.com/user/b", "github.com/user/c")
+
+	idx := New(t.TempDir(), fs.NewScanner())
+	if projects, _ := scanProjects(idx, "dev", dev); len(projects) != 1 {
+		t.Fatalf("dev: got %d projects", len(projects))
+	}
+	if projects, _ := scanProjects(idx, "release", release); len(projects) != 2 {
+		t.Fatalf("release: got %d projects", len(projects))
+	}
+	if idx.entryPath("dev", dev) == idx.entryPath("dev", release) {
+		t.Error("entries of different paths must not share a file")
+	}
+}
+
+func TestScanMissingRootAndUnwritableCache(t *testing.T) {
+	idx := New(t.TempDir(), fs.NewScanner())
+	if _, err := scanProjects(idx, "dev", filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected error for missing root")
+	}
+
+	// The cache directory is a file: scanning still works, rebuilding reports the error
+	blocker := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(blocker, nil, 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	root := t.TempDir()
+	makeRepos(t, root, "github.com/user/a")
+
+	idx = New(blocker, fs.NewScanner())
+	if projects, err := scanProjects(idx, "dev", root); err != nil || len(projects) != 1 {
+		t.Errorf("Scan with unwritable cache: %v, %v", projects, err)
+	}
+	if _, err := idx.Rebuild("dev", root); err == nil {
+		t.Error("expected Rebuild to report the write error")
+	}
+}
+
+func TestScanDiscardsEntriesOfOtherScannerSettings(t *testing.T) {
+	root := t.TempDir()
+	makeRepos(t, root, "gitlab.com/group/sub/repo/.git")
+
+	dir := t.TempDir()
+	if projects, _ := scanProjects(New(dir, fs.NewScanner()), "dev", root); len(projects) != 1 || projects[0].Name != "gitlab.com/group/sub/repo" {
+		t.Fatalf("default depth: %v", projects)
+	}
+
+	shallow := fs.NewScanner()
+	shallow.SetMaxDepth(3)
+	idx := New(dir, shallow)
+	idx.MaxAge = 0
+	if projects, _ := scanProjects(idx, "dev", root); len(projects) != 1 || projects[0].Name != "gitlab.com/group/sub" {
+		t.Fatalf("expected rescan with depth 3, got %v", projects)
+	}
+}
+
+// scanProjects returns only the projects of Index.Scan.
+func scanProjects(idx *Index, rootName domain.RootName, rootPath string) ([]domain.Project, error) {
+	result, err := idx.Scan(rootName, rootPath)
+	if err != nil {
+		return nil, err
+	}
+	return result.Projects, nil
+}
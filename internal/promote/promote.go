@@ -0,0 +1,182 @@
+package promote
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mi8bi/ghqx/internal/config"
+	"github.com/mi8bi/ghqx/internal/domain"
+	"github.com/mi8bi/ghqx/internal/fs"
+	"github.com/mi8bi/ghqx/internal/git"
+	"github.com/mi8bi/ghqx/internal/status"
+)
+
+// Service moves projects between workspace roots.
+// It places the project where the target root's layout expects it, so the
+// project stays ghq-compatible after being promoted (e.g. sandbox -> dev -> release).
+type Service struct {
+	// cfg holds the application configuration with root paths
+	cfg *config.Config
+	// status locates the project to move
+	status *status.Service
+	// git checks for uncommitted changes before moving
+	git *git.Client
+}
+
+// NewService creates a new promote service.
+func NewService(cfg *config.Config, statusService *status.Service) *Service {
+	return &Service{
+		cfg:    cfg,
+		status: statusService,
+		// Moving is a one-shot operation, so allow more time than the status scan does
+		git: git.NewClientWithTimeout(10 * time.Second),
+	}
+}
+
+// Options configures a promote operation.
+type Options struct {
+	// Project is the fully qualified project name (e.g. "github.com/user/repo")
+	Project string
+	// From restricts the lookup to a single source root (optional)
+	From string
+	// To is the target root name. If empty, the next lifecycle stage is used.
+	To string
+	// Force allows moving repositories with uncommitted changes
+	Force bool
+}
+
+// Result describes a completed promote operation.
+type Result struct {
+	// Project is the project as it was found before the move
+	Project domain.Project
+	// TargetRoot is the root the project was moved into
+	TargetRoot string
+	// TargetPath is the new absolute path of the project
+	TargetPath string
+}
+
+// Promote moves a project into another workspace root under the same relative name.
+// Dirty repositories are refused unless Force is set, an existing target path
+// is never overwritten, and read-only roots are neither source nor target.
+func (s *Service) Promote(opts Options) (*Result, error) {
+	if opts.Project == "" {
+		return nil, domain.ErrArgumentRequired
+	}
+
+	if opts.From != "" {
+		if _, ok := s.cfg.GetRoot(opts.From); !ok {
+			return nil, domain.ErrRootNotFound(opts.From)
+		}
+	}
+
+	project, err := s.status.FindProject(opts.Project, opts.From)
+	if err != nil {
+		return nil, err
+	}
+
+	targetRoot, err := s.resolveTargetRoot(project, opts.To)
+	if err != nil {
+		return nil, err
+	}
+	if targetRoot == string(project.Root) {
+		return nil, domain.ErrPromoteSameWorkspace(targetRoot)
+	}
+	for _, root := range []string{string(project.Root), targetRoot} {
+		if s.cfg.IsReadOnly(root) {
+			return nil, domain.ErrRootReadOnly(root)
+		}
+	}
+
+	targetName, err := s.targetName(project, targetRoot)
+	if err != nil {
+		return nil, err
+	}
+	targetRootPath, _ := s.cfg.GetRoot(targetRoot)
+	targetPath := filepath.Join(targetRootPath, filepath.FromSlash(targetName))
+
+	if _, err := os.Lstat(targetPath); err == nil {
+		return nil, domain.ErrProjectAlreadyExists(targetPath)
+	}
+
+	if project.HasGit && !opts.Force {
+		dirty, err := s.git.IsDirty(project.Path)
+		if err != nil {
+			return nil, err
+		}
+		if dirty {
+			return nil, domain.ErrGitDirtyRepo
+		}
+	}
+
+	if err := fs.MoveDir(project.Path, targetPath); err != nil {
+		return nil, domain.ErrPromoteMoveFailed(project.Path, targetPath, err)
+	}
+
+	fs.RemoveEmptyParents(filepath.Dir(project.Path), s.rootPath(project.Root))
+
+	return &Result{
+		Project:    *project,
+		TargetRoot: targetRoot,
+		TargetPath: targetPath,
+	}, nil
+}
+
+// targetName returns the project's relative path in the target root's layout.
+// Repositories are placed by their reference; other projects can only lose
+// leading segments, so moving them into a deeper layout is refused.
+func (s *Service) targetName(project *domain.Project, targetRoot string) (string, error) {
+	layout := s.cfg.RootLayout(targetRoot)
+	if layout == s.cfg.RootLayout(string(project.Root)) {
+		return project.Name, nil
+	}
+
+	if ref, ok := s.status.RepoRef(*project); ok {
+		return layout.RepoPath(ref), nil
+	}
+
+	segments := strings.Split(project.Name, "/")
+	if len(segments) < int(layout) {
+		return "", domain.ErrPromoteLayoutMismatch(project.Name, targetRoot, layout.String())
+	}
+	return strings.Join(segments[len(segments)-int(layout):], "/"), nil
+}
+
+// resolveTargetRoot returns the explicit target root, or the root of the next
+// lifecycle stage when no target is given.
+func (s *Service) resolveTargetRoot(project *domain.Project, to string) (string, error) {
+	if to != "" {
+		if _, ok := s.cfg.GetRoot(to); !ok {
+			return "", domain.ErrRootNotFound(to)
+		}
+		return to, nil
+	}
+
+	next, ok := domain.NextWorkspaceType(project.WorkspaceType)
+	if !ok {
+		return "", domain.ErrPromoteNoNextWorkspace(string(project.WorkspaceType))
+	}
+
+	// Sort for a deterministic choice when several roots share a type
+	var names []string
+	for name := range s.cfg.Roots {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if s.cfg.RootWorkspaceType(name) == next {
+			return name, nil
+		}
+	}
+
+	return "", domain.ErrRootNotFound(string(next))
+}
+
+// rootPath returns the filesystem path of a root name.
+func (s *Service) rootPath(name domain.RootName) string {
+	path, _ := s.cfg.GetRoot(string(name))
+	return path
+}
@@ -0,0 +1,278 @@
+package promote
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/mi8bi/ghqx/internal/config"
+	"github.com/mi8bi/ghqx/internal/domain"
+	"github.com/mi8bi/ghqx/internal/status"
+)
+
+// setupRoots creates sandbox/dev/release roots under a temp directory.
+func setupRoots(t *testing.T) *config.Config {
+	t.Helper()
+	tmp := t.TempDir()
+
+	cfg := &config.Config{
+		Roots: map[string]string{
+			"sandbox": filepath.Join(tmp, "sandbox"),
+			"dev":     filepath.Join(tmp, "dev"),
+			"release": filepath.Join(tmp, "release"),
+		},
+		Default: config.DefaultConfig{Root: "sandbox"},
+	}
+	for _, p := range cfg.Roots {
+		if err := os.MkdirAll(p, 0755); err != nil {
+			t.Fatalf("mkdir root: %v", err)
+		}
+	}
+	return cfg
+}
+
+func newService(cfg *config.Config) *Service {
+	return NewService(cfg, status.NewService(cfg))
+}
+
+func TestPromoteToNextStage(t *testing.T) {
+	cfg := setupRoots(t)
+	src := filepath.Join(cfg.Roots["sandbox"], "github.com", "user", "repo")
+	if err := os.MkdirAll(src, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "main.go"), []byte("package main"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	res, err := newService(cfg).Promote(Options{Project: "github.com/user/repo"})
+	if err != nil {
+		t.Fatalf("Promote failed: %v", err)
+	}
+
+	if res.TargetRoot != "dev" {
+		t.Errorf("expected target root dev, got %q", res.TargetRoot)
+	}
+	want := filepath.Join(cfg.Roots["dev"], "github.com", "user", "repo")
+	if res.TargetPath != want {
+		t.Errorf("expected target path %q, got %q", want, res.TargetPath)
+	}
+	if _, err := os.Stat(filepath.Join(want, "main.go")); err != nil {
+		t.Errorf("expected file to be moved: %v", err)
+	}
+	if _, err := os.Stat(src); !os.IsNotExist(err) {
+		t.Errorf("expected source to be removed")
+	}
+	// Empty host/owner directories should be cleaned up, the root kept
+	if _, err := os.Stat(filepath.Join(cfg.Roots["sandbox"], "github.com")); !os.IsNotExist(err) {
+		t.Errorf("expected empty parent directories to be removed")
+	}
+	if _, err := os.Stat(cfg.Roots["sandbox"]); err != nil {
+		t.Errorf("root directory must be kept: %v", err)
+	}
+}
+
+func TestPromoteExplicitTarget(t *testing.T) {
+	cfg := setupRoots(t)
+	if err := os.MkdirAll(filepath.Join(cfg.Roots["sandbox"], "github.com", "user", "repo"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	res, err := newService(cfg).Promote(Options{Project: "github.com/user/repo", To: "release"})
+	if err != nil {
+		t.Fatalf("Promote failed: %v", err)
+	}
+	if res.TargetRoot != "release" {
+		t.Errorf("expected target root release, got %q", res.TargetRoot)
+	}
+}
+
+func TestPromoteRefusesExistingTarget(t *testing.T) {
+	cfg := setupRoots(t)
+	src := filepath.Join(cfg.Roots["sandbox"], "github.com", "user", "repo")
+	dst := filepath.Join(cfg.Roots["dev"], "github.com", "user", "repo")
+	for _, p := range []string{src, dst} {
+		if err := os.MkdirAll(p, 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+
+	_, err := newService(cfg).Promote(Options{Project: "github.com/user/repo", From: "sandbox", To: "dev"})
+	if err == nil {
+		t.Fatal("expected error when target exists")
+	}
+	if _, err := os.Stat(src); err != nil {
+		t.Errorf("source must be untouched: %v", err)
+	}
+}
+
+func TestPromoteRefusesAmbiguousProject(t *testing.T) {
+	cfg := setupRoots(t)
+	for _, root := range []string{"sandbox", "dev"} {
+		if err := os.MkdirAll(filepath.Join(cfg.Roots[root], "github.com", "user", "repo"), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+	s := newService(cfg)
+
+	_, err := s.Promote(Options{Project: "github.com/user/repo", To: "release"})
+	want := domain.ErrProjectAmbiguous("github.com/user/repo", []string{"dev", "sandbox"}).Message
+	var gerr *domain.GhqxError
+	if !errors.As(err, &gerr) || gerr.Message != want {
+		t.Fatalf("expected %q, got %v", want, err)
+	}
+
+	res, err := s.Promote(Options{Project: "github.com/user/repo", From: "dev", To: "release"})
+	if err != nil {
+		t.Fatalf("Promote with --from failed: %v", err)
+	}
+	if res.Project.Root != "dev" {
+		t.Errorf("expected project from dev, got %q", res.Project.Root)
+	}
+}
+
+func TestPromoteErrors(t *testing.T) {
+	cfg := setupRoots(t)
+	if err := os.MkdirAll(filepath.Join(cfg.Roots["release"], "github.com", "user", "repo"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	s := newService(cfg)
+
+	testCases := []struct {
+		name string
+		opts Options
+	}{
+		{"empty project", Options{}},
+		{"unknown project", Options{Project: "github.com/user/none"}},
+		{"unknown from", Options{Project: "github.com/user/repo", From: "nope"}},
+		{"unknown target", Options{Project: "github.com/user/repo", To: "nope"}},
+		{"same workspace", Options{Project: "github.com/user/repo", To: "release"}},
+		{"no next stage", Options{Project: "github.com/user/repo"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := s.Promote(tc.opts); err == nil {
+				t.Errorf("expected error for %s", tc.name)
+			}
+		})
+	}
+}
+
+func TestPromoteRefusesDirtyRepo(t *testing.T) {
+	if exec.Command("git", "--version").Run() != nil {
+		t.Skip("git not available on this system")
+	}
+
+	cfg := setupRoots(t)
+	src := filepath.Join(cfg.Roots["sandbox"], "github.com", "user", "repo")
+	if err := os.MkdirAll(src, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := exec.Command("git", "-C", src, "init").Run(); err != nil {
+		t.Fatalf("git init: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "dirty.txt"), []byte("x"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	s := newService(cfg)
+	_, err := s.Promote(Options{Project: "github.com/user/repo"})
+	var ghqxErr *domain.GhqxError
+	if !errors.As(err, &ghqxErr) || ghqxErr.Code != domain.ErrCodeDirtyRepo {
+		t.Fatalf("expected dirty repo error, got %v", err)
+	}
+
+	// --force moves it anyway
+	if _, err := s.Promote(Options{Project: "github.com/user/repo", Force: true}); err != nil {
+		t.Fatalf("Promote with Force failed: %v", err)
+	}
+}
+
+func TestPromoteUsesConfiguredWorkspaceTypes(t *testing.T) {
+	tmp := t.TempDir()
+	cfg := &config.Config{
+		Roots: map[string]string{
+			"scratch": filepath.Join(tmp, "scratch"),
+			"work":    filepath.Join(tmp, "work"),
+			"oss":     filepath.Join(tmp, "oss"),
+		},
+		RootOptions: map[string]config.RootOptions{
+			"scratch": {Type: "sandbox"},
+			"work":    {Type: "dev"},
+			"oss":     {Type: "dev"},
+		},
+	}
+	for _, p := range cfg.Roots {
+		if err := os.MkdirAll(p, 0755); err != nil {
+			t.Fatalf("mkdir root: %v", err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(cfg.Roots["scratch"], "github.com", "user", "repo"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	// Two roots share the next type; the first by name is chosen
+	res, err := newService(cfg).Promote(Options{Project: "github.com/user/repo"})
+	if err != nil {
+		t.Fatalf("Promote failed: %v", err)
+	}
+	if res.TargetRoot != "oss" {
+		t.Errorf("expected target root oss, got %q", res.TargetRoot)
+	}
+}
+
+func TestPromoteUsesTargetLayout(t *testing.T) {
+	cfg := setupRoots(t)
+	cfg.RootOptions = map[string]config.RootOptions{
+		"dev":     {Layout: "owner/repo"},
+		"release": {Layout: "flat"},
+	}
+	if err := os.MkdirAll(filepath.Join(cfg.Roots["sandbox"], "github.com", "user", "repo"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	res, err := newService(cfg).Promote(Options{Project: "github.com/user/repo"})
+	if err != nil {
+		t.Fatalf("Promote failed: %v", err)
+	}
+	if want := filepath.Join(cfg.Roots["dev"], "user", "repo"); res.TargetPath != want {
+		t.Errorf("expected target path %q, got %q", want, res.TargetPath)
+	}
+
+	res, err = newService(cfg).Promote(Options{Project: "user/repo", To: "release"})
+	if err != nil {
+		t.Fatalf("Promote failed: %v", err)
+	}
+	if want := filepath.Join(cfg.Roots["release"], "repo"); res.TargetPath != want {
+		t.Errorf("expected target path %q, got %q", want, res.TargetPath)
+	}
+
+	// A plain directory has no reference to rebuild host/owner from
+	_, err = newService(cfg).Promote(Options{Project: "repo", To: "sandbox"})
+	var ghqxErr *domain.GhqxError
+	if !errors.As(err, &ghqxErr) || ghqxErr.Code != domain.ErrCodeInvalidPath {
+		t.Errorf("expected layout mismatch error, got %v", err)
+	}
+}
+
+func TestPromoteRefusesReadOnlyRoots(t *testing.T) {
+	cfg := setupRoots(t)
+	if err := os.MkdirAll(filepath.Join(cfg.Roots["sandbox"], "github.com", "user", "repo"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	cfg.RootOptions = map[string]config.RootOptions{"release": {ReadOnly: true}}
+
+	_, err := newService(cfg).Promote(Options{Project: "github.com/user/repo", To: "release"})
+	var ghqxErr *domain.GhqxError
+	if !errors.As(err, &ghqxErr) || ghqxErr.Code != domain.ErrCodeInvalidPath {
+		t.Errorf("expected read-only error, got %v", err)
+	}
+
+	cfg.RootOptions = map[string]config.RootOptions{"sandbox": {ReadOnly: true}}
+	if _, err := newService(cfg).Promote(Options{Project: "github.com/user/repo"}); !errors.As(err, &ghqxErr) {
+		t.Errorf("expected read-only error for the source root, got %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package status
+
+import "github.com/mi8bi/ghqx/internal/domain"
+
+// Problem is a set of conditions that make a repository unsafe to delete.
+// Values are bit flags and double as process exit codes, so a combined
+// exit code tells which categories were found (e.g., 6 = uncommitted + unpushed).
+type Problem int
+
+const (
+	// ProblemUncommitted means the working tree or index has changes (exit code 2)
+	ProblemUncommitted Problem = 1 << (iota + 1)
+	// ProblemUnpushed means the branch has commits not on its upstream,
+	// or has no upstream at all (exit code 4)
+	ProblemUnpushed
+	// ProblemStashed means the repository has stash entries (exit code 8)
+	ProblemStashed
+	// ProblemUnreadable means git status could not be read, so the
+	// repository cannot be proven safe (exit code 16)
+	ProblemUnreadable
+)
+
+// allProblems lists every problem in exit code order.
+var allProblems = []Problem{ProblemUncommitted, ProblemUnpushed, ProblemStashed, ProblemUnreadable}
+
+// Has reports whether p includes every flag of other.
+func (p Problem) Has(other Problem) bool {
+	return p&other == other
+}
+
+// List returns the individual problems contained in p.
+func (p Problem) List() []Problem {
+	var list []Problem
+	for _, problem := range allProblems {
+		if p.Has(problem) {
+			list = append(list, problem)
+		}
+	}
+	return list
+}
+
+// CheckResult is a repository with at least one problem.
+type CheckResult struct {
+	// Project is the offending repository
+	Project domain.Project
+	// Problems is the set of problems found
+	Problems Problem
+}
+
+// CheckProject returns the problems of a single project.
+// The project must have been loaded with Options.LoadGitStatus.
+func CheckProject(p domain.Project) Problem {
+	if !p.HasGit {
+		return 0
+	}
+	// A timed-out or broken repository must not pass as clean
+	if !p.Git.Loaded {
+		return ProblemUnreadable
+	}
+
+	var problems Problem
+	if p.Dirty || p.Git.HasChanges() {
+		problems |= ProblemUncommitted
+	}
+	if p.Git.Ahead > 0 || hasUntrackedBranch(p) {
+		problems |= ProblemUnpushed
+	}
+	if p.Git.Stashes > 0 {
+		problems |= ProblemStashed
+	}
+	return problems
+}
+
+// hasUntrackedBranch reports whether the current branch has commits but no
+// upstream, so none of them were ever pushed. A detached HEAD is not a branch
+// and a repository without commits has nothing to push.
+func hasUntrackedBranch(p domain.Project) bool {
+	return !p.Git.HasUpstream() && p.Branch != "" && p.Branch != "HEAD" && !p.Git.LastCommit.IsZero()
+}
+
+// Check returns the projects that have problems, preserving order,
+// along with the union of all problems found.
+func Check(projects []domain.Project) ([]CheckResult, Problem) {
+	var results []CheckResult
+	var all Problem
+	for _, p := range projects {
+		if problems := CheckProject(p); problems != 0 {
+			results = append(results, CheckResult{Project: p, Problems: problems})
+			all |= problems
+		}
+	}
+	return results, all
+}
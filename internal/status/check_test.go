@@ -0,0 +1,55 @@
+package status
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mi8bi/ghqx/internal/domain"
+)
+
+func TestCheckProject(t *testing.T) {
+	committed := time.Unix(1700000000, 0)
+	testCases := []struct {
+		name    string
+		project domain.Project
+		want    Problem
+	}{
+		{"not git", domain.Project{Dirty: true}, 0},
+		{"clean", domain.Project{HasGit: true, Branch: "main", Git: domain.GitStatus{Loaded: true, Upstream: "origin/main", LastCommit: committed}}, 0},
+		{"untracked", domain.Project{HasGit: true, Dirty: true, Git: domain.GitStatus{Loaded: true, Upstream: "origin/main", Untracked: 1}}, ProblemUncommitted},
+		{"unpushed", domain.Project{HasGit: true, Git: domain.GitStatus{Loaded: true, Upstream: "origin/main", Ahead: 2}}, ProblemUnpushed},
+		{"no upstream", domain.Project{HasGit: true, Branch: "feature", Git: domain.GitStatus{Loaded: true, LastCommit: committed}}, ProblemUnpushed},
+		{"no upstream detached", domain.Project{HasGit: true, Branch: "HEAD", Git: domain.GitStatus{Loaded: true, LastCommit: committed}}, 0},
+		{"no upstream no commits", domain.Project{HasGit: true, Branch: "main", Git: domain.GitStatus{Loaded: true}}, 0},
+		{"stashed", domain.Project{HasGit: true, Git: domain.GitStatus{Loaded: true, Upstream: "origin/main", Stashes: 1}}, ProblemStashed},
+		{"behind only", domain.Project{HasGit: true, Git: domain.GitStatus{Loaded: true, Upstream: "origin/main", Behind: 3}}, 0},
+		{"not loaded", domain.Project{HasGit: true}, ProblemUnreadable},
+		{"all", domain.Project{HasGit: true, Dirty: true, Git: domain.GitStatus{Loaded: true, Upstream: "origin/main", Ahead: 1, Stashes: 1}}, ProblemUncommitted | ProblemUnpushed | ProblemStashed},
+	}
+
+	for _, tc := range testCases {
+		if got := CheckProject(tc.project); got != tc.want {
+			t.Errorf("%s: CheckProject = %d, want %d", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	projects := []domain.Project{
+		{Name: "clean", HasGit: true, Git: domain.GitStatus{Loaded: true, Upstream: "origin/main"}},
+		{Name: "dirty", HasGit: true, Dirty: true, Git: domain.GitStatus{Loaded: true, Upstream: "origin/main"}},
+		{Name: "stash", HasGit: true, Git: domain.GitStatus{Loaded: true, Upstream: "origin/main", Stashes: 2}},
+		{Name: "plain"},
+	}
+
+	results, all := Check(projects)
+	if len(results) != 2 || results[0].Project.Name != "dirty" || results[1].Project.Name != "stash" {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+	if all != ProblemUncommitted|ProblemStashed || int(all) != 10 {
+		t.Errorf("unexpected combined problems: %d", all)
+	}
+	if list := all.List(); len(list) != 2 || list[0] != ProblemUncommitted || list[1] != ProblemStashed {
+		t.Errorf("unexpected problem list: %v", list)
+	}
+}
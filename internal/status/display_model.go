@@ -1,6 +1,10 @@
 package status
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/mi8bi/ghqx/internal/domain"
 	"github.com/mi8bi/ghqx/internal/i18n"
 )
@@ -9,10 +13,15 @@ import (
 // It contains formatted strings suitable for CLI/TUI output.
 type ProjectDisplay struct {
 	Repo       string         // Short repository name (e.g., user/repo)
-	Workspace  string         // Workspace name (e.g., sandbox, dev, release)
+	Workspace  string         // Workspace root name (e.g., sandbox, dev, work)
 	GitManaged string         // Git management status (e.g., "管理" / "Managed")
 	Status     string         // Repository status (e.g., "clean" / "dirty")
 	FullPath   string         // Full filesystem path to the project
+	Branch     string         // Current branch name, "-" if unknown
+	Sync       string         // Ahead/behind upstream (e.g., "↑2 ↓1"), "-" if unknown
+	Changes    string         // Change counts (e.g., "+1 ~2 ?3"), "-" if none
+	Stash      string         // Number of stash entries, "-" if none
+	LastCommit string         // Relative age of the last commit (e.g., "3d ago")
 	RawProject domain.Project // Original project data for detailed operations
 }
 
@@ -21,14 +30,28 @@ type ProjectDisplay struct {
 func NewProjectDisplay(p domain.Project) ProjectDisplay {
 	return ProjectDisplay{
 		Repo:       p.DisplayName,
-		Workspace:  string(p.WorkspaceType),
-		GitManaged: formatGitManaged(p.HasGit),
-		Status:     formatStatus(p.HasGit, p.Dirty),
+		Workspace:  string(p.Root),
+		GitManaged: formatManaged(p),
+		Status:     formatStatus(p.HasWorkingCopy(), p.Dirty),
 		FullPath:   p.Path,
+		Branch:     orDash(p.Branch),
+		Sync:       formatSync(p.Git),
+		Changes:    formatChanges(p.Git),
+		Stash:      formatStash(p.Git),
+		LastCommit: formatLastCommit(p.Git.LastCommit, time.Now()),
 		RawProject: p,
 	}
 }
 
+// formatManaged returns the git management status, or the VCS name (e.g. "hg")
+// for repositories managed by another version control system.
+func formatManaged(p domain.Project) string {
+	if !p.HasGit && p.VCS != domain.VCSNone {
+		return string(p.VCS)
+	}
+	return formatGitManaged(p.HasGit)
+}
+
 // formatGitManaged returns a localized string for git management status.
 func formatGitManaged(hasGit bool) string {
 	if hasGit {
@@ -48,3 +71,87 @@ func formatStatus(hasGit, isDirty bool) string {
 	}
 	return i18n.T("status.repo.clean")
 }
+
+// formatSync returns ahead/behind counts relative to upstream.
+// "=" means in sync; only non-zero directions are shown otherwise.
+func formatSync(g domain.GitStatus) string {
+	if !g.Loaded {
+		return "-"
+	}
+	if !g.HasUpstream() {
+		return i18n.T("status.sync.noUpstream")
+	}
+	if g.Ahead == 0 && g.Behind == 0 {
+		return "="
+	}
+
+	var parts []string
+	if g.Ahead > 0 {
+		parts = append(parts, fmt.Sprintf("↑%d", g.Ahead))
+	}
+	if g.Behind > 0 {
+		parts = append(parts, fmt.Sprintf("↓%d", g.Behind))
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatChanges returns compact change counts:
+// +staged ~unstaged ?untracked !conflicted (zero counts are omitted).
+func formatChanges(g domain.GitStatus) string {
+	if !g.Loaded || !g.HasChanges() {
+		return "-"
+	}
+
+	var parts []string
+	for _, c := range []struct {
+		symbol string
+		count  int
+	}{
+		{"+", g.Staged},
+		{"~", g.Unstaged},
+		{"?", g.Untracked},
+		{"!", g.Conflicted},
+	} {
+		if c.count > 0 {
+			parts = append(parts, fmt.Sprintf("%s%d", c.symbol, c.count))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatStash returns the number of stash entries.
+func formatStash(g domain.GitStatus) string {
+	if g.Stashes == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d", g.Stashes)
+}
+
+// formatLastCommit returns a localized relative age of a commit.
+// Commits older than 30 days are shown as a date.
+func formatLastCommit(t, now time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+
+	age := now.Sub(t)
+	switch {
+	case age < time.Minute:
+		return i18n.T("status.time.justNow")
+	case age < time.Hour:
+		return fmt.Sprintf(i18n.T("status.time.minutesAgo"), int(age.Minutes()))
+	case age < 24*time.Hour:
+		return fmt.Sprintf(i18n.T("status.time.hoursAgo"), int(age.Hours()))
+	case age < 30*24*time.Hour:
+		return fmt.Sprintf(i18n.T("status.time.daysAgo"), int(age.Hours()/24))
+	}
+	return t.Format("2006-01-02")
+}
+
+// orDash returns "-" for empty strings.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
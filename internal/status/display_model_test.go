@@ -2,6 +2,7 @@ package status
 
 import (
 	"testing"
+	"time"
 
 	"github.com/mi8bi/ghqx/internal/domain"
 	"github.com/mi8bi/ghqx/internal/i18n"
@@ -43,3 +44,78 @@ func TestNewProjectDisplay(t *testing.T) {
 		t.Fatalf("GitManaged mismatch: %q", d.GitManaged)
 	}
 }
+
+func TestFormatGitStatusColumns(t *testing.T) {
+	i18n.SetLocale(i18n.LocaleEN)
+
+	var notLoaded domain.GitStatus
+	if formatSync(notLoaded) != "-" || formatChanges(notLoaded) != "-" || formatStash(notLoaded) != "-" {
+		t.Errorf("unloaded status should render as '-'")
+	}
+
+	g := domain.GitStatus{Loaded: true}
+	if got := formatSync(g); got != i18n.T("status.sync.noUpstream") {
+		t.Errorf("formatSync(no upstream) = %q", got)
+	}
+
+	g.Upstream = "origin/main"
+	if got := formatSync(g); got != "=" {
+		t.Errorf("formatSync(in sync) = %q", got)
+	}
+	g.Ahead, g.Behind = 2, 1
+	if got := formatSync(g); got != "↑2 ↓1" {
+		t.Errorf("formatSync(diverged) = %q", got)
+	}
+	g.Ahead = 0
+	if got := formatSync(g); got != "↓1" {
+		t.Errorf("formatSync(behind) = %q", got)
+	}
+
+	g.Staged, g.Untracked, g.Stashes = 1, 3, 2
+	if got := formatChanges(g); got != "+1 ?3" {
+		t.Errorf("formatChanges = %q", got)
+	}
+	if got := formatStash(g); got != "2" {
+		t.Errorf("formatStash = %q", got)
+	}
+}
+
+func TestFormatLastCommit(t *testing.T) {
+	i18n.SetLocale(i18n.LocaleEN)
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		t    time.Time
+		want string
+	}{
+		{time.Time{}, "-"},
+		{now.Add(-10 * time.Second), "just now"},
+		{now.Add(-5 * time.Minute), "5m ago"},
+		{now.Add(-3 * time.Hour), "3h ago"},
+		{now.Add(-4 * 24 * time.Hour), "4d ago"},
+		{now.Add(-90 * 24 * time.Hour), "2024-02-10"},
+	}
+
+	for _, tc := range testCases {
+		if got := formatLastCommit(tc.t, now); got != tc.want {
+			t.Errorf("formatLastCommit(%v) = %q, want %q", tc.t, got, tc.want)
+		}
+	}
+}
+
+func TestNewProjectDisplayOtherVCS(t *testing.T) {
+	hg := NewProjectDisplay(domain.Project{VCS: domain.VCSMercurial, Dirty: true})
+	if hg.GitManaged != "hg" || hg.Status != i18n.T("status.repo.dirty") {
+		t.Errorf("hg display = %q / %q", hg.GitManaged, hg.Status)
+	}
+
+	bare := NewProjectDisplay(domain.Project{VCS: domain.VCSBareGit})
+	if bare.GitManaged != "git-bare" || bare.Status != "-" {
+		t.Errorf("bare display = %q / %q", bare.GitManaged, bare.Status)
+	}
+
+	git := NewProjectDisplay(domain.Project{VCS: domain.VCSGit, HasGit: true})
+	if git.GitManaged != i18n.T("status.git.managed") {
+		t.Errorf("git display = %q", git.GitManaged)
+	}
+}
@@ -0,0 +1,244 @@
+package status
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"text/template"
+	"time"
+
+	"github.com/mi8bi/ghqx/internal/domain"
+)
+
+// Format identifies a status output format.
+type Format string
+
+const (
+	// FormatTable is the localized, human-readable table (default)
+	FormatTable Format = "table"
+	// FormatJSON is a single JSON array of records
+	FormatJSON Format = "json"
+	// FormatNDJSON is one JSON record per line, streamed as roots finish scanning
+	FormatNDJSON Format = "ndjson"
+	// FormatCSV is comma-separated values with a header row
+	FormatCSV Format = "csv"
+	// FormatTSV is tab-separated values with a header row
+	FormatTSV Format = "tsv"
+)
+
+// Record is the machine-readable form of a project.
+// Keys are stable and never localized; new fields are only ever appended.
+type Record struct {
+	Name          string     `json:"name"`
+	DisplayName   string     `json:"display_name"`
+	Root          string     `json:"root"`
+	WorkspaceType string     `json:"workspace_type"`
+	Path          string     `json:"path"`
+	Type          string     `json:"type"`
+	HasGit        bool       `json:"has_git"`
+	Dirty         bool       `json:"dirty"`
+	Branch        string     `json:"branch"`
+	Upstream      string     `json:"upstream"`
+	Ahead         int        `json:"ahead"`
+	Behind        int        `json:"behind"`
+	Staged        int        `json:"staged"`
+	Unstaged      int        `json:"unstaged"`
+	Untracked     int        `json:"untracked"`
+	Conflicted    int        `json:"conflicted"`
+	Stashes       int        `json:"stashes"`
+	LastCommit    *time.Time `json:"last_commit"`
+	WorktreeOf    string     `json:"worktree_of"`
+	HasSubmodules bool       `json:"has_submodules"`
+	VCS           string     `json:"vcs"`
+}
+
+// recordHeader lists the CSV/TSV column names in the same order as Record.values.
+var recordHeader = []string{
+	"name", "display_name", "root", "workspace_type", "path", "type",
+	"has_git", "dirty", "branch", "upstream", "ahead", "behind",
+	"staged", "unstaged", "untracked", "conflicted", "stashes", "last_commit",
+	"worktree_of", "has_submodules", "vcs",
+}
+
+// NewRecord converts a project to its machine-readable form.
+func NewRecord(p domain.Project) Record {
+	r := Record{
+		Name:          p.Name,
+		DisplayName:   p.DisplayName,
+		Root:          string(p.Root),
+		WorkspaceType: string(p.WorkspaceType),
+		Path:          p.Path,
+		Type:          string(p.Type),
+		HasGit:        p.HasGit,
+		Dirty:         p.Dirty,
+		Branch:        p.Branch,
+		Upstream:      p.Git.Upstream,
+		Ahead:         p.Git.Ahead,
+		Behind:        p.Git.Behind,
+		Staged:        p.Git.Staged,
+		Unstaged:      p.Git.Unstaged,
+		Untracked:     p.Git.Untracked,
+		Conflicted:    p.Git.Conflicted,
+		Stashes:       p.Git.Stashes,
+		WorktreeOf:    p.WorktreeOf,
+		HasSubmodules: p.HasSubmodules,
+		VCS:           string(p.VCS),
+	}
+	if !p.Git.LastCommit.IsZero() {
+		t := p.Git.LastCommit.UTC()
+		r.LastCommit = &t
+	}
+	return r
+}
+
+// values returns the record's fields as strings for CSV/TSV output.
+func (r Record) values() []string {
+	lastCommit := ""
+	if r.LastCommit != nil {
+		lastCommit = r.LastCommit.Format(time.RFC3339)
+	}
+	return []string{
+		r.Name, r.DisplayName, r.Root, r.WorkspaceType, r.Path, r.Type,
+		strconv.FormatBool(r.HasGit),
+		strconv.FormatBool(r.Dirty), r.Branch, r.Upstream,
+		strconv.Itoa(r.Ahead), strconv.Itoa(r.Behind),
+		strconv.Itoa(r.Staged), strconv.Itoa(r.Unstaged), strconv.Itoa(r.Untracked),
+		strconv.Itoa(r.Conflicted), strconv.Itoa(r.Stashes), lastCommit,
+		r.WorktreeOf, strconv.FormatBool(r.HasSubmodules), r.VCS,
+	}
+}
+
+// Encoder writes projects in a machine-readable format.
+// Write may be called several times (e.g., once per root); Close finishes the output.
+type Encoder interface {
+	Write(projects []domain.Project) error
+	Close() error
+}
+
+// NewEncoder creates an encoder for a machine-readable format.
+// FormatTable is rendered by the CLI itself and is not accepted here.
+func NewEncoder(format Format, w io.Writer) (Encoder, error) {
+	switch format {
+	case FormatJSON:
+		return &jsonEncoder{w: w, records: []Record{}}, nil
+	case FormatNDJSON:
+		return &ndjsonEncoder{enc: json.NewEncoder(w)}, nil
+	case FormatCSV:
+		return newCSVEncoder(w, ','), nil
+	case FormatTSV:
+		return newCSVEncoder(w, '\t'), nil
+	}
+	return nil, domain.ErrStatusInvalidFormat(string(format))
+}
+
+// NewTemplateEncoder creates an encoder that executes a text/template for every
+// project, followed by a newline. The template receives the domain.Project
+// (e.g., "{{.Path}}" or "{{.Name}} {{.Git.Ahead}}").
+func NewTemplateEncoder(text string, w io.Writer) (Encoder, error) {
+	tmpl, err := template.New("status").Parse(text)
+	if err != nil {
+		return nil, domain.ErrStatusInvalidTemplate(err)
+	}
+	return &templateEncoder{w: w, tmpl: tmpl}, nil
+}
+
+// jsonEncoder collects every record and writes a single indented array on Close.
+type jsonEncoder struct {
+	w       io.Writer
+	records []Record
+}
+
+func (e *jsonEncoder) Write(projects []domain.Project) error {
+	for _, p := range projects {
+		e.records = append(e.records, NewRecord(p))
+	}
+	return nil
+}
+
+func (e *jsonEncoder) Close() error {
+	enc := json.NewEncoder(e.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(e.records)
+}
+
+// ndjsonEncoder writes one record per line immediately.
+type ndjsonEncoder struct {
+	enc *json.Encoder
+}
+
+func (e *ndjsonEncoder) Write(projects []domain.Project) error {
+	for _, p := range projects {
+		if err := e.enc.Encode(NewRecord(p)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *ndjsonEncoder) Close() error {
+	return nil
+}
+
+// csvEncoder writes delimited rows with a header, which is always written.
+type csvEncoder struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func newCSVEncoder(w io.Writer, comma rune) *csvEncoder {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	return &csvEncoder{w: cw}
+}
+
+func (e *csvEncoder) writeHeader() error {
+	if e.headerWritten {
+		return nil
+	}
+	e.headerWritten = true
+	return e.w.Write(recordHeader)
+}
+
+func (e *csvEncoder) Write(projects []domain.Project) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	for _, p := range projects {
+		if err := e.w.Write(NewRecord(p).values()); err != nil {
+			return err
+		}
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvEncoder) Close() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// templateEncoder executes a template for every project.
+type templateEncoder struct {
+	w    io.Writer
+	tmpl *template.Template
+}
+
+func (e *templateEncoder) Write(projects []domain.Project) error {
+	for _, p := range projects {
+		if err := e.tmpl.Execute(e.w, p); err != nil {
+			return domain.ErrStatusInvalidTemplate(err)
+		}
+		if _, err := io.WriteString(e.w, "\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *templateEncoder) Close() error {
+	return nil
+}
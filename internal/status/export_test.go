@@ -0,0 +1,198 @@
+package status
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mi8bi/ghqx/internal/domain"
+)
+
+func exportProjects() []domain.Project {
+	return []domain.Project{
+		{
+			Name:          "github.com/user/repo",
+			DisplayName:   "user/repo",
+			Root:          "dev",
+			WorkspaceType: domain.WorkspaceTypeDev,
+			Path:          "/dev/github.com/user/repo",
+			Type:          domain.ProjectTypeDirty,
+			HasGit:        true,
+			Dirty:         true,
+			Branch:        "main",
+			Git: domain.GitStatus{
+				Loaded:     true,
+				Upstream:   "origin/main",
+				Ahead:      1,
+				Untracked:  2,
+				LastCommit: time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			Name:        "scratch/tool, v2",
+			DisplayName: "scratch/tool, v2",
+			Root:        "sandbox",
+			Path:        "/sandbox/scratch/tool, v2",
+			Type:        domain.ProjectTypeDir,
+		},
+	}
+}
+
+func TestRecordValuesMatchHeader(t *testing.T) {
+	r := NewRecord(exportProjects()[0])
+	if len(r.values()) != len(recordHeader) {
+		t.Fatalf("values has %d columns, header has %d", len(r.values()), len(recordHeader))
+	}
+}
+
+func TestRecordHeaderOnlyAppends(t *testing.T) {
+	// Earlier columns must keep their positions for positional consumers
+	released := []string{
+		"name", "display_name", "root", "workspace_type", "path", "type",
+		"has_git", "dirty", "branch", "upstream", "ahead", "behind",
+		"staged", "unstaged", "untracked", "conflicted", "stashes", "last_commit",
+		"worktree_of", "has_submodules",
+	}
+	for i, name := range released {
+		if recordHeader[i] != name {
+			t.Fatalf("column %d = %q, want %q", i, recordHeader[i], name)
+		}
+	}
+
+	r := NewRecord(domain.Project{VCS: domain.VCSMercurial})
+	if values := r.values(); values[len(values)-1] != "hg" {
+		t.Errorf("vcs column not at the end: %v", values)
+	}
+}
+
+func TestJSONEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	enc, err := NewEncoder(FormatJSON, &buf)
+	if err != nil {
+		t.Fatalf("NewEncoder: %v", err)
+	}
+	projects := exportProjects()
+	enc.Write(projects[:1])
+	enc.Write(projects[1:])
+	if err := enc.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	var got []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(got))
+	}
+	if got[0]["ahead"] != float64(1) || got[0]["last_commit"] != "2024-05-10T12:00:00Z" {
+		t.Errorf("unexpected first record: %v", got[0])
+	}
+	if got[1]["last_commit"] != nil {
+		t.Errorf("expected null last_commit for non-git project, got %v", got[1]["last_commit"])
+	}
+}
+
+func TestJSONEncoderEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	enc, _ := NewEncoder(FormatJSON, &buf)
+	enc.Close()
+	if strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("expected empty array, got %q", buf.String())
+	}
+}
+
+func TestNDJSONEncoderStreams(t *testing.T) {
+	var buf bytes.Buffer
+	enc, _ := NewEncoder(FormatNDJSON, &buf)
+	projects := exportProjects()
+
+	enc.Write(projects[:1])
+	if lines := strings.Count(buf.String(), "\n"); lines != 1 {
+		t.Fatalf("expected first record to be written immediately, got %d lines", lines)
+	}
+	enc.Write(projects[1:])
+	enc.Close()
+
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var r Record
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Errorf("invalid NDJSON line %q: %v", line, err)
+		}
+	}
+}
+
+func TestCSVAndTSVEncoders(t *testing.T) {
+	for _, tc := range []struct {
+		format Format
+		comma  rune
+	}{
+		{FormatCSV, ','},
+		{FormatTSV, '\t'},
+	} {
+		var buf bytes.Buffer
+		enc, err := NewEncoder(tc.format, &buf)
+		if err != nil {
+			t.Fatalf("NewEncoder(%s): %v", tc.format, err)
+		}
+		enc.Write(exportProjects())
+		enc.Close()
+
+		r := csv.NewReader(&buf)
+		r.Comma = tc.comma
+		rows, err := r.ReadAll()
+		if err != nil {
+			t.Fatalf("%s: unreadable output: %v", tc.format, err)
+		}
+		if len(rows) != 3 || rows[0][0] != "name" {
+			t.Fatalf("%s: expected header and 2 rows, got %v", tc.format, rows)
+		}
+		if rows[2][0] != "scratch/tool, v2" {
+			t.Errorf("%s: field with delimiter not preserved: %q", tc.format, rows[2][0])
+		}
+	}
+}
+
+func TestCSVEncoderHeaderWithoutProjects(t *testing.T) {
+	var buf bytes.Buffer
+	enc, _ := NewEncoder(FormatCSV, &buf)
+	enc.Close()
+	if !strings.HasPrefix(buf.String(), "name,display_name,") {
+		t.Errorf("expected header only, got %q", buf.String())
+	}
+}
+
+func TestNewEncoderRejectsUnknownFormat(t *testing.T) {
+	for _, f := range []Format{FormatTable, "xml"} {
+		if _, err := NewEncoder(f, &bytes.Buffer{}); err == nil {
+			t.Errorf("expected error for format %q", f)
+		}
+	}
+}
+
+func TestTemplateEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	enc, err := NewTemplateEncoder("{{.Root}}:{{.Path}}:{{.Git.Ahead}}", &buf)
+	if err != nil {
+		t.Fatalf("NewTemplateEncoder: %v", err)
+	}
+	if err := enc.Write(exportProjects()); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	want := "dev:/dev/github.com/user/repo:1\nsandbox:/sandbox/scratch/tool, v2:0\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+
+	if _, err := NewTemplateEncoder("{{.Path", &buf); err == nil {
+		t.Error("expected parse error")
+	}
+	enc, _ = NewTemplateEncoder("{{.NoSuchField}}", &buf)
+	if err := enc.Write(exportProjects()); err == nil {
+		t.Error("expected execution error for unknown field")
+	}
+}
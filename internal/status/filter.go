@@ -0,0 +1,76 @@
+package status
+
+import (
+	"strings"
+
+	"github.com/mi8bi/ghqx/internal/domain"
+)
+
+// Filter selects projects by their attributes.
+// Zero-valued fields do not filter, so an empty Filter matches every project.
+type Filter struct {
+	// Workspace restricts projects to a single root name
+	Workspace string
+	// Dirty keeps only git repositories with uncommitted changes
+	Dirty bool
+	// Clean keeps only git repositories without uncommitted changes
+	Clean bool
+	// Git keeps only git repositories
+	Git bool
+	// NoGit keeps only directories that are not git repositories
+	NoGit bool
+	// Query keeps projects whose name contains every whitespace-separated term (case-insensitive)
+	Query string
+}
+
+// NeedsDirtyCheck reports whether the filter depends on dirty status,
+// which requires Options.CheckDirty when scanning.
+func (f Filter) NeedsDirtyCheck() bool {
+	return f.Dirty || f.Clean
+}
+
+// Match reports whether a project satisfies every condition of the filter.
+func (f Filter) Match(p domain.Project) bool {
+	if f.Workspace != "" && string(p.Root) != f.Workspace {
+		return false
+	}
+	if f.Git && !p.HasGit {
+		return false
+	}
+	if f.NoGit && p.HasGit {
+		return false
+	}
+	// Dirty and clean only make sense for repositories with a working copy
+	if f.Dirty && (!p.HasWorkingCopy() || !p.Dirty) {
+		return false
+	}
+	if f.Clean && (!p.HasWorkingCopy() || p.Dirty) {
+		return false
+	}
+	if f.Query != "" && !matchesQuery(p.Name, f.Query) {
+		return false
+	}
+	return true
+}
+
+// matchesQuery reports whether name contains every term of the query.
+func matchesQuery(name, query string) bool {
+	name = strings.ToLower(name)
+	for _, term := range strings.Fields(strings.ToLower(query)) {
+		if !strings.Contains(name, term) {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply returns the projects that match the filter, preserving order.
+func (f Filter) Apply(projects []domain.Project) []domain.Project {
+	var matched []domain.Project
+	for _, p := range projects {
+		if f.Match(p) {
+			matched = append(matched, p)
+		}
+	}
+	return matched
+}
@@ -0,0 +1,69 @@
+package status
+
+import (
+	"testing"
+
+	"github.com/mi8bi/ghqx/internal/domain"
+)
+
+func TestFilterMatch(t *testing.T) {
+	clean := domain.Project{Name: "a", Root: "dev", HasGit: true}
+	dirty := domain.Project{Name: "b", Root: "dev", HasGit: true, Dirty: true}
+	dir := domain.Project{Name: "c", Root: "sandbox"}
+
+	testCases := []struct {
+		name   string
+		filter Filter
+		want   []string
+	}{
+		{"empty", Filter{}, []string{"a", "b", "c"}},
+		{"workspace", Filter{Workspace: "dev"}, []string{"a", "b"}},
+		{"dirty", Filter{Dirty: true}, []string{"b"}},
+		{"clean", Filter{Clean: true}, []string{"a"}},
+		{"git", Filter{Git: true}, []string{"a", "b"}},
+		{"no-git", Filter{NoGit: true}, []string{"c"}},
+		{"combined", Filter{Workspace: "sandbox", Git: true}, nil},
+		{"query", Filter{Query: "B"}, []string{"b"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.filter.Apply([]domain.Project{clean, dirty, dir})
+			if len(got) != len(tc.want) {
+				t.Fatalf("expected %v, got %d projects", tc.want, len(got))
+			}
+			for i, p := range got {
+				if p.Name != tc.want[i] {
+					t.Errorf("result %d = %s, want %s", i, p.Name, tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFilterNeedsDirtyCheck(t *testing.T) {
+	if (Filter{Workspace: "dev", Git: true}).NeedsDirtyCheck() {
+		t.Error("workspace/git filters should not need a dirty check")
+	}
+	if !(Filter{Dirty: true}).NeedsDirtyCheck() || !(Filter{Clean: true}).NeedsDirtyCheck() {
+		t.Error("dirty/clean filters need a dirty check")
+	}
+}
+
+func TestMatchesQuery(t *testing.T) {
+	testCases := []struct {
+		query string
+		want  bool
+	}{
+		{"", true},
+		{"ghqx", true},
+		{"MI8BI ghqx", true},
+		{"github ghqx-tools", false},
+		{"gitlab", false},
+	}
+	for _, tc := range testCases {
+		if got := matchesQuery("github.com/mi8bi/ghqx", tc.query); got != tc.want {
+			t.Errorf("matchesQuery(%q) = %v, want %v", tc.query, got, tc.want)
+		}
+	}
+}
@@ -1,12 +1,19 @@
 package status
 
 import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/mi8bi/ghqx/internal/config"
 	"github.com/mi8bi/ghqx/internal/domain"
 	"github.com/mi8bi/ghqx/internal/fs"
 	"github.com/mi8bi/ghqx/internal/git"
+	"github.com/mi8bi/ghqx/internal/index"
+	"github.com/mi8bi/ghqx/internal/vcs"
 )
 
 // Service handles status operations across all workspace roots.
@@ -19,43 +26,160 @@ type Service struct {
 	scanner *fs.Scanner
 	// git provides git-related operations (status, branch info, etc.)
 	git *git.Client
+	// vcs provides dirty and branch checks for the other version control systems
+	vcs *vcs.Registry
+	// index caches scan results on disk (nil scans every time)
+	index *index.Index
 }
 
 // NewService creates a new status service with default dependencies.
 func NewService(cfg *config.Config) *Service {
+	scanner := fs.NewScanner()
+	scanner.SetMaxDepth(cfg.Scan.MaxDepth)
+	scanner.SetExcludes(cfg.Scan.Exclude, cfg.RootExcludes())
+	scanner.SetLayouts(cfg.RootLayouts())
+	scanner.SetWorkspaceTypes(cfg.RootWorkspaceTypes())
+
 	return &Service{
 		cfg:     cfg,
-		scanner: fs.NewScanner(),
+		scanner: scanner,
 		git:     git.NewClient(),
+		vcs:     vcs.NewRegistry(),
 	}
 }
 
+// Scanner returns the filesystem scanner configured for this service.
+func (s *Service) Scanner() *fs.Scanner {
+	return s.scanner
+}
+
+// WithGitTimeout returns a copy of the service whose git commands may take up
+// to timeout. The default timeout suits interactive listings, where a slow
+// repository just shows less; callers relying on every status use this.
+func (s *Service) WithGitTimeout(timeout time.Duration) *Service {
+	copied := *s
+	copied.git = git.NewClientWithTimeout(timeout)
+	return &copied
+}
+
+// SetIndex makes the service read scan results from idx and keep it up to date.
+// Passing nil turns the cache off.
+func (s *Service) SetIndex(idx *index.Index) {
+	s.index = idx
+}
+
 // Options configures the behavior of status scanning operations.
 type Options struct {
 	// CheckDirty determines whether to check for uncommitted changes in git repos
 	CheckDirty bool
 	// LoadBranch determines whether to load the current branch name for git repos
 	LoadBranch bool
+	// LoadGitStatus loads ahead/behind, change counts, stashes and the last commit date.
+	// It also sets Dirty and Branch, so CheckDirty and LoadBranch are implied.
+	LoadGitStatus bool
+	// LoadModTime sets each project's latest modification time
+	LoadModTime bool
+	// Filter selects which projects are returned (the zero value returns all)
+	Filter Filter
+	// Sort orders the projects returned by GetAll (SortNone keeps scan order)
+	Sort SortKey
+}
+
+// withImpliedLoads enables the loads required by the filter and sort key.
+func (o Options) withImpliedLoads() Options {
+	if o.Filter.NeedsDirtyCheck() {
+		o.CheckDirty = true
+	}
+	switch o.Sort {
+	case SortModified:
+		o.LoadModTime = true
+	case SortLastCommit:
+		o.LoadGitStatus = true
+	}
+	return o
 }
 
-// GetAll scans all configured roots and returns all discovered projects.
+// GetAll scans all configured roots and returns all discovered projects
+// matching opts.Filter, ordered by opts.Sort.
 // Optionally filters to a single root using rootFilter.
 // Scanning is performed concurrently across all roots for better performance.
 func (s *Service) GetAll(opts Options, rootFilter ...string) ([]domain.Project, error) {
+	projects, _, err := s.GetAllWithExcluded(opts, rootFilter...)
+	return projects, err
+}
+
+// GetAllWithExcluded is GetAll that also returns the directories skipped by
+// exclude patterns in the scanned roots, sorted by path, from the same scan.
+func (s *Service) GetAllWithExcluded(opts Options, rootFilter ...string) ([]domain.Project, []fs.Exclusion, error) {
+	var allProjects []domain.Project
+	var excluded []fs.Exclusion
+	err := s.stream(opts, func(projects []domain.Project, skipped []fs.Exclusion) error {
+		allProjects = append(allProjects, projects...)
+		excluded = append(excluded, skipped...)
+		return nil
+	}, rootFilter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	SortProjects(allProjects, opts.Sort)
+	sort.Slice(excluded, func(i, j int) bool {
+		return excluded[i].Path < excluded[j].Path
+	})
+	return allProjects, excluded, nil
+}
+
+// Stream scans roots like GetAll but hands each root's projects to onRoot
+// as soon as that root has been scanned, so callers can output results early.
+// onRoot is never called concurrently; once it returns an error it is not called again.
+// Projects are filtered by opts.Filter, and each root's projects are ordered by opts.Sort.
+// Returns the first error from scanning or from onRoot.
+func (s *Service) Stream(opts Options, onRoot func([]domain.Project) error, rootFilter ...string) error {
+	return s.stream(opts, func(projects []domain.Project, _ []fs.Exclusion) error {
+		return onRoot(projects)
+	}, rootFilter)
+}
+
+// stream implements Stream, also handing onRoot the root's excluded directories.
+func (s *Service) stream(opts Options, onRoot func([]domain.Project, []fs.Exclusion) error, rootFilter []string) error {
+	opts = opts.withImpliedLoads()
+
+	// Unlike rootFilter, an unknown workspace in the filter is an error
+	// rather than a fallback to every root
+	if ws := opts.Filter.Workspace; ws != "" {
+		if _, ok := s.cfg.GetRoot(ws); !ok {
+			return domain.ErrRootNotFound(ws)
+		}
+		if len(rootFilter) == 0 || rootFilter[0] == "" {
+			rootFilter = []string{ws}
+		}
+	}
+
 	// Determine which roots to scan based on filter
 	targetRoots := s.determineTargetRoots(rootFilter)
 
-	// Prepare result containers and synchronization primitives
-	var allProjects []domain.Project
-	var mu sync.Mutex     // Protects allProjects slice
+	// Prepare synchronization primitives
+	var mu sync.Mutex     // Serializes onRoot calls and protects errors
 	var wg sync.WaitGroup // Tracks goroutine completion
 	var errors []error
-	var errMu sync.Mutex // Protects errors slice
 
 	// Launch concurrent scan for each root
 	for name, path := range targetRoots {
 		wg.Add(1)
-		go s.scanRoot(name, path, opts, &allProjects, &errors, &mu, &errMu, &wg)
+		go func(name, path string) {
+			defer wg.Done()
+
+			projects, excluded, err := s.scanRoot(name, path, opts)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err == nil && len(errors) == 0 {
+				err = onRoot(projects, excluded)
+			}
+			if err != nil {
+				errors = append(errors, err)
+			}
+		}(name, path)
 	}
 
 	// Wait for all scans to complete
@@ -63,10 +187,10 @@ func (s *Service) GetAll(opts Options, rootFilter ...string) ([]domain.Project,
 
 	// Return first error that occurred during scanning
 	if len(errors) > 0 {
-		return nil, errors[0]
+		return errors[0]
 	}
 
-	return allProjects, nil
+	return nil
 }
 
 // determineTargetRoots returns the roots to scan based on the filter.
@@ -83,30 +207,19 @@ func (s *Service) determineTargetRoots(rootFilter []string) map[string]string {
 	return s.cfg.Roots
 }
 
-// scanRoot scans a single root directory for projects.
-// This function runs concurrently and safely appends results to shared slices.
-func (s *Service) scanRoot(
-	rootName, rootPath string,
-	opts Options,
-	allProjects *[]domain.Project,
-	errors *[]error,
-	mu, errMu *sync.Mutex,
-	wg *sync.WaitGroup,
-) {
-	defer wg.Done()
-
+// scanRoot scans a single root directory for projects, also returning
+// the directories skipped by exclude patterns.
+// This function runs concurrently for each root.
+func (s *Service) scanRoot(rootName, rootPath string, opts Options) ([]domain.Project, []fs.Exclusion, error) {
 	// Determine workspace type for all discovered projects in this root
-	workspaceType := domain.DetermineWorkspaceType(domain.RootName(rootName))
+	workspaceType := s.cfg.RootWorkspaceType(rootName)
 
-	// Scan the root directory for projects
-	projects, err := s.scanner.ScanRoot(domain.RootName(rootName), rootPath)
+	// Scan the root directory for projects, through the index when enabled
+	result, err := s.scan(rootName, rootPath)
 	if err != nil {
-		// Record error in thread-safe manner
-		errMu.Lock()
-		*errors = append(*errors, err)
-		errMu.Unlock()
-		return
+		return nil, nil, err
 	}
+	projects := result.Projects
 
 	// Set workspace type for all discovered projects
 	for i := range projects {
@@ -114,22 +227,80 @@ func (s *Service) scanRoot(
 	}
 
 	// Enrich projects with git status if requested
-	if opts.CheckDirty || opts.LoadBranch {
+	if opts.CheckDirty || opts.LoadBranch || opts.LoadGitStatus {
 		s.enrichProjects(projects, opts)
 	}
 
-	// Append projects to result in thread-safe manner
-	mu.Lock()
-	*allProjects = append(*allProjects, projects...)
-	mu.Unlock()
+	if opts.LoadModTime {
+		for i := range projects {
+			projects[i].ModTime = projectModTime(projects[i])
+		}
+	}
+
+	projects = opts.Filter.Apply(projects)
+	SortProjects(projects, opts.Sort)
+
+	return projects, result.Excluded, nil
+}
+
+// scan scans a root, through the index when enabled.
+func (s *Service) scan(rootName, rootPath string) (*fs.ScanResult, error) {
+	if s.index != nil {
+		return s.index.Scan(domain.RootName(rootName), rootPath)
+	}
+	return s.scanner.Scan(domain.RootName(rootName), rootPath)
 }
 
-// enrichProjects adds git information to projects that have git.
+// projectModTime returns the latest modification time of a project.
+// For git repositories the index is checked too, since it changes on
+// every add, commit and checkout even when the top directory does not.
+func projectModTime(p domain.Project) time.Time {
+	var latest time.Time
+	candidates := []string{p.Path}
+	if gitDir, ok := fs.GitDir(p.Path); p.HasGit && ok {
+		candidates = append(candidates, filepath.Join(gitDir, "index"))
+	}
+	for _, path := range candidates {
+		if info, err := os.Stat(path); err == nil && info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest
+}
+
+// enrichProjects adds version control information to projects that are repositories.
 func (s *Service) enrichProjects(projects []domain.Project, opts Options) {
 	for i := range projects {
-		// Only enrich projects that are git repositories
-		if projects[i].HasGit {
+		switch {
+		case projects[i].HasGit:
 			s.enrichProject(&projects[i], opts)
+		case projects[i].VCS != domain.VCSNone:
+			s.enrichVCSProject(&projects[i], opts)
+		}
+	}
+}
+
+// enrichVCSProject adds the dirty status and branch of a non-git repository
+// through its VCS backend. Nothing is loaded when the tool is not installed.
+// Detailed git status has no equivalent, so LoadGitStatus only implies both checks.
+func (s *Service) enrichVCSProject(project *domain.Project, opts Options) {
+	backend, ok := s.vcs.Backend(project.VCS)
+	if !ok {
+		return
+	}
+
+	if opts.CheckDirty || opts.LoadGitStatus {
+		if dirty, err := backend.IsDirty(project.Path); err == nil {
+			project.Dirty = dirty
+			if dirty {
+				project.Type = domain.ProjectTypeDirty
+			}
+		}
+	}
+
+	if opts.LoadBranch || opts.LoadGitStatus {
+		if branch, err := backend.Branch(project.Path); err == nil {
+			project.Branch = branch
 		}
 	}
 }
@@ -137,6 +308,12 @@ func (s *Service) enrichProjects(projects []domain.Project, opts Options) {
 // enrichProject adds requested git information to a single project.
 // Checks dirty status and/or loads branch info based on Options.
 func (s *Service) enrichProject(project *domain.Project, opts Options) {
+	// Detailed status already covers dirty and branch in a single git call
+	if opts.LoadGitStatus {
+		s.updateGitStatus(project)
+		return
+	}
+
 	if opts.CheckDirty {
 		s.updateDirtyStatus(project)
 	}
@@ -158,6 +335,20 @@ func (s *Service) updateDirtyStatus(project *domain.Project) {
 	}
 }
 
+// updateGitStatus loads detailed git status and derives dirty and branch from it.
+func (s *Service) updateGitStatus(project *domain.Project) {
+	st, branch, err := s.git.Status(project.Path)
+	if err != nil {
+		return
+	}
+	project.Git = st
+	project.Branch = branch
+	project.Dirty = st.HasChanges()
+	if project.Dirty {
+		project.Type = domain.ProjectTypeDirty
+	}
+}
+
 // updateBranchInfo loads the current branch name for a project.
 func (s *Service) updateBranchInfo(project *domain.Project) {
 	branch, err := s.git.GetBranch(project.Path)
@@ -167,21 +358,92 @@ func (s *Service) updateBranchInfo(project *domain.Project) {
 }
 
 // FindProject searches for a project by its full name across all roots.
-// Returns a pointer to the project if found, or an error if not found.
-func (s *Service) FindProject(name string) (*domain.Project, error) {
+// Optionally restricts the search to a single root using rootFilter.
+// Returns a pointer to the project if found, or an error if not found or
+// if the name exists in several roots, so callers never act on an arbitrary copy.
+func (s *Service) FindProject(name string, rootFilter ...string) (*domain.Project, error) {
 	// Scan all projects without git enrichment for efficiency
-	projects, err := s.GetAll(Options{})
+	projects, err := s.GetAll(Options{}, rootFilter...)
 	if err != nil {
 		return nil, err
 	}
 
 	// Search through projects for matching name
+	var matches []domain.Project
 	for _, p := range projects {
 		if p.Name == name {
-			return &p, nil
+			matches = append(matches, p)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, domain.ErrProjectNotFound(name)
+	case 1:
+		return &matches[0], nil
+	}
+
+	roots := make([]string, len(matches))
+	for i, p := range matches {
+		roots[i] = string(p.Root)
+	}
+	sort.Strings(roots)
+	return nil, domain.ErrProjectAmbiguous(name, roots)
+}
+
+// RepoRef returns the repository a project was cloned from.
+// In the ghq layout the name is the full reference. Other layouts drop the
+// host (and owner), so the reference comes from the origin remote instead;
+// projects without one report false, like names not shaped like a repository.
+func (s *Service) RepoRef(p domain.Project) (domain.RepoRef, bool) {
+	if s.cfg.RootLayout(string(p.Root)) == domain.LayoutGhq {
+		ref, err := domain.ParseRepoRef(p.Name)
+		return ref, err == nil
+	}
+
+	if !p.HasGit {
+		return domain.RepoRef{}, false
+	}
+	remote, err := s.git.RemoteURL(p.Path)
+	if err != nil {
+		return domain.RepoRef{}, false
+	}
+	ref, err := domain.ParseRepoRef(remote)
+	return ref, err == nil
+}
+
+// FindRepository returns all projects across roots that refer to the given repository.
+// Project names are parsed as repository references, so "github.com/user/repo"
+// in any root matches "https://github.com/user/repo.git" or "user/repo".
+func (s *Service) FindRepository(ref domain.RepoRef) ([]domain.Project, error) {
+	projects, err := s.GetAll(Options{})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.MatchRepository(projects, ref), nil
+}
+
+// MatchRepository returns the projects that refer to the given repository.
+// Use this instead of FindRepository when checking many references against one scan.
+// Projects in roots with another layout are only compared after their name
+// matches the path the repository would be cloned to, keeping git calls rare.
+func (s *Service) MatchRepository(projects []domain.Project, ref domain.RepoRef) []domain.Project {
+	var matches []domain.Project
+	for _, p := range projects {
+		layout := s.cfg.RootLayout(string(p.Root))
+		if layout != domain.LayoutGhq && !strings.EqualFold(p.Name, layout.RepoPath(ref)) {
+			continue
+		}
+		if projectRef, ok := s.RepoRef(p); ok && projectRef.Equal(ref) {
+			matches = append(matches, p)
 		}
 	}
 
-	// Not found
-	return nil, domain.ErrProjectNotFound(name)
+	// Sort for deterministic output when a repository exists in several roots
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].Root < matches[j].Root
+	})
+
+	return matches
 }
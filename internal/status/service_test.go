@@ -5,9 +5,12 @@ import (
 	"os/exec"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/mi8bi/ghqx/internal/config"
+	"github.com/mi8bi/ghqx/internal/domain"
 	"github.com/mi8bi/ghqx/internal/fs"
+	"github.com/mi8bi/ghqx/internal/git"
 )
 
 func TestGetAllAndFindProject(t *testing.T) {
@@ -229,6 +232,55 @@ func TestGetAllWithLoadBranch(t *testing.T) {
 	}
 }
 
+func TestGetAllWithLoadGitStatus(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available, skipping test")
+	}
+
+	tmp := t.TempDir()
+	repo := filepath.Join(tmp, "github.com", "user", "repo")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	for _, args := range [][]string{
+		{"init"},
+		{"-c", "user.email=t@example.com", "-c", "user.name=t", "-c", "commit.gpgsign=false", "commit", "--allow-empty", "-m", "init"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, out)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(repo, "new.txt"), []byte("x"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	cfg := &config.Config{Roots: map[string]string{"dev": tmp}}
+	s := NewService(cfg)
+	// The default timeout is tuned for interactive use, not slow CI machines
+	s.git = git.NewClientWithTimeout(5 * time.Second)
+
+	projects, err := s.GetAll(Options{LoadGitStatus: true})
+	if err != nil {
+		t.Fatalf("GetAll failed: %v", err)
+	}
+	if len(projects) != 1 {
+		t.Fatalf("expected 1 project, got %d", len(projects))
+	}
+
+	p := projects[0]
+	if !p.Git.Loaded || p.Git.Untracked != 1 {
+		t.Errorf("expected detailed status with one untracked file, got %+v", p.Git)
+	}
+	if !p.Dirty || p.Type != domain.ProjectTypeDirty {
+		t.Errorf("expected project to be marked dirty: %+v", p)
+	}
+	if p.Branch == "" {
+		t.Error("branch should be loaded")
+	}
+}
+
 func TestGetAllWithMultipleRoots(t *testing.T) {
 	tmp, err := os.MkdirTemp("", "ghqx-status-multi")
 	if err != nil {
@@ -395,3 +447,228 @@ func TestDetermineTargetRootsWithEmptyFilter(t *testing.T) {
 		t.Errorf("expected 2 roots with empty filter, got %d", len(roots))
 	}
 }
+
+func TestFindRepository(t *testing.T) {
+	tmp := t.TempDir()
+	sandbox := filepath.Join(tmp, "sandbox")
+	dev := filepath.Join(tmp, "dev")
+	for _, p := range []string{
+		filepath.Join(sandbox, "github.com", "user", "repo"),
+		filepath.Join(dev, "github.com", "user", "repo"),
+		filepath.Join(dev, "github.com", "other", "repo"),
+	} {
+		if err := os.MkdirAll(p, 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+
+	cfg := &config.Config{Roots: map[string]string{"sandbox": sandbox, "dev": dev}}
+	s := NewService(cfg)
+
+	ref := domain.RepoRef{Host: "github.com", Owner: "user", Name: "repo"}
+	matches, err := s.FindRepository(ref)
+	if err != nil {
+		t.Fatalf("FindRepository failed: %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %d", len(matches))
+	}
+	if matches[0].Root != "dev" || matches[1].Root != "sandbox" {
+		t.Errorf("expected matches sorted by root, got %s, %s", matches[0].Root, matches[1].Root)
+	}
+}
+
+func TestMatchRepositoryUsesRootLayouts(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available, skipping test")
+	}
+
+	tmp := t.TempDir()
+	owned := filepath.Join(tmp, "work")
+	flat := filepath.Join(tmp, "scratch")
+	clones := map[string]string{
+		filepath.Join(owned, "alice", "tool"): "https://gitlab.com/alice/tool.git",
+		filepath.Join(flat, "tool"):           "git@github.com:alice/tool.git",
+	}
+	for dir, remote := range clones {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		for _, args := range [][]string{{"init"}, {"remote", "add", "origin", remote}} {
+			cmd := exec.Command("git", args...)
+			cmd.Dir = dir
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Fatalf("git %v failed: %v: %s", args, err, out)
+			}
+		}
+	}
+
+	cfg := &config.Config{
+		Roots: map[string]string{"work": owned, "scratch": flat},
+		RootOptions: map[string]config.RootOptions{
+			"work":    {Layout: "owner/repo"},
+			"scratch": {Layout: "flat"},
+		},
+	}
+	s := NewService(cfg)
+	s.git = git.NewClientWithTimeout(5 * time.Second)
+	projects, err := s.GetAll(Options{})
+	if err != nil {
+		t.Fatalf("GetAll failed: %v", err)
+	}
+
+	// The owner/repo clone comes from gitlab, the flat one from github
+	github := domain.RepoRef{Host: "github.com", Owner: "alice", Name: "tool"}
+	if matches := s.MatchRepository(projects, github); len(matches) != 1 || matches[0].Root != "scratch" {
+		t.Errorf("expected only the flat clone to match %s, got %+v", github, matches)
+	}
+	gitlab := domain.RepoRef{Host: "gitlab.com", Owner: "alice", Name: "tool"}
+	if matches := s.MatchRepository(projects, gitlab); len(matches) != 1 || matches[0].Root != "work" {
+		t.Errorf("expected only the owner/repo clone to match %s, got %+v", gitlab, matches)
+	}
+}
+
+func TestStreamCallsOncePerRoot(t *testing.T) {
+	tmp := t.TempDir()
+	roots := map[string]string{}
+	for _, name := range []string{"dev", "sandbox"} {
+		roots[name] = filepath.Join(tmp, name)
+		if err := os.MkdirAll(filepath.Join(roots[name], "github.com", "user", "repo"), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+	s := NewService(&config.Config{Roots: roots})
+
+	calls := 0
+	err := s.Stream(Options{}, func(projects []domain.Project) error {
+		calls++
+		if len(projects) != 1 {
+			t.Errorf("expected 1 project per root, got %d", len(projects))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Stream failed: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+
+	// An error from the callback stops further calls and is returned
+	calls = 0
+	err = s.Stream(Options{}, func([]domain.Project) error {
+		calls++
+		return domain.ErrArgumentRequired
+	})
+	if err == nil || calls != 1 {
+		t.Errorf("expected callback error after 1 call, got %v after %d calls", err, calls)
+	}
+}
+
+func TestGetAllWithFilterAndSort(t *testing.T) {
+	tmp := t.TempDir()
+	roots := map[string]string{
+		"dev":     filepath.Join(tmp, "dev"),
+		"sandbox": filepath.Join(tmp, "sandbox"),
+	}
+	for _, p := range []string{
+		filepath.Join(roots["dev"], "github.com", "user", "beta"),
+		filepath.Join(roots["dev"], "github.com", "user", "alpha"),
+		filepath.Join(roots["sandbox"], "github.com", "user", "alpha-tool"),
+	} {
+		if err := os.MkdirAll(p, 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+	s := NewService(&config.Config{Roots: roots})
+
+	projects, err := s.GetAll(Options{Filter: Filter{Query: "alpha"}, Sort: SortName})
+	if err != nil {
+		t.Fatalf("GetAll failed: %v", err)
+	}
+	if len(projects) != 2 || projects[0].Name != "github.com/user/alpha" || projects[1].Name != "github.com/user/alpha-tool" {
+		t.Errorf("unexpected projects: %+v", projects)
+	}
+
+	projects, err = s.GetAll(Options{Filter: Filter{Workspace: "dev"}, Sort: SortModified})
+	if err != nil {
+		t.Fatalf("GetAll failed: %v", err)
+	}
+	if len(projects) != 2 {
+		t.Fatalf("expected 2 dev projects, got %d", len(projects))
+	}
+	for _, p := range projects {
+		if p.Root != "dev" || p.ModTime.IsZero() {
+			t.Errorf("expected dev project with mod time, got %+v", p)
+		}
+	}
+
+	if _, err := s.GetAll(Options{Filter: Filter{Workspace: "nope"}}); err == nil {
+		t.Error("expected error for unknown workspace in filter")
+	}
+}
+
+func TestGetAllWithExcluded(t *testing.T) {
+	tmp := t.TempDir()
+	for _, dir := range []string{"github.com/user/repo", "github.com/user/tmp"} {
+		if err := os.MkdirAll(filepath.Join(tmp, filepath.FromSlash(dir)), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+
+	cfg := &config.Config{
+		Roots:       map[string]string{"dev": tmp},
+		RootOptions: map[string]config.RootOptions{"dev": {Exclude: []string{"tmp"}}},
+	}
+	svc := NewService(cfg)
+
+	projects, excluded, err := svc.GetAllWithExcluded(Options{})
+	if err != nil || len(projects) != 1 {
+		t.Fatalf("GetAllWithExcluded: %v, %v", projects, err)
+	}
+	if len(excluded) != 1 || excluded[0].Pattern != "tmp" {
+		t.Errorf("excluded = %v", excluded)
+	}
+}
+
+func TestGetAllEnrichesOtherVCS(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	// A fake hg reporting a modified file on branch "stable"
+	bin := t.TempDir()
+	script := "#!/bin/sh\ncase \"$1\" in\nstatus) echo 'M a.txt' ;;\nbranch) echo stable ;;\nesac\n"
+	if err := os.WriteFile(filepath.Join(bin, "hg"), []byte(script), 0755); err != nil {
+		t.Fatalf("write hg: %v", err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	tmp := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmp, "hg.example.com", "user", "repo", ".hg"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	s := NewService(&config.Config{Roots: map[string]string{"dev": tmp}})
+	projects, err := s.GetAll(Options{CheckDirty: true, LoadBranch: true})
+	if err != nil {
+		t.Fatalf("GetAll failed: %v", err)
+	}
+	if len(projects) != 1 {
+		t.Fatalf("expected 1 project, got %d", len(projects))
+	}
+
+	p := projects[0]
+	if p.VCS != domain.VCSMercurial || p.HasGit {
+		t.Errorf("unexpected VCS %q (HasGit %v)", p.VCS, p.HasGit)
+	}
+	if !p.Dirty || p.Type != domain.ProjectTypeDirty || p.Branch != "stable" {
+		t.Errorf("expected dirty project on stable, got %+v", p)
+	}
+
+	// --dirty applies to every VCS with a working copy
+	projects, _ = s.GetAll(Options{Filter: Filter{Dirty: true}})
+	if len(projects) != 1 {
+		t.Errorf("dirty filter should keep the hg repository, got %d projects", len(projects))
+	}
+}
@@ -0,0 +1,67 @@
+package status
+
+import (
+	"sort"
+
+	"github.com/mi8bi/ghqx/internal/domain"
+)
+
+// SortKey selects the order of projects returned by GetAll.
+type SortKey string
+
+const (
+	// SortNone keeps the scan order
+	SortNone SortKey = ""
+	// SortName orders by project name
+	SortName SortKey = "name"
+	// SortWorkspace orders by workspace root, then project name
+	SortWorkspace SortKey = "workspace"
+	// SortModified orders by last modification time, newest first
+	SortModified SortKey = "modified"
+	// SortLastCommit orders by last commit date, newest first
+	SortLastCommit SortKey = "last-commit"
+)
+
+// ParseSortKey validates a sort key given on the command line.
+func ParseSortKey(s string) (SortKey, error) {
+	switch key := SortKey(s); key {
+	case SortNone, SortName, SortWorkspace, SortModified, SortLastCommit:
+		return key, nil
+	}
+	return SortNone, domain.ErrStatusInvalidSort(s)
+}
+
+// SortProjects orders projects in place by the given key.
+// Ties are broken by workspace root and name so the order is always stable.
+func SortProjects(projects []domain.Project, key SortKey) {
+	if key == SortNone {
+		return
+	}
+
+	byWorkspace := func(a, b domain.Project) bool {
+		if a.Root != b.Root {
+			return a.Root < b.Root
+		}
+		return a.Name < b.Name
+	}
+
+	sort.SliceStable(projects, func(i, j int) bool {
+		a, b := projects[i], projects[j]
+		switch key {
+		case SortName:
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+		case SortModified:
+			if !a.ModTime.Equal(b.ModTime) {
+				return a.ModTime.After(b.ModTime)
+			}
+		case SortLastCommit:
+			// Zero times (no commits, not a git repository) sort last
+			if !a.Git.LastCommit.Equal(b.Git.LastCommit) {
+				return a.Git.LastCommit.After(b.Git.LastCommit)
+			}
+		}
+		return byWorkspace(a, b)
+	})
+}
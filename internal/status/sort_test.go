@@ -0,0 +1,56 @@
+package status
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mi8bi/ghqx/internal/domain"
+)
+
+func TestParseSortKey(t *testing.T) {
+	for _, s := range []string{"", "name", "workspace", "modified", "last-commit"} {
+		if _, err := ParseSortKey(s); err != nil {
+			t.Errorf("ParseSortKey(%q) failed: %v", s, err)
+		}
+	}
+	if _, err := ParseSortKey("size"); err == nil {
+		t.Error("expected error for unknown sort key")
+	}
+}
+
+func TestSortProjects(t *testing.T) {
+	now := time.Now()
+	projects := func() []domain.Project {
+		return []domain.Project{
+			{Name: "b", Root: "dev", ModTime: now.Add(-time.Hour)},
+			{Name: "a", Root: "sandbox", ModTime: now, Git: domain.GitStatus{LastCommit: now.Add(-2 * time.Hour)}},
+			{Name: "c", Root: "dev", ModTime: now.Add(-2 * time.Hour), Git: domain.GitStatus{LastCommit: now}},
+		}
+	}
+	names := func(ps []domain.Project) string {
+		s := ""
+		for _, p := range ps {
+			s += p.Name
+		}
+		return s
+	}
+
+	testCases := []struct {
+		key  SortKey
+		want string
+	}{
+		{SortNone, "bac"},
+		{SortName, "abc"},
+		{SortWorkspace, "bca"},
+		{SortModified, "abc"},
+		{SortLastCommit, "cab"},
+	}
+
+	for _, tc := range testCases {
+		ps := projects()
+		SortProjects(ps, tc.key)
+		if got := names(ps); got != tc.want {
+			t.Errorf("SortProjects(%q) = %s, want %s", tc.key, got, tc.want)
+		}
+	}
+}
@@ -20,10 +20,11 @@ type errorMsg struct {
 // loadProjects はプロジェクトを非同期で読み込む
 func (m StatusModel) loadProjects() tea.Cmd {
 	return func() tea.Msg {
-		opts := status.Options{
-			CheckDirty: true,
-			LoadBranch: false,
-		}
+		// フィルタ・ソート条件はそのまま使い、表示に必要な情報の読み込みを追加する
+		opts := m.opts
+		opts.CheckDirty = true
+		// 同期状態・変更数・スタッシュ・最終コミットも表示する
+		opts.LoadGitStatus = true
 
 		projects, err := m.app.Status.GetAll(opts)
 		if err != nil {
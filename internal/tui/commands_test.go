@@ -7,6 +7,7 @@ import (
 
 	"github.com/mi8bi/ghqx/internal/app"
 	"github.com/mi8bi/ghqx/internal/config"
+	"github.com/mi8bi/ghqx/internal/status"
 )
 
 func TestLoadProjects(t *testing.T) {
@@ -94,3 +95,23 @@ func TestErrorMsg(t *testing.T) {
 		t.Error("error mismatch")
 	}
 }
+
+func TestLoadProjectsAppliesFilter(t *testing.T) {
+	tmp := t.TempDir()
+	for _, name := range []string{"alpha", "beta"} {
+		if err := os.MkdirAll(filepath.Join(tmp, "github.com", "user", name), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+
+	model := NewStatusModel(app.New(&config.Config{Roots: map[string]string{"sandbox": tmp}}))
+	model.opts = status.Options{Filter: status.Filter{Query: "beta"}}
+
+	msg, ok := model.loadProjects()().(projectsLoadedMsg)
+	if !ok {
+		t.Fatalf("expected projectsLoadedMsg")
+	}
+	if len(msg.projects) != 1 || msg.projects[0].Repo != "user/beta" {
+		t.Errorf("expected only user/beta, got %+v", msg.projects)
+	}
+}
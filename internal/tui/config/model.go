@@ -2,10 +2,12 @@ package configtui
 
 import (
 	"fmt"
+	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/mi8bi/ghqx/internal/config"
 	"github.com/mi8bi/ghqx/internal/domain"
+	"github.com/mi8bi/ghqx/internal/i18n"
 )
 
 // Model は config editor の Bubble Tea モデル
@@ -14,6 +16,8 @@ type Model struct {
 	cursor      int
 	state       EditState
 	editValue   string // 編集中の値
+	pendingRoot string // 追加・名前変更・削除の対象のルート名
+	pendingPath string // 作成を確認中のディレクトリ
 	message     string
 	messageType MessageType
 	width       int
@@ -59,21 +63,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case saveSuccessMsg:
 		m.state = EditStateList
-		m.message = "設定を保存しました"
+		m.message = i18n.T("config.edit.saved")
 		m.messageType = MessageTypeSuccess
 		m.editor.Modified = false
 		return m, nil
 
 	case saveErrorMsg:
 		m.state = EditStateList
-		if ghqxErr, ok := msg.err.(*domain.GhqxError); ok {
-			m.message = "保存失敗: " + ghqxErr.Message
-			if ghqxErr.Hint != "" {
-				m.message += " (" + ghqxErr.Hint + ")"
-			}
-		} else {
-			m.message = "保存失敗: " + msg.err.Error()
-		}
+		m.message = fmt.Sprintf(i18n.T("config.edit.saveFailed"), errorText(msg.err))
 		m.messageType = MessageTypeError
 		return m, nil
 	}
@@ -81,6 +78,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// errorText はエラーをメッセージ欄に表示する文字列にする
+func errorText(err error) string {
+	if ghqxErr, ok := err.(*domain.GhqxError); ok {
+		text := ghqxErr.Message
+		if ghqxErr.Hint != "" {
+			text += " (" + ghqxErr.Hint + ")"
+		}
+		return text
+	}
+	return err.Error()
+}
+
+// setMessage はメッセージ欄を更新する
+func (m *Model) setMessage(text string, messageType MessageType) {
+	m.message = text
+	m.messageType = messageType
+}
+
 // handleKeyPress はキーボード入力を処理する
 func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch m.state {
@@ -88,6 +103,10 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleListKeys(msg)
 	case EditStateEdit:
 		return m.handleEditKeys(msg)
+	case EditStateAddName, EditStateAddPath, EditStateRename:
+		return m.handleInputKeys(msg)
+	case EditStateConfirmRemove, EditStateConfirmCreate:
+		return m.handleConfirmKeys(msg)
 	default:
 		return m, nil
 	}
@@ -98,7 +117,7 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "ctrl+c", "q":
 		if m.editor.Modified {
-			m.message = "未保存の変更があります。Ctrl+S で保存するか、Ctrl+Q で破棄して終了"
+			m.message = i18n.T("config.edit.unsaved")
 			m.messageType = MessageTypeInfo
 			return m, nil
 		}
@@ -132,6 +151,33 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.messageType = MessageTypeNone
 		return m, nil
 
+	case "a":
+		// ルートを追加 (名前 → パスの順に入力)
+		m.state = EditStateAddName
+		m.editValue = ""
+		m.pendingRoot = ""
+		m.setMessage("", MessageTypeNone)
+		return m, nil
+
+	case "r":
+		// ルート名を変更
+		if root := m.editor.Fields[m.cursor].Root; root != "" {
+			m.state = EditStateRename
+			m.editValue = root
+			m.pendingRoot = root
+			m.setMessage("", MessageTypeNone)
+		}
+		return m, nil
+
+	case "d", "delete":
+		// ルートを削除 (確認後)
+		if root := m.editor.Fields[m.cursor].Root; root != "" {
+			m.state = EditStateConfirmRemove
+			m.pendingRoot = root
+			m.setMessage("", MessageTypeNone)
+		}
+		return m, nil
+
 	case "ctrl+s":
 		// 保存
 		return m, m.saveConfig()
@@ -146,12 +192,25 @@ func (m Model) handleEditKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	switch msg.String() {
 	case "esc", "q": // Added 'q' for canceling selection
+		if field.Type == FieldTypeString && msg.String() == "q" {
+			// 文字列の入力中は q も入力する
+			m.editValue += "q"
+			return m, nil
+		}
 		// キャンセル
 		m.state = EditStateList
 		m.editValue = ""
 		return m, nil
 
 	case "enter":
+		// ルートのパスは検証してから確定する
+		if field.Root != "" {
+			if m.commitRootPath(field.Root, m.editValue, m.cursor) {
+				m.setMessage("", MessageTypeNone)
+			}
+			return m, nil
+		}
+
 		// 確定
 		switch field.Type {
 		case FieldTypeBool:
@@ -205,25 +264,163 @@ func (m Model) handleEditKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
-	case "backspace":
-		if field.Type == FieldTypeString && len(m.editValue) > 0 { // Only allow backspace for string type
-			m.editValue = m.editValue[:len(m.editValue)-1]
+	default:
+		// 文字入力 (FieldTypeString のみ)
+		if field.Type == FieldTypeString {
+			m.editValue = editText(m.editValue, msg)
+		}
+		return m, nil
+	}
+}
+
+// editText は文字入力と Backspace を value に反映する
+func editText(value string, msg tea.KeyMsg) string {
+	switch msg.Type {
+	case tea.KeyBackspace:
+		if runes := []rune(value); len(runes) > 0 {
+			return string(runes[:len(runes)-1])
+		}
+	case tea.KeySpace:
+		return value + " "
+	case tea.KeyRunes:
+		return value + string(msg.Runes)
+	}
+	return value
+}
+
+// commitRootPath は検証したパスをフィールドに反映する
+// ディレクトリが存在しない場合は作成を確認し、使えないパスの場合は false を返す
+func (m *Model) commitRootPath(root, path string, index int) bool {
+	status, _ := m.editor.CheckPath(root, path)
+	if !status.Valid() {
+		m.setMessage(m.pathStatusText(root, path), MessageTypeError)
+		return false
+	}
+
+	m.editor.UpdateField(index, path)
+	m.state = EditStateList
+	m.editValue = ""
+
+	if status == PathMissing {
+		m.state = EditStateConfirmCreate
+		m.pendingPath = path
+	}
+	return true
+}
+
+// handleInputKeys はルート名・パスの入力中のキー処理
+func (m Model) handleInputKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.state = EditStateList
+		m.editValue = ""
+		m.setMessage("", MessageTypeNone)
+		return m, nil
+
+	case "enter":
+		value := m.editValue
+		switch m.state {
+		case EditStateAddName:
+			if err := m.editor.Config.ValidateRootName(value); err != nil {
+				m.setMessage(errorText(err), MessageTypeError)
+				return m, nil
+			}
+			m.pendingRoot = value
+			m.state = EditStateAddPath
+			m.editValue = m.editor.SuggestPath(value)
+			m.setMessage("", MessageTypeNone)
+			return m, nil
+
+		case EditStateAddPath:
+			if status, _ := m.editor.CheckPath(m.pendingRoot, value); !status.Valid() {
+				m.setMessage(m.pathStatusText(m.pendingRoot, value), MessageTypeError)
+				return m, nil
+			}
+			index, err := m.editor.AddRoot(m.pendingRoot, value)
+			if err != nil {
+				m.setMessage(errorText(err), MessageTypeError)
+				return m, nil
+			}
+			m.cursor = index
+			m.commitRootPath(m.pendingRoot, value, index)
+			m.setMessage(fmt.Sprintf(i18n.T("config.edit.rootAdded"), m.pendingRoot), MessageTypeSuccess)
+			return m, nil
+
+		case EditStateRename:
+			index, err := m.editor.RenameRoot(m.pendingRoot, value)
+			if err != nil {
+				m.setMessage(errorText(err), MessageTypeError)
+				return m, nil
+			}
+			m.cursor = index
+			m.state = EditStateList
+			m.editValue = ""
+			m.setMessage(fmt.Sprintf(i18n.T("config.edit.rootRenamed"), m.pendingRoot, value), MessageTypeSuccess)
+			return m, nil
 		}
 		return m, nil
 
 	default:
-		// 文字入力 (FieldTypeString のみ)
-		if field.Type == FieldTypeString && len(msg.String()) == 1 {
-			m.editValue += msg.String()
+		m.editValue = editText(m.editValue, msg)
+		return m, nil
+	}
+}
+
+// handleConfirmKeys は y/n の確認中のキー処理
+func (m Model) handleConfirmKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		if m.state == EditStateConfirmRemove {
+			if err := m.editor.RemoveRoot(m.pendingRoot); err != nil {
+				m.setMessage(errorText(err), MessageTypeError)
+			} else {
+				m.setMessage(fmt.Sprintf(i18n.T("config.edit.rootRemoved"), m.pendingRoot), MessageTypeSuccess)
+				if m.cursor >= len(m.editor.Fields) {
+					m.cursor = len(m.editor.Fields) - 1
+				}
+			}
+		} else {
+			if err := os.MkdirAll(config.ExpandPath(m.pendingPath), 0755); err != nil {
+				m.setMessage(errorText(err), MessageTypeError)
+			} else {
+				m.setMessage(fmt.Sprintf(i18n.T("config.edit.directoryCreated"), m.pendingPath), MessageTypeSuccess)
+			}
 		}
+
+	case "n", "N", "esc", "q":
+		m.setMessage("", MessageTypeNone)
+
+	default:
 		return m, nil
 	}
+
+	m.state = EditStateList
+	m.pendingRoot = ""
+	m.pendingPath = ""
+	return m, nil
+}
+
+// pathStatusText はパスの検証結果の説明を返す
+func (m Model) pathStatusText(root, path string) string {
+	status, other := m.editor.CheckPath(root, path)
+	switch status {
+	case PathOK:
+		return i18n.T("config.edit.path.ok")
+	case PathMissing:
+		return i18n.T("config.edit.path.missing")
+	case PathEmpty:
+		return i18n.T("config.edit.path.empty")
+	case PathNotDir:
+		return i18n.T("config.edit.path.notDir")
+	default:
+		return fmt.Sprintf(i18n.T("config.edit.path.nested"), other)
+	}
 }
 
 // View は Bubble Tea の画面描画
 func (m Model) View() string {
 	if m.state == EditStateSaving {
-		return styleTitleBar.Render("ghqx config edit - 保存中...") + "\n\n"
+		return styleTitleBar.Render("ghqx config edit - "+i18n.T("config.edit.saving")) + "\n\n"
 	}
 
 	s := styleTitleBar.Render("ghqx config edit") + "\n\n"
@@ -233,6 +430,9 @@ func (m Model) View() string {
 		s += m.renderField(field, i == m.cursor) + "\n"
 	}
 
+	// 入力・確認
+	s += m.renderPrompt()
+
 	// メッセージ
 	if m.message != "" {
 		s += "\n"
@@ -251,9 +451,9 @@ func (m Model) View() string {
 	s += "\n"
 	status := ""
 	if m.editor.Modified {
-		status = styleModified.Render("[変更あり]") + " "
+		status = styleModified.Render(i18n.T("config.edit.modified")) + " "
 	}
-	status += fmt.Sprintf("設定: %s", m.editor.ConfigPath)
+	status += fmt.Sprintf(i18n.T("config.edit.configPath"), m.editor.ConfigPath)
 	s += styleStatusBar.Render(status) + "\n"
 
 	// ヘルプ
@@ -279,6 +479,10 @@ func (m Model) renderField(field Field, selected bool) string {
 	}
 
 	desc := styleFieldDescription.Render("  " + field.Description)
+	if m.state == EditStateEdit && selected && field.Root != "" {
+		// 入力中のパスを検証した結果
+		desc = "  " + m.renderPathStatus(field.Root, m.editValue)
+	}
 
 	line := fmt.Sprintf("%s  %s\n%s", name, value, desc)
 
@@ -288,6 +492,45 @@ func (m Model) renderField(field Field, selected bool) string {
 	return styleNormalField.Render(line)
 }
 
+// renderPathStatus はパスの検証結果を描画する
+func (m Model) renderPathStatus(root, path string) string {
+	text := m.pathStatusText(root, path)
+	status, _ := m.editor.CheckPath(root, path)
+	switch {
+	case status == PathOK:
+		return styleSuccessMessage.Render("✓ " + text)
+	case status.Valid():
+		return styleInfoMessage.Render("• " + text)
+	default:
+		return styleErrorMessage.Render("✗ " + text)
+	}
+}
+
+// renderPrompt はルートの追加・名前変更の入力欄と確認を描画する
+func (m Model) renderPrompt() string {
+	var label string
+	switch m.state {
+	case EditStateAddName:
+		label = i18n.T("config.edit.prompt.addName")
+	case EditStateAddPath:
+		label = fmt.Sprintf(i18n.T("config.edit.prompt.addPath"), m.pendingRoot)
+	case EditStateRename:
+		label = fmt.Sprintf(i18n.T("config.edit.prompt.rename"), m.pendingRoot)
+	case EditStateConfirmRemove:
+		return "\n" + styleInfoMessage.Render("? "+fmt.Sprintf(i18n.T("config.edit.confirm.remove"), m.pendingRoot)) + "\n"
+	case EditStateConfirmCreate:
+		return "\n" + styleInfoMessage.Render("? "+fmt.Sprintf(i18n.T("config.edit.confirm.create"), m.pendingPath)) + "\n"
+	default:
+		return ""
+	}
+
+	s := "\n" + styleFieldName.Render(label) + "  " + styleEditInput.Render("> "+m.editValue+"_") + "\n"
+	if m.state == EditStateAddPath {
+		s += "  " + m.renderPathStatus(m.pendingRoot, m.editValue) + "\n"
+	}
+	return s
+}
+
 // renderHelp はヘルプテキストを描画する
 func (m Model) renderHelp() string {
 	var help string
@@ -297,16 +540,20 @@ func (m Model) renderHelp() string {
 		field := m.editor.Fields[m.cursor]
 		switch field.Type {
 		case FieldTypeBool:
-			help = "Enter/Space: 切替 | Esc/q: キャンセル"
+			help = i18n.T("config.edit.help.bool")
 		case FieldTypeSelection:
-			help = "←→: 移動 | Enter: 確定 | Esc/q: キャンセル" // Updated help for selection
+			help = i18n.T("config.edit.help.selection")
 		default:
-			help = "Enter: 確定 | Esc/q: キャンセル | Backspace: 削除"
+			help = i18n.T("config.edit.help.input")
 		}
+	case EditStateAddName, EditStateAddPath, EditStateRename:
+		help = i18n.T("config.edit.help.input")
+	case EditStateConfirmRemove, EditStateConfirmCreate:
+		help = i18n.T("config.edit.help.confirm")
 	default:
-		help = "↑↓/jk: 移動 | Enter: 編集 | Ctrl+S: 保存 | q: 終了"
+		help = i18n.T("config.edit.help.list")
 		if m.editor.Modified {
-			help += " | Ctrl+Q: 破棄して終了"
+			help += i18n.T("config.edit.help.forceQuit")
 		}
 	}
 
@@ -8,6 +8,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/mi8bi/ghqx/internal/config"
+	"github.com/mi8bi/ghqx/internal/i18n"
 )
 
 func TestNewModel(t *testing.T) {
@@ -149,7 +150,7 @@ func TestModelUpdateEditMode(t *testing.T) {
 	model := NewModel(cfg, "/tmp/config.toml")
 	model.state = EditStateEdit
 	model.cursor = 0
-	model.editValue = "/tmp/test"
+	model.editValue = t.TempDir() // Root paths are validated before they are confirmed
 
 	// Test text input (for string fields)
 	keyMsg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}}
@@ -353,7 +354,7 @@ func TestRenderHelp(t *testing.T) {
 	}
 
 	// Test edit mode help for selection field
-	model.cursor = 3
+	model.cursor = len(model.editor.Fields) - 1
 	help = model.renderHelp()
 	if help == "" {
 		t.Error("selection help should not be empty")
@@ -448,3 +449,146 @@ func TestSaveWithValidPath(t *testing.T) {
 		t.Errorf("expected saveSuccessMsg, got %T", msg)
 	}
 }
+
+// sendKeys feeds key presses to the model; plain strings are typed as runes.
+func sendKeys(m Model, keys ...any) Model {
+	for _, key := range keys {
+		var msg tea.KeyMsg
+		switch k := key.(type) {
+		case tea.KeyType:
+			msg = tea.KeyMsg{Type: k}
+		case string:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		}
+		next, _ := m.Update(msg)
+		m = next.(Model)
+	}
+	return m
+}
+
+func TestModelAddRootAndCreateDirectory(t *testing.T) {
+	tmp := t.TempDir()
+	cfg := &config.Config{
+		Roots:   map[string]string{"dev": filepath.Join(tmp, "dev")},
+		Default: config.DefaultConfig{Root: "dev"},
+	}
+	model := NewModel(cfg, filepath.Join(tmp, "config.toml"))
+
+	// Invalid names keep the prompt open
+	model = sendKeys(model, "a", "a.b", tea.KeyEnter)
+	if model.state != EditStateAddName || model.messageType != MessageTypeError {
+		t.Fatalf("expected an error for an invalid name, state %v", model.state)
+	}
+
+	// The path is suggested next to the default root
+	model = sendKeys(model, tea.KeyEsc, "a", "work", tea.KeyEnter)
+	workPath := filepath.Join(tmp, "work")
+	if model.state != EditStateAddPath || model.editValue != workPath {
+		t.Fatalf("expected path prompt with %q, got %v %q", workPath, model.state, model.editValue)
+	}
+	if !strings.Contains(model.View(), "work") {
+		t.Error("view should show the path prompt")
+	}
+
+	// Missing directories can be created
+	model = sendKeys(model, tea.KeyEnter)
+	if model.state != EditStateConfirmCreate || cfg.Roots["work"] != workPath {
+		t.Fatalf("expected a create prompt after adding, state %v", model.state)
+	}
+	if model.editor.Fields[model.cursor].Root != "work" {
+		t.Error("cursor should move to the new root")
+	}
+	model = sendKeys(model, "y")
+	if model.state != EditStateList {
+		t.Errorf("expected list state, got %v", model.state)
+	}
+	if info, err := os.Stat(workPath); err != nil || !info.IsDir() {
+		t.Errorf("directory was not created: %v", err)
+	}
+}
+
+func TestModelRejectsOverlappingPaths(t *testing.T) {
+	tmp := t.TempDir()
+	cfg := &config.Config{
+		Roots:   map[string]string{"dev": tmp, "sandbox": filepath.Join(t.TempDir(), "sandbox")},
+		Default: config.DefaultConfig{Root: "dev"},
+	}
+	model := NewModel(cfg, filepath.Join(tmp, "config.toml"))
+
+	// Edit sandbox (second field) to a path inside dev
+	model.cursor = 1
+	model = sendKeys(model, tea.KeyEnter)
+	model.editValue = filepath.Join(tmp, "inner")
+	if !strings.Contains(model.View(), "dev") {
+		t.Error("view should name the overlapping root while typing")
+	}
+
+	model = sendKeys(model, tea.KeyEnter)
+	if model.state != EditStateEdit || model.messageType != MessageTypeError {
+		t.Errorf("overlapping paths should not be accepted, state %v", model.state)
+	}
+	if model.editor.Fields[1].Value == model.editValue {
+		t.Error("field should keep its old value")
+	}
+}
+
+func TestModelRenameAndRemoveRoot(t *testing.T) {
+	tmp := t.TempDir()
+	cfg := &config.Config{
+		Roots:   map[string]string{"dev": tmp, "sandbox": filepath.Join(tmp, "..", "sandbox")},
+		Default: config.DefaultConfig{Root: "dev"},
+	}
+	model := NewModel(cfg, filepath.Join(tmp, "config.toml"))
+
+	// Rename dev; the default root follows
+	model = sendKeys(model, "r", tea.KeyBackspace, tea.KeyBackspace, tea.KeyBackspace, "work", tea.KeyEnter)
+	if _, ok := cfg.Roots["work"]; !ok || cfg.Default.Root != "work" {
+		t.Fatalf("rename failed: %+v", cfg)
+	}
+	if model.editor.Fields[model.cursor].Root != "work" {
+		t.Error("cursor should stay on the renamed root")
+	}
+
+	// The default root cannot be removed
+	model = sendKeys(model, "d", "y")
+	if model.messageType != MessageTypeError {
+		t.Error("removing the default root should fail")
+	}
+
+	// n cancels, y removes
+	model.cursor = 0 // sandbox
+	model = sendKeys(model, "d", "n")
+	if _, ok := cfg.Roots["sandbox"]; !ok {
+		t.Fatal("n should cancel the removal")
+	}
+	model = sendKeys(model, "d")
+	if !strings.Contains(model.View(), "sandbox") {
+		t.Error("view should ask about sandbox")
+	}
+	model = sendKeys(model, "y")
+	if _, ok := cfg.Roots["sandbox"]; ok || !model.editor.Modified {
+		t.Error("sandbox should be removed")
+	}
+}
+
+func TestModelViewEnglish(t *testing.T) {
+	i18n.SetLocale(i18n.LocaleEN)
+	defer i18n.SetLocale(i18n.LocaleJA)
+
+	cfg := &config.Config{
+		Roots:   map[string]string{"dev": "/tmp/dev"},
+		Default: config.DefaultConfig{Root: "dev"},
+	}
+	model := NewModel(cfg, "/tmp/config.toml")
+	model.editor.Modified = true
+
+	view := model.View()
+	for _, want := range []string{"Default root", "[modified]", "Add root"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("English view missing %q", want)
+		}
+	}
+	if strings.Contains(view, "変更あり") {
+		t.Error("English view should not contain Japanese labels")
+	}
+}
@@ -1,30 +1,40 @@
 package configtui
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
-	"sort" // Added this import
 
 	"github.com/mi8bi/ghqx/internal/config"
+	"github.com/mi8bi/ghqx/internal/i18n"
 )
 
 // EditState は編集状態を表す
 type EditState int
 
 const (
-	EditStateList EditState = iota // リスト表示
-	EditStateEdit                   // 編集中
-	EditStateSaving                 // 保存中
+	EditStateList          EditState = iota // リスト表示
+	EditStateEdit                           // 編集中
+	EditStateSaving                         // 保存中
+	EditStateAddName                        // 追加するルート名の入力中
+	EditStateAddPath                        // 追加するルートのパスの入力中
+	EditStateRename                         // ルート名の入力中
+	EditStateConfirmRemove                  // ルート削除の確認中
+	EditStateConfirmCreate                  // ディレクトリ作成の確認中
 )
 
 // Field は編集可能なフィールド
 type Field struct {
-	Name        string      // 表示名
-	Key         string      // 内部キー
-	Value       string      // 現在の値
-	DefaultValue string     // デフォルト値
-	Description string      // 説明
-	Type        FieldType   // フィールドタイプ
-	Options     []string    // FieldTypeSelection の場合に選択肢を保持
+	Name         string    // 表示名
+	Key          string    // 内部キー
+	Root         string    // ルートのパスのフィールドではルート名
+	Value        string    // 現在の値
+	DefaultValue string    // デフォルト値
+	Description  string    // 説明
+	Type         FieldType // フィールドタイプ
+	Options      []string  // FieldTypeSelection の場合に選択肢を保持
 }
 
 // FieldType はフィールドの種類
@@ -37,12 +47,28 @@ const (
 	FieldTypeSelection // Added this
 )
 
+// PathStatus はルートのパスの検証結果
+type PathStatus int
+
+const (
+	PathOK      PathStatus = iota // ディレクトリが存在する
+	PathMissing                   // 存在しない (作成できる)
+	PathEmpty                     // 未入力
+	PathNotDir                    // ディレクトリ以外が存在する
+	PathNested                    // 他のルートと重なっている
+)
+
+// Valid はパスをルートとして使えるかを返す
+func (s PathStatus) Valid() bool {
+	return s == PathOK || s == PathMissing
+}
+
 // ConfigEditor は設定エディタのデータ
 type ConfigEditor struct {
-	Config      *config.Config
-	Fields      []Field
-	Modified    bool
-	ConfigPath  string
+	Config     *config.Config
+	Fields     []Field
+	Modified   bool
+	ConfigPath string
 }
 
 // NewConfigEditor は ConfigEditor を作成する
@@ -57,50 +83,34 @@ func NewConfigEditor(cfg *config.Config, configPath string) *ConfigEditor {
 	return editor
 }
 
-// buildFields は編集可能なフィールドを構築する
+// buildFields は Config のルートごとのパスとデフォルトルートのフィールドを構築する
 func (e *ConfigEditor) buildFields() {
-	// Get sorted root names for selection options
 	var rootNames []string
 	for name := range e.Config.Roots {
 		rootNames = append(rootNames, name)
 	}
 	sort.Strings(rootNames)
 
-	e.Fields = []Field{
-		{
-			Name:        "dev ルート",
-			Key:         "roots.dev",
-			Value:       e.Config.Roots["dev"],
-			DefaultValue: "",
-			Description: "dev ワークスペースのパス",
+	e.Fields = make([]Field, 0, len(rootNames)+1)
+	for _, name := range rootNames {
+		e.Fields = append(e.Fields, Field{
+			Name:        name,
+			Key:         "roots." + name,
+			Root:        name,
+			Value:       e.Config.RawRootPath(name),
+			Description: fmt.Sprintf(i18n.T("config.edit.field.root.description"), e.Config.RootWorkspaceType(name)),
 			Type:        FieldTypeString,
-		},
-		{
-			Name:        "release ルート",
-			Key:         "roots.release",
-			Value:       e.Config.Roots["release"],
-			DefaultValue: "",
-			Description: "release ワークスペースのパス",
-			Type:        FieldTypeString,
-		},
-		{
-			Name:        "sandbox ルート",
-			Key:         "roots.sandbox",
-			Value:       e.Config.Roots["sandbox"],
-			DefaultValue: "",
-			Description: "sandbox ワークスペースのパス",
-			Type:        FieldTypeString,
-		},
-		{
-			Name:        "デフォルトルート",
-			Key:         "default.root",
-			Value:       e.Config.Default.Root,
-			DefaultValue: "dev",
-			Description: "デフォルトで使用するルート",
-			Type:        FieldTypeSelection, // Changed to FieldTypeSelection
-			Options:     rootNames,          // Populated with root names
-		},
+		})
 	}
+
+	e.Fields = append(e.Fields, Field{
+		Name:        i18n.T("config.edit.field.defaultRoot.name"),
+		Key:         "default.root",
+		Value:       e.Config.Default.Root,
+		Description: i18n.T("config.edit.field.defaultRoot.description"),
+		Type:        FieldTypeSelection,
+		Options:     rootNames,
+	})
 }
 
 // UpdateField はフィールドの値を更新する
@@ -116,19 +126,98 @@ func (e *ConfigEditor) UpdateField(index int, value string) {
 // ApplyChanges は変更を Config に反映する
 func (e *ConfigEditor) ApplyChanges() {
 	for _, field := range e.Fields {
-		switch field.Key {
-		case "roots.dev":
-			e.Config.Roots["dev"] = field.Value
-		case "roots.release":
-			e.Config.Roots["release"] = field.Value
-		case "roots.sandbox":
-			e.Config.Roots["sandbox"] = field.Value
-		case "default.root":
+		switch {
+		case field.Root != "":
+			e.Config.SetRootPath(field.Root, field.Value)
+		case field.Key == "default.root":
 			e.Config.Default.Root = field.Value
 		}
 	}
 }
 
+// AddRoot はルートを追加し、追加したフィールドの位置を返す
+func (e *ConfigEditor) AddRoot(name, path string) (int, error) {
+	e.ApplyChanges()
+	if err := e.Config.AddRoot(name, path); err != nil {
+		return 0, err
+	}
+	e.rebuild()
+	return e.rootIndex(name), nil
+}
+
+// RemoveRoot はルートとその設定を削除する (ディレクトリは残す)
+func (e *ConfigEditor) RemoveRoot(name string) error {
+	e.ApplyChanges()
+	if err := e.Config.RemoveRoot(name); err != nil {
+		return err
+	}
+	e.rebuild()
+	return nil
+}
+
+// RenameRoot はルート名を変更し、変更後のフィールドの位置を返す
+func (e *ConfigEditor) RenameRoot(oldName, newName string) (int, error) {
+	e.ApplyChanges()
+	if err := e.Config.RenameRoot(oldName, newName); err != nil {
+		return 0, err
+	}
+	e.rebuild()
+	return e.rootIndex(newName), nil
+}
+
+// rebuild はルートの追加・削除・名前変更の後にフィールドを作り直す
+func (e *ConfigEditor) rebuild() {
+	e.buildFields()
+	e.Modified = true
+}
+
+// rootIndex はルートのフィールドの位置を返す (見つからない場合は 0)
+func (e *ConfigEditor) rootIndex(name string) int {
+	for i, field := range e.Fields {
+		if field.Root == name {
+			return i
+		}
+	}
+	return 0
+}
+
+// CheckPath は path を root のパスとして検証する (~ と環境変数は展開する)
+// PathNested の場合は重なっているルート名も返す
+func (e *ConfigEditor) CheckPath(root, path string) (PathStatus, string) {
+	if path == "" {
+		return PathEmpty, ""
+	}
+	path = config.ExpandPath(path)
+
+	// 編集中の値も含めて他のルートと比較する
+	for _, field := range e.Fields {
+		if field.Root == "" || field.Root == root || field.Value == "" {
+			continue
+		}
+		if config.PathsOverlap(path, config.ExpandPath(field.Value)) {
+			return PathNested, field.Root
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return PathMissing, ""
+	}
+	if !info.IsDir() {
+		return PathNotDir, ""
+	}
+	return PathOK, ""
+}
+
+// SuggestPath は新しいルートのパスの候補を返す (デフォルトルートと同じ階層)
+func (e *ConfigEditor) SuggestPath(name string) string {
+	base := e.Config.RawRootPath(e.Config.Default.Root)
+	if base == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(base), name)
+}
+
 // ヘルパー関数
 
 func boolToString(b bool) string {
@@ -152,4 +241,4 @@ func stringToInt(s string) int {
 		return 0
 	}
 	return i
-}
\ No newline at end of file
+}
@@ -1,6 +1,9 @@
 package configtui
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/mi8bi/ghqx/internal/config"
@@ -71,3 +74,111 @@ func TestBoolStringIntHelpers(t *testing.T) {
 		t.Fatalf("stringToInt(\"bad\") should return 0 for invalid input")
 	}
 }
+
+func TestConfigEditorBuildsFieldsFromRoots(t *testing.T) {
+	cfg := &config.Config{
+		Roots:   map[string]string{"work": "w", "oss": "o", "dev": "d", "sandbox": "s"},
+		Default: config.DefaultConfig{Root: "work"},
+	}
+
+	e := NewConfigEditor(cfg, "/tmp/config")
+	var roots []string
+	for _, field := range e.Fields {
+		if field.Root != "" {
+			roots = append(roots, field.Root)
+		}
+	}
+	if strings.Join(roots, ",") != "dev,oss,sandbox,work" {
+		t.Fatalf("expected a field per root in name order, got %v", roots)
+	}
+
+	last := e.Fields[len(e.Fields)-1]
+	if last.Key != "default.root" || len(last.Options) != 4 {
+		t.Errorf("expected the default root selection last, got %+v", last)
+	}
+}
+
+func TestConfigEditorAddRenameRemoveRoot(t *testing.T) {
+	cfg := &config.Config{
+		Roots:   map[string]string{"dev": "/tmp/ws/dev"},
+		Default: config.DefaultConfig{Root: "dev"},
+	}
+	e := NewConfigEditor(cfg, "/tmp/config")
+
+	if got := e.SuggestPath("work"); got != filepath.Join("/tmp/ws", "work") {
+		t.Errorf("SuggestPath = %q", got)
+	}
+
+	index, err := e.AddRoot("work", "/tmp/ws/work")
+	if err != nil {
+		t.Fatalf("AddRoot failed: %v", err)
+	}
+	if e.Fields[index].Root != "work" || !e.Modified {
+		t.Errorf("expected the new root's field at %d, got %+v", index, e.Fields[index])
+	}
+	if _, err := e.AddRoot("work", "/tmp/elsewhere"); err == nil {
+		t.Error("duplicate roots should be rejected")
+	}
+
+	// Pending edits survive structural changes
+	e.UpdateField(e.rootIndex("dev"), "/tmp/ws/dev2")
+	index, err = e.RenameRoot("work", "oss")
+	if err != nil {
+		t.Fatalf("RenameRoot failed: %v", err)
+	}
+	if e.Fields[index].Root != "oss" || e.Fields[index].Value != "/tmp/ws/work" {
+		t.Errorf("unexpected renamed field %+v", e.Fields[index])
+	}
+	if cfg.Roots["dev"] != "/tmp/ws/dev2" {
+		t.Errorf("pending edit was lost: %v", cfg.Roots)
+	}
+
+	if err := e.RemoveRoot("oss"); err != nil {
+		t.Fatalf("RemoveRoot failed: %v", err)
+	}
+	if _, ok := cfg.Roots["oss"]; ok || len(e.Fields) != 2 {
+		t.Errorf("root was not removed: %v", cfg.Roots)
+	}
+	if err := e.RemoveRoot("dev"); err == nil {
+		t.Error("the default root should not be removable")
+	}
+}
+
+func TestConfigEditorCheckPath(t *testing.T) {
+	tmp := t.TempDir()
+	file := filepath.Join(tmp, "file")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	cfg := &config.Config{
+		Roots:   map[string]string{"dev": tmp, "sandbox": filepath.Join(t.TempDir(), "sandbox")},
+		Default: config.DefaultConfig{Root: "dev"},
+	}
+	e := NewConfigEditor(cfg, "/tmp/config")
+
+	tests := []struct {
+		root, path string
+		want       PathStatus
+	}{
+		{"dev", tmp, PathOK},
+		{"dev", "", PathEmpty},
+		{"dev", filepath.Join(t.TempDir(), "new"), PathMissing},
+		{"new", file, PathNested},
+		{"sandbox", filepath.Join(tmp, "sub"), PathNested},
+		{"sandbox", file, PathNested},
+	}
+	for _, tt := range tests {
+		if got, _ := e.CheckPath(tt.root, tt.path); got != tt.want {
+			t.Errorf("CheckPath(%q, %q) = %v, want %v", tt.root, tt.path, got, tt.want)
+		}
+	}
+
+	e.UpdateField(e.rootIndex("dev"), filepath.Join(t.TempDir(), "dev"))
+	if got, _ := e.CheckPath("sandbox", file); got != PathNotDir {
+		t.Errorf("expected PathNotDir once dev moved away, got %v", got)
+	}
+	if status, other := e.CheckPath("new", cfg.Roots["sandbox"]); status != PathNested || other != "sandbox" {
+		t.Errorf("expected overlap with sandbox, got %v %q", status, other)
+	}
+}
@@ -2,12 +2,14 @@ package tui
 
 import (
 	"fmt"
+	"path/filepath"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/mi8bi/ghqx/internal/app"
 	"github.com/mi8bi/ghqx/internal/domain"
 	"github.com/mi8bi/ghqx/internal/i18n"
+	"github.com/mi8bi/ghqx/internal/status"
 )
 
 // StatusModel は status TUI の Bubble Tea モデル
@@ -31,6 +33,8 @@ type StatusModel struct {
 
 	showDetail bool // 詳細表示モード
 
+	opts status.Options // プロジェクト読み込み時のフィルタ・ソート条件
+
 }
 
 // NewStatusModel は新しい StatusModel を作成する
@@ -46,6 +50,8 @@ func NewStatusModel(application *app.App) StatusModel {
 		cursor: 0,
 
 		viewState: ViewStateLoading,
+
+		opts: status.Options{Sort: status.SortWorkspace},
 	}
 
 }
@@ -244,7 +250,11 @@ func (m StatusModel) renderList() string {
 
 	statusHeader := lipgloss.NewStyle().Width(8).Align(lipgloss.Left).Render(i18n.T("status.header.status"))
 
-	header := fmt.Sprintf("%s %s %s %s", repoHeader, workspaceHeader, gitManagedHeader, statusHeader)
+	syncHeader := lipgloss.NewStyle().Width(12).Align(lipgloss.Left).Render(i18n.T("status.header.sync"))
+
+	changesHeader := lipgloss.NewStyle().Width(12).Align(lipgloss.Left).Render(i18n.T("status.header.changes"))
+
+	header := fmt.Sprintf("%s %s %s %s %s %s", repoHeader, workspaceHeader, gitManagedHeader, statusHeader, syncHeader, changesHeader)
 
 	s += styleHeader.Render(header) + "\n"
 
@@ -284,6 +294,28 @@ func (m StatusModel) renderList() string {
 
 }
 
+// workspace は root の設定 (ワークスペース種別・説明・色) を返す
+
+func (m StatusModel) workspace(name domain.RootName) domain.Root {
+
+	if m.app != nil && m.app.Config != nil {
+
+		for _, root := range m.app.Config.Workspaces() {
+
+			if root.Name == name {
+
+				return root
+
+			}
+
+		}
+
+	}
+
+	return domain.Root{Name: name}
+
+}
+
 // renderDetailView は選択中のプロジェクトの詳細を表示する
 
 func (m StatusModel) renderDetailView() string {
@@ -302,10 +334,18 @@ func (m StatusModel) renderDetailView() string {
 
 	s += fmt.Sprintf("  %s:     %s\n", i18n.T("status.detail.path"), row.FullPath)
 
-	s += fmt.Sprintf("  %s:   %s\n", i18n.T("status.detail.workspace"), getWorkspaceStyle(row.Workspace).Render(row.Workspace)) // Updated to row.Workspace and getWorkspaceStyle
+	root := m.workspace(proj.Root)
+
+	s += fmt.Sprintf("  %s:   %s\n", i18n.T("status.detail.workspace"), workspaceStyle(proj.WorkspaceType, root.Color).Render(string(proj.WorkspaceType)))
 
 	s += fmt.Sprintf("  %s:   %s\n", i18n.T("status.detail.root"), proj.Root)
 
+	if root.Description != "" {
+
+		s += fmt.Sprintf("  %s:   %s\n", i18n.T("status.detail.description"), root.Description)
+
+	}
+
 	s += "\n"
 
 	// Git 情報
@@ -314,6 +354,18 @@ func (m StatusModel) renderDetailView() string {
 
 	s += fmt.Sprintf("  %s:  %s\n", i18n.T("status.detail.gitManaged"), row.GitManaged)
 
+	if proj.WorktreeOf != "" {
+
+		s += fmt.Sprintf("  %s: %s\n", i18n.T("status.detail.worktreeOf"), proj.WorktreeOf)
+
+	}
+
+	if proj.HasSubmodules {
+
+		s += fmt.Sprintf("  %s: %s\n", i18n.T("status.detail.submodules"), filepath.Join(proj.Path, ".gitmodules"))
+
+	}
+
 	s += fmt.Sprintf("  %s:     %s\n", i18n.T("status.detail.status"), getStatusStyle(row.Status).Render(row.Status))
 
 	if proj.Branch != "" {
@@ -322,6 +374,27 @@ func (m StatusModel) renderDetailView() string {
 
 	}
 
+	// 詳細な git 状態（読み込み済みの場合のみ）
+
+	if proj.Git.Loaded {
+
+		if proj.Git.HasUpstream() {
+
+			s += fmt.Sprintf("  %s: %s\n", i18n.T("status.detail.upstream"), proj.Git.Upstream)
+
+		}
+
+		s += fmt.Sprintf("  %s: %s\n", i18n.T("status.detail.sync"), row.Sync)
+
+		s += fmt.Sprintf("  %s: %s\n", i18n.T("status.detail.changes"),
+			fmt.Sprintf(i18n.T("status.detail.changeCounts"), proj.Git.Staged, proj.Git.Unstaged, proj.Git.Untracked, proj.Git.Conflicted))
+
+		s += fmt.Sprintf("  %s: %s\n", i18n.T("status.detail.stash"), row.Stash)
+
+		s += fmt.Sprintf("  %s: %s\n", i18n.T("status.detail.lastCommit"), row.LastCommit)
+
+	}
+
 	s += "\n"
 
 	// メッセージ
@@ -356,13 +429,21 @@ func (m StatusModel) renderProjectRow(row ProjectRow, selected bool) string {
 
 	repoCell := lipgloss.NewStyle().Width(30).Align(lipgloss.Left).Render(row.Repo)
 
-	workspaceCell := lipgloss.NewStyle().Width(10).Align(lipgloss.Left).Render(row.Workspace) // Renamed from zoneCell and updated to row.Workspace
+	// The workspace is colored like in the detail view, honoring the root's configured color
+
+	root := m.workspace(row.RawProject.Root)
+
+	workspaceCell := workspaceStyle(row.RawProject.WorkspaceType, root.Color).Width(10).Align(lipgloss.Left).Render(row.Workspace)
 
 	gitManagedCell := lipgloss.NewStyle().Width(10).Align(lipgloss.Left).Render(row.GitManaged)
 
 	statusCell := lipgloss.NewStyle().Width(8).Align(lipgloss.Left).Render(row.Status)
 
-	line := fmt.Sprintf("%s %s %s %s", repoCell, workspaceCell, gitManagedCell, statusCell)
+	syncCell := lipgloss.NewStyle().Width(12).Align(lipgloss.Left).Render(row.Sync)
+
+	changesCell := lipgloss.NewStyle().Width(12).Align(lipgloss.Left).Render(row.Changes)
+
+	line := fmt.Sprintf("%s %s %s %s %s %s", repoCell, workspaceCell, gitManagedCell, statusCell, syncCell, changesCell)
 
 	// 選択行はハイライト
 
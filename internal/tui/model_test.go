@@ -380,3 +380,40 @@ func TestCtrlCQuit(t *testing.T) {
 		t.Error("Ctrl+C should return quit command")
 	}
 }
+
+func TestRenderDetailViewWithGitStatus(t *testing.T) {
+	cfg := &config.Config{
+		Roots:   map[string]string{"dev": "/tmp"},
+		Default: config.DefaultConfig{Root: "dev"},
+	}
+	model := NewStatusModel(app.New(cfg))
+
+	proj := domain.Project{
+		DisplayName: "user/repo",
+		Root:        "dev",
+		HasGit:      true,
+		Branch:      "main",
+		Git: domain.GitStatus{
+			Loaded:    true,
+			Upstream:  "origin/main",
+			Ahead:     2,
+			Untracked: 1,
+			Stashes:   1,
+		},
+	}
+	model.projects = []ProjectRow{NewProjectRow(status.NewProjectDisplay(proj))}
+	model.viewState = ViewStateList
+	model.showDetail = true
+
+	view := model.View()
+	for _, want := range []string{"origin/main", "↑2", "1"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("detail view should contain %q:\n%s", want, view)
+		}
+	}
+
+	model.showDetail = false
+	if view := model.View(); !strings.Contains(view, "?1") {
+		t.Errorf("list view should contain change counts:\n%s", view)
+	}
+}
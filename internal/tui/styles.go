@@ -2,6 +2,7 @@ package tui
 
 import (
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mi8bi/ghqx/internal/domain"
 	"github.com/mi8bi/ghqx/internal/i18n"
 )
 
@@ -89,6 +90,15 @@ func getWorkspaceStyle(workspace string) lipgloss.Style { // Renamed parameter
 	}
 }
 
+// workspaceStyle はワークスペース種別に応じたスタイルを返す
+// 設定で色が指定されている場合はその色を優先する
+func workspaceStyle(workspaceType domain.WorkspaceType, color string) lipgloss.Style {
+	if color != "" {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(color))
+	}
+	return getWorkspaceStyle(string(workspaceType))
+}
+
 // getStatusStyle はステータスに応じたスタイルを返す
 func getStatusStyle(status string) lipgloss.Style {
 	switch status {
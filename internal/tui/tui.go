@@ -3,11 +3,14 @@ package tui
 import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/mi8bi/ghqx/internal/app"
+	"github.com/mi8bi/ghqx/internal/status"
 )
 
 // RunStatus は status TUI を起動する
-func RunStatus(application *app.App) error {
+// opts のフィルタ・ソート条件で表示するプロジェクトを絞り込む
+func RunStatus(application *app.App, opts status.Options) error {
 	model := NewStatusModel(application)
+	model.opts = opts
 
 	p := tea.NewProgram(
 		model,
@@ -53,6 +53,12 @@ func FormatSuccess(message string) string {
 	return fmt.Sprintf("%s %s\n", i18n.T("ui.success.prefix"), message)
 }
 
+// FormatFailure formats a one-line failure message.
+// Use FormatError for errors that carry hints.
+func FormatFailure(message string) string {
+	return fmt.Sprintf("%s %s\n", i18n.T("ui.failure.prefix"), message)
+}
+
 // FormatWarning formats a warning message.
 func FormatWarning(message string) string {
 	return fmt.Sprintf("%s %s\n", i18n.T("ui.warning.prefix"), message)
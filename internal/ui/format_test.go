@@ -2,6 +2,7 @@ package ui
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/mi8bi/ghqx/internal/domain"
@@ -44,3 +45,10 @@ func TestFormatMessages(t *testing.T) {
 		}
 	})
 }
+
+func TestFormatFailure(t *testing.T) {
+	out := FormatFailure("boom")
+	if !strings.Contains(out, "boom") || !strings.HasSuffix(out, "\n") {
+		t.Errorf("unexpected FormatFailure output: %q", out)
+	}
+}
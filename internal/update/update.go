@@ -0,0 +1,187 @@
+package update
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mi8bi/ghqx/internal/config"
+	"github.com/mi8bi/ghqx/internal/domain"
+	"github.com/mi8bi/ghqx/internal/git"
+	"github.com/mi8bi/ghqx/internal/status"
+)
+
+// ResultStatus describes what happened to a single repository during an update.
+type ResultStatus string
+
+const (
+	// StatusUpdated means new commits were fetched (and merged unless fetch-only)
+	StatusUpdated ResultStatus = "updated"
+	// StatusUpToDate means the repository was already current
+	StatusUpToDate ResultStatus = "up-to-date"
+	// StatusSkippedDirty means the repository has uncommitted changes and was not touched
+	StatusSkippedDirty ResultStatus = "skipped-dirty"
+	// StatusNoUpstream means the current branch tracks no remote branch
+	StatusNoUpstream ResultStatus = "no-upstream"
+	// StatusFailed means fetching or merging failed
+	StatusFailed ResultStatus = "failed"
+)
+
+// Service updates git repositories across workspace roots.
+type Service struct {
+	// cfg holds the application configuration with root paths
+	cfg *config.Config
+	// status discovers the repositories to update
+	status *status.Service
+	// git runs fetch and merge operations
+	git *git.Client
+}
+
+// NewService creates a new update service.
+func NewService(cfg *config.Config, statusService *status.Service) *Service {
+	return &Service{
+		cfg:    cfg,
+		status: statusService,
+		// Network operations need far more time than the local status checks
+		git: git.NewClientWithTimeout(2 * time.Minute),
+	}
+}
+
+// Options configures an update run.
+type Options struct {
+	// Workspace restricts the update to a single root (optional)
+	Workspace string
+	// FetchOnly only fetches and never merges into the working tree
+	FetchOnly bool
+	// Parallel is the maximum number of repositories updated at once
+	Parallel int
+}
+
+// Result is the outcome of updating a single repository.
+type Result struct {
+	// Project is the repository that was processed
+	Project domain.Project
+	// Status summarizes what happened
+	Status ResultStatus
+	// Err holds the failure cause when Status is StatusFailed
+	Err error
+}
+
+// Run fetches every git repository and fast-forwards clean ones to their upstream.
+// Dirty repositories are skipped before any git command touches them.
+// onDone is called after each repository completes (may be nil, never called concurrently).
+// Results are sorted by workspace root and project name.
+func (s *Service) Run(opts Options, onDone func(Result)) ([]Result, error) {
+	repos, err := s.status.GetAll(status.Options{
+		CheckDirty: true,
+		Filter:     status.Filter{Workspace: opts.Workspace, Git: true},
+		Sort:       status.SortWorkspace,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	parallel := opts.Parallel
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	results := make([]Result, len(repos))
+	sem := make(chan struct{}, parallel) // Limits concurrent git processes
+	var wg sync.WaitGroup
+	var doneMu sync.Mutex // Serializes onDone calls
+
+	for i, p := range repos {
+		wg.Add(1)
+		go func(i int, p domain.Project) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i] = s.updateRepository(p, opts.FetchOnly)
+
+			if onDone != nil {
+				doneMu.Lock()
+				onDone(results[i])
+				doneMu.Unlock()
+			}
+		}(i, p)
+	}
+
+	wg.Wait()
+
+	return results, nil
+}
+
+// updateRepository fetches a single repository and fast-forwards it when allowed.
+func (s *Service) updateRepository(p domain.Project, fetchOnly bool) Result {
+	result := Result{Project: p}
+
+	// Re-check right before touching the repository; the scan result may be stale
+	dirty, err := s.git.IsDirty(p.Path)
+	if err != nil {
+		return failed(result, err)
+	}
+	if p.Dirty || dirty {
+		result.Status = StatusSkippedDirty
+		return result
+	}
+
+	if !s.git.HasUpstream(p.Path) {
+		// Still fetch so remote refs stay current, but there is nothing to merge
+		if err := s.git.Fetch(p.Path); err != nil {
+			return failed(result, err)
+		}
+		result.Status = StatusNoUpstream
+		return result
+	}
+
+	// Compare the upstream before and after fetching to detect new commits
+	before, _ := s.git.RevParse(p.Path, "@{u}")
+	if err := s.git.Fetch(p.Path); err != nil {
+		return failed(result, err)
+	}
+	upstream, err := s.git.RevParse(p.Path, "@{u}")
+	if err != nil {
+		return failed(result, err)
+	}
+
+	if fetchOnly {
+		result.Status = StatusUpToDate
+		if upstream != before {
+			result.Status = StatusUpdated
+		}
+		return result
+	}
+
+	head, err := s.git.RevParse(p.Path, "HEAD")
+	if err != nil {
+		return failed(result, err)
+	}
+	if head == upstream {
+		result.Status = StatusUpToDate
+		return result
+	}
+
+	if err := s.git.MergeFastForward(p.Path); err != nil {
+		return failed(result, err)
+	}
+
+	newHead, err := s.git.RevParse(p.Path, "HEAD")
+	if err != nil {
+		return failed(result, err)
+	}
+
+	// HEAD ahead of upstream (local commits only) stays where it is
+	result.Status = StatusUpToDate
+	if newHead != head {
+		result.Status = StatusUpdated
+	}
+	return result
+}
+
+// failed marks a result as failed with the given cause.
+func failed(result Result, err error) Result {
+	result.Status = StatusFailed
+	result.Err = err
+	return result
+}
@@ -0,0 +1,169 @@
+package update
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/mi8bi/ghqx/internal/config"
+	"github.com/mi8bi/ghqx/internal/status"
+)
+
+// runGit runs a git command in dir and fails the test on error.
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.email=t@example.com", "-c", "user.name=t", "-c", "commit.gpgsign=false"}, args...)...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %v: %s", args, err, out)
+	}
+}
+
+// setup creates a remote repository and two clones of it inside a dev root.
+// It returns the config, the remote path and the two clone paths.
+func setup(t *testing.T) (*config.Config, string, string, string) {
+	t.Helper()
+	if exec.Command("git", "--version").Run() != nil {
+		t.Skip("git not available on this system")
+	}
+
+	tmp := t.TempDir()
+	remote := filepath.Join(tmp, "remote")
+	if err := os.MkdirAll(remote, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	runGit(t, remote, "init")
+	runGit(t, remote, "commit", "--allow-empty", "-m", "init")
+
+	dev := filepath.Join(tmp, "dev")
+	clean := filepath.Join(dev, "example.com", "user", "clean")
+	dirty := filepath.Join(dev, "example.com", "user", "dirty")
+	for _, p := range []string{clean, dirty} {
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		runGit(t, tmp, "clone", "--quiet", remote, p)
+	}
+
+	// New upstream commit that both clones are missing
+	runGit(t, remote, "commit", "--allow-empty", "-m", "second")
+
+	// Uncommitted change in one clone
+	if err := os.WriteFile(filepath.Join(dirty, "wip.txt"), []byte("wip"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	cfg := &config.Config{Roots: map[string]string{"dev": dev}}
+	return cfg, remote, clean, dirty
+}
+
+func findResult(t *testing.T, results []Result, path string) Result {
+	t.Helper()
+	for _, r := range results {
+		if r.Project.Path == path {
+			return r
+		}
+	}
+	t.Fatalf("no result for %s", path)
+	return Result{}
+}
+
+func TestRunUpdatesCleanAndSkipsDirty(t *testing.T) {
+	cfg, _, clean, dirty := setup(t)
+	s := NewService(cfg, status.NewService(cfg))
+
+	calls := 0
+	results, err := s.Run(Options{Parallel: 2}, func(Result) { calls++ })
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if calls != len(results) {
+		t.Errorf("expected onDone for every result, got %d of %d", calls, len(results))
+	}
+
+	if r := findResult(t, results, clean); r.Status != StatusUpdated {
+		t.Errorf("expected clean repo to be updated, got %s (%v)", r.Status, r.Err)
+	}
+	if r := findResult(t, results, dirty); r.Status != StatusSkippedDirty {
+		t.Errorf("expected dirty repo to be skipped, got %s", r.Status)
+	}
+
+	// The dirty clone must not even have fetched
+	out, err := exec.Command("git", "-C", dirty, "rev-list", "--count", "HEAD..@{u}").Output()
+	if err != nil || string(out) != "0\n" {
+		t.Errorf("dirty repo should be untouched, got %q, %v", out, err)
+	}
+
+	// A second run has nothing left to do
+	results, err = s.Run(Options{}, nil)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if r := findResult(t, results, clean); r.Status != StatusUpToDate {
+		t.Errorf("expected up-to-date on second run, got %s", r.Status)
+	}
+}
+
+func TestRunFetchOnly(t *testing.T) {
+	cfg, _, clean, _ := setup(t)
+	s := NewService(cfg, status.NewService(cfg))
+
+	results, err := s.Run(Options{FetchOnly: true}, nil)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if r := findResult(t, results, clean); r.Status != StatusUpdated {
+		t.Errorf("expected fetch to bring new commits, got %s (%v)", r.Status, r.Err)
+	}
+
+	// HEAD stays behind upstream because nothing was merged
+	out, err := exec.Command("git", "-C", clean, "rev-list", "--count", "HEAD..@{u}").Output()
+	if err != nil || string(out) != "1\n" {
+		t.Errorf("expected HEAD to remain one commit behind, got %q, %v", out, err)
+	}
+}
+
+func TestRunReportsDivergedAsFailed(t *testing.T) {
+	cfg, _, clean, _ := setup(t)
+	runGit(t, clean, "commit", "--allow-empty", "-m", "local")
+
+	s := NewService(cfg, status.NewService(cfg))
+	results, err := s.Run(Options{}, nil)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if r := findResult(t, results, clean); r.Status != StatusFailed || r.Err == nil {
+		t.Errorf("expected diverged branch to fail, got %s", r.Status)
+	}
+}
+
+func TestRunNoUpstream(t *testing.T) {
+	if exec.Command("git", "--version").Run() != nil {
+		t.Skip("git not available on this system")
+	}
+
+	dev := t.TempDir()
+	repo := filepath.Join(dev, "example.com", "user", "local")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	runGit(t, repo, "init")
+	runGit(t, repo, "commit", "--allow-empty", "-m", "init")
+
+	cfg := &config.Config{Roots: map[string]string{"dev": dev}}
+	results, err := NewService(cfg, status.NewService(cfg)).Run(Options{}, nil)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if r := findResult(t, results, repo); r.Status != StatusNoUpstream {
+		t.Errorf("expected no-upstream, got %s (%v)", r.Status, r.Err)
+	}
+}
+
+func TestRunUnknownWorkspace(t *testing.T) {
+	cfg := &config.Config{Roots: map[string]string{"dev": t.TempDir()}}
+	if _, err := NewService(cfg, status.NewService(cfg)).Run(Options{Workspace: "nope"}, nil); err == nil {
+		t.Fatal("expected error for unknown workspace")
+	}
+}
@@ -0,0 +1,252 @@
+package vcs
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mi8bi/ghqx/internal/domain"
+	"github.com/mi8bi/ghqx/internal/git"
+)
+
+// DefaultTimeout bounds each command of the non-git backends.
+// Mercurial and Fossil start slower than git, so this is longer than the git client's timeout.
+const DefaultTimeout = 500 * time.Millisecond
+
+// Backend runs the checks ghqx needs against one kind of repository.
+type Backend interface {
+	// IsDirty reports whether the working copy has uncommitted changes (untracked files included)
+	IsDirty(path string) (bool, error)
+	// Branch returns the current branch, or the closest equivalent of the VCS
+	Branch(path string) (string, error)
+}
+
+// Registry hands out the backends whose tools are installed on this machine.
+// It is safe for concurrent use; tool lookups are done once per kind.
+type Registry struct {
+	// timeout bounds each command of the non-git backends
+	timeout time.Duration
+	// lookPath finds a tool's executable (exec.LookPath, replaced in tests)
+	lookPath func(file string) (string, error)
+
+	mu sync.Mutex
+	// backends caches the lookup result per kind; nil means the tool is not installed
+	backends map[domain.VCSKind]Backend
+}
+
+// NewRegistry creates a registry with the default command timeout.
+func NewRegistry() *Registry {
+	return NewRegistryWithTimeout(DefaultTimeout)
+}
+
+// NewRegistryWithTimeout creates a registry with a custom command timeout.
+// This is primarily intended for testing purposes.
+func NewRegistryWithTimeout(timeout time.Duration) *Registry {
+	return &Registry{
+		timeout:  timeout,
+		lookPath: exec.LookPath,
+		backends: make(map[domain.VCSKind]Backend),
+	}
+}
+
+// Backend returns the backend for kind. It returns false for plain directories,
+// unknown kinds and kinds whose tool is not installed.
+func (r *Registry) Backend(kind domain.VCSKind) (Backend, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, cached := r.backends[kind]
+	if !cached {
+		b = r.newBackend(kind)
+		r.backends[kind] = b
+	}
+	return b, b != nil
+}
+
+// newBackend creates the backend for kind, or nil if it cannot be used.
+func (r *Registry) newBackend(kind domain.VCSKind) Backend {
+	tool := Tool(kind)
+	if tool == "" {
+		return nil
+	}
+	if _, err := r.lookPath(tool); err != nil {
+		return nil
+	}
+
+	switch kind {
+	case domain.VCSGit:
+		return gitBackend{git.NewClient()}
+	case domain.VCSBareGit:
+		return bareGitBackend{git.NewClient()}
+	case domain.VCSMercurial:
+		return &commandBackend{
+			tool:       tool,
+			timeout:    r.timeout,
+			env:        []string{"HGPLAIN=1"}, // Stable, unlocalized output
+			dirtyArgs:  []string{"status"},
+			branchArgs: []string{"branch"},
+		}
+	case domain.VCSSubversion:
+		return &commandBackend{
+			tool:        tool,
+			timeout:     r.timeout,
+			dirtyArgs:   []string{"status", "--ignore-externals", "--non-interactive"},
+			branchArgs:  []string{"info", "--show-item", "relative-url", "--non-interactive"},
+			parseBranch: svnBranch,
+		}
+	case domain.VCSFossil:
+		return &commandBackend{
+			tool:       tool,
+			timeout:    r.timeout,
+			dirtyArgs:  []string{"changes"},
+			branchArgs: []string{"branch", "current"},
+		}
+	case domain.VCSJujutsu:
+		return &commandBackend{
+			tool:    tool,
+			timeout: r.timeout,
+			// The working-copy commit holds the uncommitted changes
+			dirtyArgs: []string{"diff", "--summary", "--color=never"},
+			// Bookmarks usually sit on an ancestor of the working-copy commit
+			branchArgs:  []string{"log", "--no-graph", "--color=never", "-r", "latest(::@ & bookmarks())", "-T", "bookmarks"},
+			parseBranch: jjBranch,
+		}
+	}
+	return nil
+}
+
+// Tool returns the executable used for kind, or "" for plain directories.
+func Tool(kind domain.VCSKind) string {
+	switch kind {
+	case domain.VCSGit, domain.VCSBareGit:
+		return "git"
+	case domain.VCSMercurial:
+		return "hg"
+	case domain.VCSSubversion:
+		return "svn"
+	case domain.VCSFossil:
+		return "fossil"
+	case domain.VCSJujutsu:
+		return "jj"
+	}
+	return ""
+}
+
+// gitBackend adapts the git client to Backend.
+type gitBackend struct {
+	client *git.Client
+}
+
+func (b gitBackend) IsDirty(path string) (bool, error) {
+	return b.client.IsDirty(path)
+}
+
+func (b gitBackend) Branch(path string) (string, error) {
+	return b.client.GetBranch(path)
+}
+
+// bareGitBackend handles bare repositories, which have no working tree to be dirty.
+type bareGitBackend struct {
+	client *git.Client
+}
+
+func (b bareGitBackend) IsDirty(path string) (bool, error) {
+	return false, nil
+}
+
+func (b bareGitBackend) Branch(path string) (string, error) {
+	return b.client.GetBranch(path)
+}
+
+// commandBackend runs a VCS tool whose dirty check is "any output means changes".
+type commandBackend struct {
+	tool    string
+	timeout time.Duration
+	// env is added to the environment of every command
+	env        []string
+	dirtyArgs  []string
+	branchArgs []string
+	// parseBranch turns the branch command's output into a branch name (nil: trimmed output)
+	parseBranch func(out string) string
+}
+
+func (b *commandBackend) IsDirty(path string) (bool, error) {
+	out, err := b.run(path, "status", b.dirtyArgs...)
+	if err != nil {
+		return false, err
+	}
+	return out != "", nil
+}
+
+func (b *commandBackend) Branch(path string) (string, error) {
+	out, err := b.run(path, "branch", b.branchArgs...)
+	if err != nil {
+		return "", err
+	}
+	if b.parseBranch != nil {
+		return b.parseBranch(out), nil
+	}
+	return out, nil
+}
+
+// run executes the tool in path with the backend's timeout.
+// op names the operation in returned errors (e.g., "status").
+func (b *commandBackend) run(path, op string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, b.tool, args...)
+	cmd.Dir = path
+	if len(b.env) > 0 {
+		cmd.Env = append(os.Environ(), b.env...)
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", domain.ErrVCSTimeout(b.tool, op)
+		}
+		vcsErr := domain.ErrVCSCommandFailed(b.tool, op, err)
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			vcsErr = vcsErr.WithInternal(msg)
+		}
+		return "", vcsErr
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// svnBranch derives a branch name from a repository-relative URL:
+// "^/trunk" is trunk, "^/branches/x/..." is x and "^/tags/v1" is v1.
+// Other layouts return the relative URL unchanged.
+func svnBranch(out string) string {
+	parts := strings.Split(strings.TrimPrefix(out, "^/"), "/")
+	for i, part := range parts {
+		switch part {
+		case "trunk":
+			return part
+		case "branches", "tags":
+			if i+1 < len(parts) {
+				return parts[i+1]
+			}
+		}
+	}
+	return out
+}
+
+// jjBranch returns the first bookmark of "jj log -T bookmarks" output,
+// without the markers jj adds for conflicted or unpushed bookmarks.
+func jjBranch(out string) string {
+	fields := strings.Fields(out)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.TrimRight(fields[0], "*?")
+}
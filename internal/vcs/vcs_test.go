@@ -0,0 +1,144 @@
+package vcs
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mi8bi/ghqx/internal/domain"
+)
+
+// fakeTool installs an executable shell script named tool that runs script,
+// and puts it first on PATH for the rest of the test.
+func fakeTool(t *testing.T, tool, script string) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, tool), []byte("#!/bin/sh\n"+script), 0755); err != nil {
+		t.Fatalf("write %s: %v", tool, err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestRegistryWithoutTools(t *testing.T) {
+	r := NewRegistry()
+	r.lookPath = func(string) (string, error) { return "", exec.ErrNotFound }
+
+	for _, kind := range []domain.VCSKind{domain.VCSGit, domain.VCSMercurial, domain.VCSSubversion, domain.VCSFossil, domain.VCSJujutsu, domain.VCSNone, "darcs"} {
+		if _, ok := r.Backend(kind); ok {
+			t.Errorf("Backend(%q) should be unavailable", kind)
+		}
+	}
+}
+
+func TestCommandBackend(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	fakeTool(t, "hg", `
+case "$1" in
+status) [ "$HGPLAIN" = 1 ] && echo "M file.txt" ;;
+branch) echo default ;;
+esac
+`)
+
+	b, ok := NewRegistry().Backend(domain.VCSMercurial)
+	if !ok {
+		t.Fatal("hg backend should be available")
+	}
+	dir := t.TempDir()
+	if dirty, err := b.IsDirty(dir); err != nil || !dirty {
+		t.Errorf("IsDirty = %v, %v", dirty, err)
+	}
+	if branch, err := b.Branch(dir); err != nil || branch != "default" {
+		t.Errorf("Branch = %q, %v", branch, err)
+	}
+}
+
+func TestCommandBackendErrors(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	fakeTool(t, "fossil", `
+case "$1" in
+changes) echo "not within an open checkout" >&2; exit 1 ;;
+branch) exec sleep 5 ;;
+esac
+`)
+
+	b, ok := NewRegistryWithTimeout(100 * time.Millisecond).Backend(domain.VCSFossil)
+	if !ok {
+		t.Fatal("fossil backend should be available")
+	}
+
+	_, err := b.IsDirty(t.TempDir())
+	var ghqxErr *domain.GhqxError
+	if !errors.As(err, &ghqxErr) || ghqxErr.Code != domain.ErrCodeCommandFailed || ghqxErr.Internal == "" {
+		t.Errorf("expected command failure with stderr, got %#v", err)
+	}
+
+	if _, err := b.Branch(t.TempDir()); err == nil {
+		t.Error("expected timeout error")
+	}
+}
+
+func TestGitBackends(t *testing.T) {
+	if exec.Command("git", "--version").Run() != nil {
+		t.Skip("git not available")
+	}
+
+	bare := filepath.Join(t.TempDir(), "repo.git")
+	if err := exec.Command("git", "init", "--quiet", "--bare", "--initial-branch=main", bare).Run(); err != nil {
+		t.Skipf("git init --bare: %v", err)
+	}
+
+	b, ok := NewRegistry().Backend(domain.VCSBareGit)
+	if !ok {
+		t.Fatal("bare git backend should be available")
+	}
+	if dirty, err := b.IsDirty(bare); err != nil || dirty {
+		t.Errorf("bare repository should never be dirty: %v, %v", dirty, err)
+	}
+
+	if _, ok := NewRegistry().Backend(domain.VCSGit); !ok {
+		t.Error("git backend should be available")
+	}
+}
+
+func TestSvnBranch(t *testing.T) {
+	testCases := map[string]string{
+		"^/trunk":                 "trunk",
+		"^/trunk/src":             "trunk",
+		"^/branches/feature/docs": "feature",
+		"^/project/tags/v1.0":     "v1.0",
+		"^/custom/layout":         "^/custom/layout",
+	}
+	for in, want := range testCases {
+		if got := svnBranch(in); got != want {
+			t.Errorf("svnBranch(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestJjBranch(t *testing.T) {
+	testCases := map[string]string{
+		"":              "",
+		"main":          "main",
+		"main* feature": "main",
+		"conflicted??":  "conflicted",
+		"  dev  ":       "dev",
+	}
+	for in, want := range testCases {
+		if got := jjBranch(in); got != want {
+			t.Errorf("jjBranch(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestTool(t *testing.T) {
+	if Tool(domain.VCSBareGit) != "git" || Tool(domain.VCSJujutsu) != "jj" || Tool(domain.VCSNone) != "" {
+		t.Error("unexpected tool names")
+	}
+}
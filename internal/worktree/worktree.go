@@ -0,0 +1,204 @@
+package worktree
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mi8bi/ghqx/internal/config"
+	"github.com/mi8bi/ghqx/internal/domain"
+	"github.com/mi8bi/ghqx/internal/fs"
+	"github.com/mi8bi/ghqx/internal/git"
+	"github.com/mi8bi/ghqx/internal/status"
+)
+
+// Separator joins the repository and branch in a worktree's directory name,
+// so a worktree of github.com/user/repo on branch fix is github.com/user/repo@fix.
+const Separator = "@"
+
+// Service creates, lists and removes linked git worktrees of workspace projects.
+// Worktrees are laid out next to other projects as <host>/<owner>/<repo>@<branch>,
+// so the scanner finds them like any other repository.
+type Service struct {
+	// cfg holds the application configuration with root paths
+	cfg *config.Config
+	// status locates projects and worktrees
+	status *status.Service
+	// git runs the worktree commands
+	git *git.Client
+}
+
+// NewService creates a new worktree service.
+func NewService(cfg *config.Config, statusService *status.Service) *Service {
+	return &Service{
+		cfg:    cfg,
+		status: statusService,
+		// Checking out a worktree writes the whole tree, so allow much more time than the status scan does
+		git: git.NewClientWithTimeout(time.Minute),
+	}
+}
+
+// AddOptions configures the creation of a worktree.
+type AddOptions struct {
+	// Project is the fully qualified name of the repository (e.g. "github.com/user/repo")
+	Project string
+	// Branch is checked out in the worktree, and created from HEAD if it does not exist
+	Branch string
+	// From restricts the project lookup to a single source root (optional)
+	From string
+	// Workspace is the root to create the worktree in (default: the project's root)
+	Workspace string
+}
+
+// Result describes a created or removed worktree.
+type Result struct {
+	// Repository is the path of the main repository
+	Repository string
+	// Root is the workspace root the worktree lives in
+	Root string
+	// Path is the absolute path of the worktree
+	Path string
+}
+
+// Add creates a linked worktree of a project checked out on a branch.
+// An existing directory at the worktree path is never overwritten.
+func (s *Service) Add(opts AddOptions) (*Result, error) {
+	if opts.Project == "" || opts.Branch == "" {
+		return nil, domain.ErrArgumentRequired
+	}
+
+	if opts.From != "" {
+		if _, ok := s.cfg.GetRoot(opts.From); !ok {
+			return nil, domain.ErrRootNotFound(opts.From)
+		}
+	}
+
+	project, err := s.status.FindProject(opts.Project, opts.From)
+	if err != nil {
+		return nil, err
+	}
+	if !project.HasGit {
+		return nil, domain.ErrWorktreeNotGit(project.Name)
+	}
+
+	targetRoot := string(project.Root)
+	if opts.Workspace != "" {
+		targetRoot = opts.Workspace
+	}
+	targetRootPath, ok := s.cfg.GetRoot(targetRoot)
+	if !ok {
+		return nil, domain.ErrRootNotFound(targetRoot)
+	}
+	if s.cfg.IsReadOnly(targetRoot) {
+		return nil, domain.ErrRootReadOnly(targetRoot)
+	}
+
+	// Worktrees of worktrees are laid out next to the main repository's other worktrees
+	repository, name := project.Path, project.Name
+	if project.WorktreeOf != "" {
+		repository = project.WorktreeOf
+		name, _, _ = strings.Cut(name, Separator)
+	}
+
+	targetPath := filepath.Join(targetRootPath, filepath.FromSlash(Name(name, opts.Branch)))
+	if _, err := os.Lstat(targetPath); err == nil {
+		return nil, domain.ErrProjectAlreadyExists(targetPath)
+	}
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+		return nil, domain.ErrFSCreateDir(err)
+	}
+
+	if err := s.git.WorktreeAdd(repository, targetPath, opts.Branch); err != nil {
+		fs.RemoveEmptyParents(filepath.Dir(targetPath), targetRootPath)
+		return nil, err
+	}
+
+	return &Result{Repository: repository, Root: targetRoot, Path: targetPath}, nil
+}
+
+// List returns the linked worktrees in all roots, sorted by name.
+// When project is not empty, only worktrees of that repository are returned.
+func (s *Service) List(project string) ([]domain.Project, error) {
+	projects, err := s.status.GetAll(status.Options{LoadBranch: true})
+	if err != nil {
+		return nil, err
+	}
+
+	repository := ""
+	if project != "" {
+		main, err := s.status.FindProject(project)
+		if err != nil {
+			return nil, err
+		}
+		repository = main.Path
+		if main.WorktreeOf != "" {
+			repository = main.WorktreeOf
+		}
+	}
+
+	var worktrees []domain.Project
+	for _, p := range projects {
+		if p.WorktreeOf == "" || (repository != "" && p.WorktreeOf != repository) {
+			continue
+		}
+		worktrees = append(worktrees, p)
+	}
+
+	sort.Slice(worktrees, func(i, j int) bool {
+		return worktrees[i].Name < worktrees[j].Name
+	})
+	return worktrees, nil
+}
+
+// Remove deletes a linked worktree and its administrative files in the main repository.
+// Worktrees with uncommitted changes are refused unless force is set.
+func (s *Service) Remove(name, workspace string, force bool) (*Result, error) {
+	if name == "" {
+		return nil, domain.ErrArgumentRequired
+	}
+
+	if workspace != "" {
+		if _, ok := s.cfg.GetRoot(workspace); !ok {
+			return nil, domain.ErrRootNotFound(workspace)
+		}
+	}
+
+	project, err := s.status.FindProject(name, workspace)
+	if err != nil {
+		return nil, err
+	}
+	if project.WorktreeOf == "" {
+		return nil, domain.ErrWorktreeNotLinked(project.Name)
+	}
+	if s.cfg.IsReadOnly(string(project.Root)) {
+		return nil, domain.ErrRootReadOnly(string(project.Root))
+	}
+
+	if !force {
+		dirty, err := s.git.IsDirty(project.Path)
+		if err != nil {
+			return nil, err
+		}
+		if dirty {
+			return nil, domain.ErrGitDirtyRepo
+		}
+	}
+
+	if err := s.git.WorktreeRemove(project.WorktreeOf, project.Path, force); err != nil {
+		return nil, err
+	}
+
+	rootPath, _ := s.cfg.GetRoot(string(project.Root))
+	fs.RemoveEmptyParents(filepath.Dir(project.Path), rootPath)
+
+	return &Result{Repository: project.WorktreeOf, Root: string(project.Root), Path: project.Path}, nil
+}
+
+// Name returns the project name of the worktree of a repository on a branch.
+// Slashes in the branch name (e.g. "feature/x") become dashes to keep the
+// host/owner/repo layout.
+func Name(repository, branch string) string {
+	return repository + Separator + strings.ReplaceAll(branch, "/", "-")
+}
@@ -0,0 +1,184 @@
+package worktree
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/mi8bi/ghqx/internal/config"
+	"github.com/mi8bi/ghqx/internal/domain"
+	"github.com/mi8bi/ghqx/internal/status"
+)
+
+// setup creates dev and sandbox roots with a committed repository at dev/github.com/user/repo.
+func setup(t *testing.T) (*config.Config, string) {
+	t.Helper()
+	if exec.Command("git", "--version").Run() != nil {
+		t.Skip("git not available")
+	}
+
+	tmp := t.TempDir()
+	cfg := &config.Config{
+		Roots: map[string]string{
+			"dev":     filepath.Join(tmp, "dev"),
+			"sandbox": filepath.Join(tmp, "sandbox"),
+		},
+		Default: config.DefaultConfig{Root: "dev"},
+	}
+	for _, p := range cfg.Roots {
+		if err := os.MkdirAll(p, 0755); err != nil {
+			t.Fatalf("mkdir root: %v", err)
+		}
+	}
+
+	repo := filepath.Join(cfg.Roots["dev"], "github.com", "user", "repo")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "--allow-empty", "-m", "init"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	return cfg, repo
+}
+
+func newService(cfg *config.Config) *Service {
+	return NewService(cfg, status.NewService(cfg))
+}
+
+func TestAddListRemove(t *testing.T) {
+	cfg, repo := setup(t)
+	s := newService(cfg)
+
+	res, err := s.Add(AddOptions{Project: "github.com/user/repo", Branch: "feature/login"})
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	want := filepath.Join(cfg.Roots["dev"], "github.com", "user", "repo@feature-login")
+	if res.Path != want || res.Root != "dev" || res.Repository != repo {
+		t.Errorf("Add result = %+v", res)
+	}
+
+	// A second worktree in another workspace
+	if _, err := s.Add(AddOptions{Project: "github.com/user/repo", Branch: "review", Workspace: "sandbox"}); err != nil {
+		t.Fatalf("Add to sandbox failed: %v", err)
+	}
+
+	worktrees, err := s.List("")
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(worktrees) != 2 {
+		t.Fatalf("expected 2 worktrees, got %d", len(worktrees))
+	}
+	if worktrees[0].Name != "github.com/user/repo@feature-login" || worktrees[0].Branch != "feature/login" {
+		t.Errorf("unexpected worktree %+v", worktrees[0])
+	}
+	if worktrees[1].Root != "sandbox" || worktrees[1].WorktreeOf != repo {
+		t.Errorf("unexpected worktree %+v", worktrees[1])
+	}
+
+	// The existing worktree path is never overwritten
+	_, err = s.Add(AddOptions{Project: "github.com/user/repo", Branch: "feature/login"})
+	var ghqxErr *domain.GhqxError
+	if !errors.As(err, &ghqxErr) || ghqxErr.Code != domain.ErrCodeInvalidPath {
+		t.Errorf("expected already-exists error, got %v", err)
+	}
+
+	// Dirty worktrees are refused unless forced
+	if err := os.WriteFile(filepath.Join(want, "wip.txt"), []byte("wip"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := s.Remove("github.com/user/repo@feature-login", "", false); !errors.Is(err, domain.ErrGitDirtyRepo) {
+		t.Errorf("expected dirty error, got %v", err)
+	}
+	if _, err := os.Stat(want); err != nil {
+		t.Fatalf("dirty worktree must be kept: %v", err)
+	}
+	if _, err := s.Remove("github.com/user/repo@feature-login", "", true); err != nil {
+		t.Fatalf("forced Remove failed: %v", err)
+	}
+	if _, err := os.Stat(want); !os.IsNotExist(err) {
+		t.Error("worktree should be removed")
+	}
+
+	// Removing the last project of a root cleans up its host/owner directories
+	if _, err := s.Remove("github.com/user/repo@review", "sandbox", false); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(cfg.Roots["sandbox"], "github.com")); !os.IsNotExist(err) {
+		t.Error("expected empty parent directories to be removed")
+	}
+
+	worktrees, _ = s.List("github.com/user/repo")
+	if len(worktrees) != 0 {
+		t.Errorf("expected no worktrees left, got %d", len(worktrees))
+	}
+}
+
+func TestRemoveRefusesMainRepository(t *testing.T) {
+	cfg, _ := setup(t)
+	_, err := newService(cfg).Remove("github.com/user/repo", "", true)
+	var ghqxErr *domain.GhqxError
+	if !errors.As(err, &ghqxErr) || ghqxErr.Code != domain.ErrCodeInvalidPath {
+		t.Errorf("expected not-linked error, got %v", err)
+	}
+}
+
+func TestAddErrors(t *testing.T) {
+	cfg, _ := setup(t)
+	s := newService(cfg)
+
+	if err := os.MkdirAll(filepath.Join(cfg.Roots["dev"], "github.com", "user", "plain"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if _, err := s.Add(AddOptions{Project: "github.com/user/plain", Branch: "x"}); err == nil {
+		t.Error("expected error for non-git project")
+	}
+	if _, err := s.Add(AddOptions{Project: "github.com/user/repo", Branch: "x", Workspace: "nope"}); err == nil {
+		t.Error("expected error for unknown workspace")
+	}
+	if _, err := s.Add(AddOptions{Project: "github.com/user/repo"}); err != domain.ErrArgumentRequired {
+		t.Errorf("expected argument error, got %v", err)
+	}
+}
+
+func TestAddRefusesAmbiguousProject(t *testing.T) {
+	cfg, repo := setup(t)
+	s := newService(cfg)
+
+	// The same repository cloned into a second workspace
+	clone := filepath.Join(cfg.Roots["sandbox"], "github.com", "user", "repo")
+	if out, err := exec.Command("git", "clone", "--quiet", repo, clone).CombinedOutput(); err != nil {
+		t.Fatalf("git clone: %v\n%s", err, out)
+	}
+
+	_, err := s.Add(AddOptions{Project: "github.com/user/repo", Branch: "x"})
+	want := domain.ErrProjectAmbiguous("github.com/user/repo", []string{"dev", "sandbox"}).Message
+	var gerr *domain.GhqxError
+	if !errors.As(err, &gerr) || gerr.Message != want {
+		t.Fatalf("expected %q, got %v", want, err)
+	}
+
+	res, err := s.Add(AddOptions{Project: "github.com/user/repo", Branch: "x", From: "sandbox"})
+	if err != nil {
+		t.Fatalf("Add with From failed: %v", err)
+	}
+	if res.Repository != clone || res.Root != "sandbox" {
+		t.Errorf("Add result = %+v", res)
+	}
+}
+
+func TestName(t *testing.T) {
+	if got := Name("github.com/user/repo", "feature/x"); got != "github.com/user/repo@feature-x" {
+		t.Errorf("Name = %q", got)
+	}
+}